@@ -0,0 +1,100 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package archive
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-billy/v5/osfs"
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+	"github.com/pkg/errors"
+)
+
+// IgnoreFile is read from the root of a source directory. When it is missing,
+// the .gitignore files of the directory are used instead.
+const IgnoreFile = ".kpignore"
+
+// Ignorer decides which files of a source directory are left out of an
+// archive, using gitignore pattern semantics.
+type Ignorer struct {
+	matcher gitignore.Matcher
+}
+
+// NewIgnorer reads the ignore patterns of dir and appends the exclude patterns
+// so they take precedence over the patterns found in the directory.
+func NewIgnorer(dir string, excludes []string) (*Ignorer, error) {
+	patterns, err := readKpIgnore(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if patterns == nil {
+		patterns = []gitignore.Pattern{gitignore.ParsePattern(".git", nil)}
+
+		gitPatterns, err := gitignore.ReadPatterns(osfs.New(dir), nil)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read .gitignore files in '%s'", dir)
+		}
+		patterns = append(patterns, gitPatterns...)
+	}
+
+	for _, exclude := range excludes {
+		patterns = append(patterns, gitignore.ParsePattern(exclude, nil))
+	}
+
+	return &Ignorer{matcher: gitignore.NewMatcher(patterns)}, nil
+}
+
+// Ignored reports whether the slash separated path relative to the source
+// directory is ignored. A nil Ignorer ignores nothing.
+func (i *Ignorer) Ignored(relPath string, isDir bool) bool {
+	if i == nil {
+		return false
+	}
+	return i.matcher.Match(splitPath(relPath), isDir)
+}
+
+func splitPath(relPath string) []string {
+	return strings.Split(filepath.ToSlash(relPath), "/")
+}
+
+// readKpIgnore returns nil patterns when the directory has no ignore file and
+// an empty, non-nil slice when the file exists but has no patterns.
+func readKpIgnore(dir string) ([]gitignore.Pattern, error) {
+	f, err := os.Open(filepath.Join(dir, IgnoreFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	patterns := []gitignore.Pattern{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "#") || strings.TrimSpace(line) == "" {
+			continue
+		}
+		patterns = append(patterns, gitignore.ParsePattern(line, nil))
+	}
+
+	return patterns, errors.Wrapf(scanner.Err(), "failed to read %s", IgnoreFile)
+}
+
+// ListFiles returns the slash separated paths of the files in dir that are
+// not ignored, in the order they are added to an archive.
+func ListFiles(dir string, ignorer *Ignorer) ([]string, error) {
+	var files []string
+	err := walkDir(dir, ignorer, func(_, relPath string, fi os.FileInfo) error {
+		if !fi.IsDir() {
+			files = append(files, filepath.ToSlash(relPath))
+		}
+		return nil
+	})
+	return files, err
+}
@@ -0,0 +1,101 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package archive_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/archive"
+)
+
+func TestIgnore(t *testing.T) {
+	spec.Run(t, "Test ignore files", testIgnore)
+}
+
+func testIgnore(t *testing.T, when spec.G, it spec.S) {
+	var dir string
+
+	writeFile := func(path, contents string) {
+		fullPath := filepath.Join(dir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0755))
+		require.NoError(t, os.WriteFile(fullPath, []byte(contents), 0644))
+	}
+
+	it.Before(func() {
+		dir = t.TempDir()
+		writeFile("main.go", "package main")
+		writeFile("build/out.bin", "binary")
+		writeFile("node_modules/dep/index.js", "module")
+		writeFile("secrets/key.pem", "key")
+		writeFile("secrets/README.md", "readme")
+		writeFile(".git/HEAD", "ref: refs/heads/main")
+	})
+
+	when("the directory has a .kpignore file", func() {
+		it("only leaves out the files matching its patterns", func() {
+			writeFile(".kpignore", "# build outputs\nbuild/\nsecrets/*\n!secrets/README.md\n")
+			writeFile(".gitignore", "node_modules\n")
+
+			ignorer, err := archive.NewIgnorer(dir, nil)
+			require.NoError(t, err)
+
+			files, err := archive.ListFiles(dir, ignorer)
+			require.NoError(t, err)
+			require.Equal(t, []string{
+				".git/HEAD",
+				".gitignore",
+				".kpignore",
+				"main.go",
+				"node_modules/dep/index.js",
+				"secrets/README.md",
+			}, files)
+		})
+	})
+
+	when("the directory has no .kpignore file", func() {
+		it("leaves out the .git directory and the files matching .gitignore files", func() {
+			writeFile(".gitignore", "node_modules\n")
+			writeFile("secrets/.gitignore", "*.pem\n")
+
+			ignorer, err := archive.NewIgnorer(dir, nil)
+			require.NoError(t, err)
+
+			files, err := archive.ListFiles(dir, ignorer)
+			require.NoError(t, err)
+			require.Equal(t, []string{
+				".gitignore",
+				"build/out.bin",
+				"main.go",
+				"secrets/.gitignore",
+				"secrets/README.md",
+			}, files)
+		})
+	})
+
+	it("leaves out the files matching the excludes", func() {
+		writeFile(".kpignore", "!build/\n")
+
+		ignorer, err := archive.NewIgnorer(dir, []string{"build", "*.md", ".*"})
+		require.NoError(t, err)
+
+		files, err := archive.ListFiles(dir, ignorer)
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"main.go",
+			"node_modules/dep/index.js",
+			"secrets/key.pem",
+		}, files)
+	})
+
+	it("does not ignore files with a nil ignorer", func() {
+		files, err := archive.ListFiles(dir, nil)
+		require.NoError(t, err)
+		require.Len(t, files, 6)
+	})
+}
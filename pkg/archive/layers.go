@@ -0,0 +1,117 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package archive
+
+import (
+	"archive/tar"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+)
+
+// DependencyPatterns match the dependency manifests, lockfiles and vendored
+// directories that are packaged in their own source layer. They change less
+// often than the rest of the source, so their layer can usually be reused.
+var DependencyPatterns = []string{
+	"go.mod", "go.sum", "vendor/",
+	"package.json", "package-lock.json", "npm-shrinkwrap.json", "yarn.lock", "pnpm-lock.yaml", "node_modules/",
+	"requirements.txt", "Pipfile", "Pipfile.lock", "poetry.lock", "pyproject.toml",
+	"Gemfile", "Gemfile.lock",
+	"pom.xml", "build.gradle", "build.gradle.kts", "settings.gradle", "settings.gradle.kts", "gradle.lockfile",
+	"Cargo.toml", "Cargo.lock",
+	"composer.json", "composer.lock",
+	"packages.lock.json",
+}
+
+type tarEntry struct {
+	file    string
+	relPath string
+	fi      os.FileInfo
+}
+
+// CreateLayerTars writes the files of dir that are not ignored to temporary tar
+// files: one with the files matching DependencyPatterns, when there are any,
+// followed by one with the rest of the source. The tars are reproducible, so an
+// unchanged set of files always results in the same layer digest.
+func CreateLayerTars(dir string, ignorer *Ignorer) ([]string, error) {
+	var patterns []gitignore.Pattern
+	for _, p := range DependencyPatterns {
+		patterns = append(patterns, gitignore.ParsePattern(p, nil))
+	}
+	dependencies := gitignore.NewMatcher(patterns)
+
+	var (
+		dependencyEntries []tarEntry
+		sourceEntries     []tarEntry
+		sourceDirs        = map[string]tarEntry{}
+		dependencyDirs    = map[string]bool{}
+	)
+	err := walkDir(dir, ignorer, func(file, relPath string, fi os.FileInfo) error {
+		entry := tarEntry{file: file, relPath: filepath.ToSlash(relPath), fi: fi}
+
+		if !dependencies.Match(splitPath(entry.relPath), fi.IsDir()) {
+			sourceEntries = append(sourceEntries, entry)
+			if fi.IsDir() {
+				sourceDirs[entry.relPath] = entry
+			}
+			return nil
+		}
+
+		// parent directories are repeated so their modes are kept whichever layer is extracted first
+		var parents []tarEntry
+		for parent := path.Dir(entry.relPath); parent != "."; parent = path.Dir(parent) {
+			if parentEntry, ok := sourceDirs[parent]; ok && !dependencyDirs[parent] {
+				parents = append([]tarEntry{parentEntry}, parents...)
+				dependencyDirs[parent] = true
+			}
+		}
+		dependencyEntries = append(dependencyEntries, parents...)
+		dependencyEntries = append(dependencyEntries, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var tars []string
+	if len(dependencyEntries) > 0 {
+		tarPath, err := writeEntriesToTar(dependencyEntries)
+		if err != nil {
+			return nil, err
+		}
+		tars = append(tars, tarPath)
+	}
+
+	tarPath, err := writeEntriesToTar(sourceEntries)
+	if err != nil {
+		for _, t := range tars {
+			os.Remove(t)
+		}
+		return nil, err
+	}
+
+	return append(tars, tarPath), nil
+}
+
+func writeEntriesToTar(entries []tarEntry) (string, error) {
+	fh, err := ioutil.TempFile("", "")
+	if err != nil {
+		return "", fmt.Errorf("create file for tar: %s", err)
+	}
+	defer fh.Close()
+
+	tw := tar.NewWriter(fh)
+	for _, entry := range entries {
+		if err := writeTarEntry(tw, entry.file, path.Join("/", entry.relPath), entry.fi, 0, 0, -1); err != nil {
+			os.Remove(fh.Name())
+			return "", err
+		}
+	}
+
+	return fh.Name(), tw.Close()
+}
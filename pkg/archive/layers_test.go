@@ -0,0 +1,116 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package archive_test
+
+import (
+	"archive/tar"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/archive"
+)
+
+func TestLayers(t *testing.T) {
+	spec.Run(t, "Test source layers", testLayers)
+}
+
+func testLayers(t *testing.T, when spec.G, it spec.S) {
+	var dir string
+
+	writeFile := func(path, contents string) {
+		fullPath := filepath.Join(dir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0755))
+		require.NoError(t, os.WriteFile(fullPath, []byte(contents), 0644))
+	}
+
+	createLayerTars := func() []string {
+		tars, err := archive.CreateLayerTars(dir, nil)
+		require.NoError(t, err)
+		for _, tarPath := range tars {
+			path := tarPath
+			t.Cleanup(func() { os.Remove(path) })
+		}
+		return tars
+	}
+
+	it.Before(func() {
+		dir = t.TempDir()
+		writeFile("go.mod", "module app")
+		writeFile("main.go", "package main")
+		writeFile("services/api/package-lock.json", "{}")
+		writeFile("services/api/index.js", "console.log()")
+		writeFile("vendor/dep/dep.go", "package dep")
+	})
+
+	it("puts the dependency files in a layer before the rest of the source", func() {
+		tars := createLayerTars()
+		require.Len(t, tars, 2)
+
+		require.Equal(t, []string{
+			"/go.mod",
+			"/services",
+			"/services/api",
+			"/services/api/package-lock.json",
+			"/vendor",
+			"/vendor/dep",
+			"/vendor/dep/dep.go",
+		}, tarNames(t, tars[0]))
+
+		require.Equal(t, []string{
+			"/main.go",
+			"/services",
+			"/services/api",
+			"/services/api/index.js",
+		}, tarNames(t, tars[1]))
+	})
+
+	it("creates the same dependency layer when only the source changes", func() {
+		before := createLayerTars()
+
+		writeFile("main.go", "package main // changed")
+		after := createLayerTars()
+
+		require.Equal(t, fileDigest(t, before[0]), fileDigest(t, after[0]))
+		require.NotEqual(t, fileDigest(t, before[1]), fileDigest(t, after[1]))
+	})
+
+	it("only creates a source layer when there are no dependency files", func() {
+		dir = t.TempDir()
+		writeFile("main.go", "package main")
+
+		tars := createLayerTars()
+		require.Len(t, tars, 1)
+		require.Equal(t, []string{"/main.go"}, tarNames(t, tars[0]))
+	})
+}
+
+func tarNames(t *testing.T, tarPath string) []string {
+	f, err := os.Open(tarPath)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var names []string
+	tr := tar.NewReader(f)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return names
+		}
+		require.NoError(t, err)
+		names = append(names, header.Name)
+	}
+}
+
+func fileDigest(t *testing.T, path string) string {
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return fmt.Sprintf("%x", sha256.Sum256(contents))
+}
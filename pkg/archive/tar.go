@@ -7,9 +7,9 @@ import (
 	"archive/tar"
 	"fmt"
 	"io"
-	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 )
 
@@ -17,21 +17,13 @@ var (
 	normalizedTime = time.Date(1980, time.January, 1, 0, 0, 1, 0, time.UTC)
 )
 
-func CreateTar(path string) (string, error) {
-	fh, err := ioutil.TempFile("", "")
-	if err != nil {
-		return "", fmt.Errorf("create file for tar: %s", err)
+func WriteTar(writer io.Writer, path string) error {
+	tw := tar.NewWriter(writer)
+	if err := writeDirToTar(tw, path, "/", 0, 0, -1, nil); err != nil {
+		return err
 	}
-	defer fh.Close()
 
-	tw := tar.NewWriter(fh)
-	defer tw.Close()
-
-	if err := writeDirToTar(tw, path, "/", 0, 0, -1); err != nil {
-		return "", err
-	}
-
-	return fh.Name(), nil
+	return tw.Close()
 }
 
 func ReadTar(reader io.Reader, dir string) error {
@@ -45,6 +37,10 @@ func ReadTar(reader io.Reader, dir string) error {
 		}
 
 		filePath := filepath.Join(dir, header.Name)
+		if filePath != filepath.Clean(dir) && !strings.HasPrefix(filePath, filepath.Clean(dir)+string(os.PathSeparator)) {
+			return fmt.Errorf("%s: illegal file path", header.Name)
+		}
+
 		switch header.Typeflag {
 		case tar.TypeDir:
 			err := os.MkdirAll(filePath, os.FileMode(header.Mode))
@@ -56,8 +52,9 @@ func ReadTar(reader io.Reader, dir string) error {
 			if err != nil {
 				return err
 			}
-			defer outFile.Close()
-			if _, err := io.Copy(outFile, tarReader); err != nil {
+			_, err = io.Copy(outFile, tarReader)
+			outFile.Close()
+			if err != nil {
 				return err
 			}
 		}
@@ -65,61 +62,83 @@ func ReadTar(reader io.Reader, dir string) error {
 	return nil
 }
 
-func writeDirToTar(tw *tar.Writer, srcDir, basePath string, uid, gid int, mode int64) error {
-	return filepath.Walk(srcDir, func(file string, fi os.FileInfo, err error) error {
+func writeDirToTar(tw *tar.Writer, srcDir, basePath string, uid, gid int, mode int64, ignorer *Ignorer) error {
+	return walkDir(srcDir, ignorer, func(file, relPath string, fi os.FileInfo) error {
+		return writeTarEntry(tw, file, filepath.Join(basePath, relPath), fi, uid, gid, mode)
+	})
+}
+
+func writeTarEntry(tw *tar.Writer, file, name string, fi os.FileInfo, uid, gid int, mode int64) error {
+	var (
+		header *tar.Header
+		err    error
+	)
+	if fi.Mode()&os.ModeSymlink != 0 {
+		target, err := os.Readlink(file)
 		if err != nil {
 			return err
 		}
 
-		if fi.Mode()&os.ModeSocket != 0 {
-			return nil
+		header, err = tar.FileInfoHeader(fi, target)
+		if err != nil {
+			return err
 		}
+	} else {
+		header, err = tar.FileInfoHeader(fi, fi.Name())
+		if err != nil {
+			return err
+		}
+	}
 
-		var header *tar.Header
-		if fi.Mode()&os.ModeSymlink != 0 {
-			target, err := os.Readlink(file)
-			if err != nil {
-				return err
-			}
+	header.Name = filepath.ToSlash(name)
+	finalizeHeader(header, uid, gid, mode)
 
-			header, err = tar.FileInfoHeader(fi, target)
-			if err != nil {
-				return err
-			}
-		} else {
-			header, err = tar.FileInfoHeader(fi, fi.Name())
-			if err != nil {
-				return err
-			}
-		}
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
 
-		relPath, err := filepath.Rel(srcDir, file)
+	if fi.Mode().IsRegular() {
+		f, err := os.Open(file)
 		if err != nil {
 			return err
-		} else if relPath == "." {
-			return nil
 		}
+		defer f.Close()
+
+		if _, err := io.Copy(tw, f); err != nil {
+			return err
+		}
+	}
 
-		header.Name = filepath.ToSlash(filepath.Join(basePath, relPath))
-		finalizeHeader(header, uid, gid, mode)
+	return nil
+}
 
-		if err := tw.WriteHeader(header); err != nil {
+// walkDir calls fn for the files and directories of srcDir that are not
+// ignored, skipping the contents of ignored directories.
+func walkDir(srcDir string, ignorer *Ignorer, fn func(file, relPath string, fi os.FileInfo) error) error {
+	return filepath.Walk(srcDir, func(file string, fi os.FileInfo, err error) error {
+		if err != nil {
 			return err
 		}
 
-		if fi.Mode().IsRegular() {
-			f, err := os.Open(file)
-			if err != nil {
-				return err
-			}
-			defer f.Close()
+		if fi.Mode()&os.ModeSocket != 0 {
+			return nil
+		}
 
-			if _, err := io.Copy(tw, f); err != nil {
-				return err
+		relPath, err := filepath.Rel(srcDir, file)
+		if err != nil {
+			return err
+		} else if relPath == "." {
+			return nil
+		}
+
+		if ignorer.Ignored(relPath, fi.IsDir()) {
+			if fi.IsDir() {
+				return filepath.SkipDir
 			}
+			return nil
 		}
 
-		return nil
+		return fn(file, relPath, fi)
 	})
 }
 
@@ -0,0 +1,83 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package archive_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/archive"
+)
+
+func TestTar(t *testing.T) {
+	spec.Run(t, "Test Tar operations", testTar)
+}
+
+func testTar(t *testing.T, when spec.G, it spec.S) {
+	var dir string
+
+	it.Before(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "tar-test")
+		require.NoError(t, err)
+	})
+
+	it.After(func() {
+		require.NoError(t, os.RemoveAll(dir))
+	})
+
+	when("#ReadTar", func() {
+		it("reads a tar written by WriteTar", func() {
+			srcDir, err := ioutil.TempDir("", "tar-src")
+			require.NoError(t, err)
+			defer os.RemoveAll(srcDir)
+
+			require.NoError(t, os.Mkdir(filepath.Join(srcDir, "some-dir"), 0755))
+			require.NoError(t, ioutil.WriteFile(filepath.Join(srcDir, "some-dir", "some-file"), []byte("some-content"), 0644))
+
+			buf := &bytes.Buffer{}
+			require.NoError(t, archive.WriteTar(buf, srcDir))
+
+			require.NoError(t, archive.ReadTar(buf, dir))
+
+			content, err := ioutil.ReadFile(filepath.Join(dir, "some-dir", "some-file"))
+			require.NoError(t, err)
+			require.Equal(t, "some-content", string(content))
+		})
+
+		it("errors when an entry is outside of the directory", func() {
+			buf := makeTar(t, &tar.Header{Name: "../some-file", Typeflag: tar.TypeReg, Mode: 0644})
+
+			err := archive.ReadTar(buf, dir)
+			require.EqualError(t, err, "../some-file: illegal file path")
+
+			_, err = os.Stat(filepath.Join(filepath.Dir(dir), "some-file"))
+			require.True(t, os.IsNotExist(err))
+		})
+
+		it("skips entries that are not a directory or regular file", func() {
+			buf := makeTar(t, &tar.Header{Name: "some-link", Typeflag: tar.TypeSymlink, Linkname: "/etc/passwd"})
+
+			require.NoError(t, archive.ReadTar(buf, dir))
+
+			_, err := os.Lstat(filepath.Join(dir, "some-link"))
+			require.True(t, os.IsNotExist(err))
+		})
+	})
+}
+
+func makeTar(t *testing.T, header *tar.Header) *bytes.Buffer {
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+	require.NoError(t, tw.WriteHeader(header))
+	require.NoError(t, tw.Close())
+	return buf
+}
@@ -0,0 +1,120 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package build
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const metadataFile = "metadata.yaml"
+
+// LogSaver writes the logs of every lifecycle step of a build to its own file
+// so they outlive the build pod.
+type LogSaver struct {
+	k8sClient kubernetes.Interface
+}
+
+func NewLogSaver(k8sClient kubernetes.Interface) *LogSaver {
+	return &LogSaver{k8sClient: k8sClient}
+}
+
+type buildMetadata struct {
+	Name        string               `json:"name"`
+	Namespace   string               `json:"namespace"`
+	Image       string               `json:"image"`
+	BuildNumber string               `json:"buildNumber"`
+	Reasons     string               `json:"reasons,omitempty"`
+	Status      v1alpha2.BuildStatus `json:"status"`
+}
+
+// Save writes <step>.log for each started step container of the build, and a
+// metadata file from the build status, into a build-<number> directory under
+// outputDir. It returns the directory that was written.
+func (s *LogSaver) Save(ctx context.Context, bld v1alpha2.Build, outputDir string, timestamps bool) (string, error) {
+	buildNumber := bld.Labels[v1alpha2.BuildNumberLabel]
+	dir := filepath.Join(outputDir, fmt.Sprintf("build-%s", buildNumber))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+
+	if err := writeMetadata(dir, bld); err != nil {
+		return "", err
+	}
+
+	if bld.Status.PodName == "" {
+		return dir, nil
+	}
+
+	pod, err := s.k8sClient.CoreV1().Pods(bld.Namespace).Get(ctx, bld.Status.PodName, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return "", errors.Errorf("logs for build \"%s\" are no longer available, pod \"%s\" not found", buildNumber, bld.Status.PodName)
+	} else if err != nil {
+		return "", err
+	}
+
+	started := map[string]bool{}
+	for _, c := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		started[c.Name] = c.State.Waiting == nil
+	}
+
+	for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+		if !v1alpha2.IsBuildStep(container.Name) || !started[container.Name] {
+			continue
+		}
+
+		if err := s.saveContainerLogs(ctx, pod, container.Name, filepath.Join(dir, container.Name+".log"), timestamps); err != nil {
+			return "", err
+		}
+	}
+
+	return dir, nil
+}
+
+func (s *LogSaver) saveContainerLogs(ctx context.Context, pod *corev1.Pod, container, path string, timestamps bool) error {
+	logs, err := s.k8sClient.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+		Container:  container,
+		Timestamps: timestamps,
+	}).Stream(ctx)
+	if err != nil {
+		return err
+	}
+	defer logs.Close()
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(file, logs)
+	return err
+}
+
+func writeMetadata(dir string, bld v1alpha2.Build) error {
+	data, err := yaml.Marshal(buildMetadata{
+		Name:        bld.Name,
+		Namespace:   bld.Namespace,
+		Image:       bld.Labels[v1alpha2.ImageLabel],
+		BuildNumber: bld.Labels[v1alpha2.BuildNumberLabel],
+		Reasons:     bld.Annotations[v1alpha2.BuildReasonAnnotation],
+		Status:      bld.Status,
+	})
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(dir, metadataFile), data, 0644)
+}
@@ -0,0 +1,69 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package build
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/pivotal/kpack/pkg/client/clientset/versioned"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/k8s"
+)
+
+const BuildNeededAnnotation = "image.kpack.io/additionalBuildNeeded"
+
+var ErrNoBuilds = errors.New("no builds found")
+
+// Trigger annotates the latest build of the image so that kpack creates
+// a new one, and returns the number of the build that will be created.
+func Trigger(ctx context.Context, client versioned.Interface, namespace, name string) (int, error) {
+	original, err := Latest(ctx, client, namespace, name)
+	if err != nil {
+		return 0, err
+	}
+
+	patched := original.DeepCopy()
+	patched.Annotations[BuildNeededAnnotation] = time.Now().String()
+
+	patch, err := k8s.CreatePatch(original, patched)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = client.KpackV1alpha2().Builds(namespace).Patch(ctx, original.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return 0, err
+	}
+
+	return NextBuildNumber(patched), nil
+}
+
+func NextBuildNumber(bld *v1alpha2.Build) int {
+	previousBuildNumber, _ := strconv.Atoi(bld.Labels[v1alpha2.BuildNumberLabel])
+	return previousBuildNumber + 1
+}
+
+// Latest returns the most recent build of the image.
+func Latest(ctx context.Context, client versioned.Interface, namespace, name string) (*v1alpha2.Build, error) {
+	buildList, err := client.KpackV1alpha2().Builds(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: v1alpha2.ImageLabel + "=" + name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(buildList.Items) == 0 {
+		return nil, ErrNoBuilds
+	}
+
+	sort.Slice(buildList.Items, Sort(buildList.Items))
+	return buildList.Items[len(buildList.Items)-1].DeepCopy(), nil
+}
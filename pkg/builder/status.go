@@ -0,0 +1,82 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package builder
+
+import (
+	buildv1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/commands"
+)
+
+// Status holds the fields displayed by the builder and clusterbuilder status
+// commands, for printing with --output.
+type Status struct {
+	Status         string                   `json:"status"`
+	Reason         string                   `json:"reason,omitempty"`
+	Image          string                   `json:"image,omitempty"`
+	StackID        string                   `json:"stackId,omitempty"`
+	RunImage       string                   `json:"runImage,omitempty"`
+	StackRef       *Ref                     `json:"stackRef,omitempty"`
+	StoreRef       *Ref                     `json:"storeRef,omitempty"`
+	Buildpacks     []commands.BuildpackInfo `json:"buildpacks,omitempty"`
+	BuildpackRefs  []Ref                    `json:"buildpackRefs,omitempty"`
+	DetectionOrder []DetectionGroup         `json:"detectionOrder,omitempty"`
+}
+
+type Ref struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+}
+
+type DetectionGroup struct {
+	Group []DetectionRef `json:"group"`
+}
+
+type DetectionRef struct {
+	Id       string `json:"id"`
+	Version  string `json:"version,omitempty"`
+	Optional bool   `json:"optional,omitempty"`
+}
+
+func NewStatus(spec buildv1alpha2.BuilderSpec, status buildv1alpha2.BuilderStatus) Status {
+	cond := status.GetCondition(corev1alpha1.ConditionReady)
+	if cond == nil {
+		return Status{Status: "Unknown"}
+	} else if cond.Status != corev1.ConditionTrue {
+		return Status{Status: "Not Ready", Reason: cond.Message}
+	}
+
+	s := Status{
+		Status:   "Ready",
+		Image:    status.LatestImage,
+		StackID:  status.Stack.ID,
+		RunImage: status.Stack.RunImage,
+		StackRef: &Ref{Name: spec.Stack.Name, Kind: spec.Stack.Kind},
+		StoreRef: &Ref{Name: spec.Store.Name, Kind: spec.Store.Kind},
+	}
+
+	for _, bpMD := range status.BuilderMetadata {
+		s.Buildpacks = append(s.Buildpacks, commands.BuildpackInfo{Id: bpMD.Id, Version: bpMD.Version, Homepage: bpMD.Homepage})
+	}
+
+	for _, entry := range spec.Order {
+		for _, ref := range entry.Group {
+			if ref.ObjectReference.Name != "" && ref.ObjectReference.Kind != "" {
+				s.BuildpackRefs = append(s.BuildpackRefs, Ref{Name: ref.ObjectReference.Name, Kind: ref.ObjectReference.Kind})
+			}
+		}
+	}
+
+	for _, entry := range status.Order {
+		group := DetectionGroup{Group: []DetectionRef{}}
+		for _, ref := range entry.Group {
+			group.Group = append(group.Group, DetectionRef{Id: ref.Id, Version: ref.Version, Optional: ref.Optional})
+		}
+		s.DetectionOrder = append(s.DetectionOrder, group)
+	}
+
+	return s
+}
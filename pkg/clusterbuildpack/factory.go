@@ -43,21 +43,7 @@ func NewFactory(printer Printer, relocator registry.Relocator, fetcher registry.
 }
 
 func (f *Factory) MakeBuildpack(keychain authn.Keychain, name, imageTag string, kpConfig config.KpConfig) (*v1alpha2.ClusterBuildpack, error) {
-	err := f.validate(keychain, imageTag)
-	if err != nil {
-		return nil, fmt.Errorf("invalid buildpack image: %w", err)
-	}
-
-	defaultRepo, err := kpConfig.DefaultRepository()
-	if err != nil {
-		return nil, err
-	}
-
-	if err := f.Printer.PrintStatus("Uploading to '%s'...", defaultRepo); err != nil {
-		return nil, err
-	}
-
-	relocatedImageRef, err := f.Uploader.UploadBuildpackage(keychain, imageTag, defaultRepo)
+	relocatedImageRef, err := f.RelocateImage(keychain, imageTag, kpConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -85,28 +71,34 @@ func (f *Factory) MakeBuildpack(keychain authn.Keychain, name, imageTag string,
 }
 
 func (f *Factory) UpdateBuildpack(keychain authn.Keychain, buildpack *v1alpha2.ClusterBuildpack, imageTag string, kpConfig config.KpConfig) (*v1alpha2.ClusterBuildpack, error) {
+	relocatedImageRef, err := f.RelocateImage(keychain, imageTag, kpConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	newBuildpack := buildpack.DeepCopy()
+	newBuildpack.Spec.ImageSource.Image = relocatedImageRef
+	return newBuildpack, nil
+}
+
+// RelocateImage validates the buildpackage and uploads it to the default
+// repository, returning the reference of the relocated image.
+func (f *Factory) RelocateImage(keychain authn.Keychain, imageTag string, kpConfig config.KpConfig) (string, error) {
 	err := f.validate(keychain, imageTag)
 	if err != nil {
-		return nil, fmt.Errorf("invalid buildpack image: %w", err)
+		return "", fmt.Errorf("invalid buildpack image: %w", err)
 	}
 
 	defaultRepo, err := kpConfig.DefaultRepository()
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
 	if err := f.Printer.PrintStatus("Uploading to '%s'...", defaultRepo); err != nil {
-		return nil, err
+		return "", err
 	}
 
-	relocatedImageRef, err := f.Uploader.UploadBuildpackage(keychain, imageTag, defaultRepo)
-	if err != nil {
-		return nil, err
-	}
-
-	newBuildpack := buildpack.DeepCopy()
-	newBuildpack.Spec.ImageSource.Image = relocatedImageRef
-	return newBuildpack, nil
+	return f.Uploader.UploadBuildpackage(keychain, imageTag, defaultRepo)
 }
 
 func (f *Factory) validate(keychain authn.Keychain, imageTag string) error {
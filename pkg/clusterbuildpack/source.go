@@ -0,0 +1,51 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package clusterbuildpack
+
+import (
+	"context"
+	"io"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/config"
+	"github.com/buildpacks-community/kpack-cli/pkg/registry"
+)
+
+type SourcePrinter interface {
+	Printer
+	Writer() io.Writer
+	IsUploading() bool
+}
+
+// SourceConfig configures how the buildpack commands reference a buildpackage.
+// Without platforms or verification the image is referenced as given,
+// otherwise the filtered or verified buildpackage is uploaded to the default
+// repository.
+type SourceConfig struct {
+	TLSConfig    registry.TLSConfig
+	Platforms    []string
+	Verification registry.VerificationConfig
+}
+
+func (c SourceConfig) Relocates() bool {
+	return len(c.Platforms) > 0 || c.Verification.Enabled()
+}
+
+// ResolveImage returns the image the buildpack resource should reference.
+func (c SourceConfig) ResolveImage(ctx context.Context, keychain authn.Keychain, printer SourcePrinter, rup registry.UtilProvider, k8sClient kubernetes.Interface, image string) (string, error) {
+	if !c.Relocates() {
+		return image, nil
+	}
+
+	fetcher, err := registry.NewSourceFetcher(rup.Fetcher(c.TLSConfig), c.Platforms, c.Verification)
+	if err != nil {
+		return "", err
+	}
+
+	kpConfig := config.NewKpConfigProvider(k8sClient).GetKpConfig(ctx)
+	factory := NewFactory(printer, rup.Relocator(printer.Writer(), c.TLSConfig, printer.IsUploading()), fetcher)
+	return factory.RelocateImage(keychain, image, kpConfig)
+}
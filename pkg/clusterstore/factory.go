@@ -69,7 +69,12 @@ func (f *Factory) MakeStore(keychain authn.Keychain, name string, kpConfig confi
 
 	for _, bp := range buildpackages {
 		uploadedBp, err := f.Uploader.UploadBuildpackage(keychain, bp, defaultRepo)
-		if err != nil {
+		if registry.IsVerificationSkipped(err) {
+			if err = f.Printer.Printlnf("\tSkipping buildpackage: %s", err); err != nil {
+				return nil, err
+			}
+			continue
+		} else if err != nil {
 			return nil, err
 		}
 
@@ -91,7 +96,12 @@ func (f *Factory) AddToStore(keychain authn.Keychain, store *v1alpha2.ClusterSto
 
 	for _, bp := range buildpackages {
 		uploadedBp, err := f.Uploader.UploadBuildpackage(keychain, bp, defaultRepo)
-		if err != nil {
+		if registry.IsVerificationSkipped(err) {
+			if err = f.Printer.Printlnf("\tSkipping buildpackage: %s", err); err != nil {
+				return nil, err
+			}
+			continue
+		} else if err != nil {
 			return nil, err
 		}
 
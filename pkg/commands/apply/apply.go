@@ -0,0 +1,239 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/commands"
+	"github.com/buildpacks-community/kpack-cli/pkg/image"
+	"github.com/buildpacks-community/kpack-cli/pkg/k8s"
+	"github.com/buildpacks-community/kpack-cli/pkg/registry"
+)
+
+type Differ interface {
+	Diff(dOld, dNew interface{}) (string, error)
+}
+
+func NewApplyCommand(clientSetProvider k8s.ClientSetProvider, rup registry.UtilProvider, differ Differ) *cobra.Command {
+	var (
+		filename  string
+		namespace string
+		tlsCfg    registry.TLSConfig
+	)
+
+	cmd := &cobra.Command{
+		Use:   "apply -f <filename>",
+		Short: "Create or patch the image resources of a manifest",
+		Long: `Create or patch the image resources listed in an image manifest.
+
+The manifest lists image resources with the same shorthand as the kp image flags:
+
+  apiVersion: kp.kpack.io/v1
+  kind: ImageManifest
+  images:
+  - name: my-app
+    namespace: my-namespace
+    tag: my-registry.com/my-app
+    git:
+      url: https://github.com/my-org/my-app
+      revision: main
+    clusterBuilder: default
+    env:
+      BP_JVM_VERSION: "17"
+    serviceBindings:
+    - my-secret
+    - CustomProvisionedService:v1:my-ps
+
+Image resources use either git, blob or localPath as source. Local source code is uploaded as with "kp image save".
+
+The changes to each image resource are shown before it is created or patched. Fields that were set by a previous
+apply and are no longer in the manifest are removed, fields set by other tools are kept.
+
+The namespace of image resources without one defaults to the kubernetes current-context namespace.
+
+Env vars can be used for registry auth as described in https://github.com/buildpacks-community/kpack-cli/blob/main/docs/auth.md`,
+		Example: `kp apply -f images.yaml
+cat images.yaml | kp apply -f -
+kp apply -f images.yaml --dry-run`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cs, err := clientSetProvider.GetClientSet(namespace)
+			if err != nil {
+				return err
+			}
+
+			ch, err := commands.NewCommandHelper(cmd)
+			if err != nil {
+				return err
+			}
+
+			data, err := readManifest(cmd, filename)
+			if err != nil {
+				return err
+			}
+
+			manifest, err := image.ReadManifest(data)
+			if err != nil {
+				return err
+			}
+
+			a := applier{
+				ch:       ch,
+				cs:       cs,
+				differ:   differ,
+				uploader: rup.SourceUploader(ch.Writer(), tlsCfg, ch.CanChangeState()),
+			}
+
+			var objs []runtime.Object
+			for _, entry := range manifest.Images {
+				img, err := a.apply(cmd.Context(), entry)
+				if err != nil {
+					return err
+				}
+				objs = append(objs, img)
+			}
+
+			return ch.PrintObjs(objs)
+		},
+	}
+	cmd.Flags().StringVarP(&filename, "filename", "f", "", "image manifest filename, use - for stdin")
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "kubernetes namespace of image resources without a namespace")
+	commands.SetImgUploadDryRunOutputFlags(cmd)
+	commands.SetTLSFlags(cmd, &tlsCfg)
+	_ = cmd.MarkFlagRequired("filename")
+	return cmd
+}
+
+type applier struct {
+	ch       *commands.CommandHelper
+	cs       k8s.ClientSet
+	differ   Differ
+	uploader image.SourceUploader
+}
+
+func (a applier) apply(ctx context.Context, entry image.ManifestImage) (*v1alpha2.Image, error) {
+	namespace := entry.Namespace
+	if namespace == "" {
+		namespace = a.cs.Namespace
+	}
+
+	if err := a.ch.PrintStatus("Applying Image Resource %q in namespace %q...", entry.Name, namespace); err != nil {
+		return nil, err
+	}
+
+	img, err := entry.Factory(a.uploader, a.ch).MakeImage(entry.Name, namespace, entry.Tag)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid image %q", entry.Name)
+	}
+
+	if err := k8s.SetLastAppliedCfg(img); err != nil {
+		return nil, err
+	}
+
+	current, err := a.cs.KpackClient.KpackV1alpha2().Images(namespace).Get(ctx, entry.Name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return a.create(ctx, img)
+	} else if err != nil {
+		return nil, err
+	}
+
+	return a.patch(ctx, current, img)
+}
+
+func (a applier) create(ctx context.Context, img *v1alpha2.Image) (*v1alpha2.Image, error) {
+	if err := a.printDiff(nil, img.Spec); err != nil {
+		return nil, err
+	}
+
+	if !a.ch.IsDryRun() {
+		var err error
+		img, err = a.cs.KpackClient.KpackV1alpha2().Images(img.Namespace).Create(ctx, img, metav1.CreateOptions{})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return img, a.ch.PrintResult("Image Resource %q created", img.Name)
+}
+
+func (a applier) patch(ctx context.Context, current, img *v1alpha2.Image) (*v1alpha2.Image, error) {
+	if current.Spec.Tag != img.Spec.Tag {
+		return nil, errors.Errorf("tag of image %q cannot be changed from '%s' to '%s'", img.Name, current.Spec.Tag, img.Spec.Tag)
+	}
+
+	// typed clients do not return the type meta of resources
+	current = current.DeepCopy()
+	current.TypeMeta = img.TypeMeta
+
+	p, err := k8s.CreateThreeWayPatch(current, img)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(p) == 0 {
+		return current, a.ch.PrintChangeResult(false, "Image Resource %q patched", img.Name)
+	}
+
+	updated, err := applyPatch(current, p)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.printDiff(current.Spec, updated.Spec); err != nil {
+		return nil, err
+	}
+
+	if !a.ch.IsDryRun() {
+		updated, err = a.cs.KpackClient.KpackV1alpha2().Images(img.Namespace).Patch(ctx, img.Name, types.MergePatchType, p, metav1.PatchOptions{})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return updated, a.ch.PrintChangeResult(true, "Image Resource %q patched", img.Name)
+}
+
+func (a applier) printDiff(dOld, dNew interface{}) error {
+	diff, err := a.differ.Diff(dOld, dNew)
+	if err != nil {
+		return err
+	}
+	return a.ch.Printlnf("%s", diff)
+}
+
+func applyPatch(img *v1alpha2.Image, p []byte) (*v1alpha2.Image, error) {
+	original, err := json.Marshal(img)
+	if err != nil {
+		return nil, err
+	}
+
+	patched, err := jsonpatch.MergePatch(original, p)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := &v1alpha2.Image{}
+	return updated, json.Unmarshal(patched, updated)
+}
+
+func readManifest(cmd *cobra.Command, filename string) ([]byte, error) {
+	if filename == "-" {
+		return io.ReadAll(cmd.InOrStdin())
+	}
+	return os.ReadFile(filename)
+}
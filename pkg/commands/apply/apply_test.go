@@ -0,0 +1,185 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply_test
+
+import (
+	"testing"
+
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
+	"github.com/sclevine/spec"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	applycmds "github.com/buildpacks-community/kpack-cli/pkg/commands/apply"
+	commandsfakes "github.com/buildpacks-community/kpack-cli/pkg/commands/fakes"
+	"github.com/buildpacks-community/kpack-cli/pkg/k8s"
+	registryfakes "github.com/buildpacks-community/kpack-cli/pkg/registry/fakes"
+	"github.com/buildpacks-community/kpack-cli/pkg/testhelpers"
+)
+
+func TestApplyCommand(t *testing.T) {
+	spec.Run(t, "TestApplyCommand", testApplyCommand)
+}
+
+func testApplyCommand(t *testing.T, when spec.G, it spec.S) {
+	const defaultNamespace = "some-default-namespace"
+
+	differ := &commandsfakes.FakeDiffer{DiffResult: "some-diff"}
+
+	cmdFunc := func(clientSet *fake.Clientset) *cobra.Command {
+		clientSetProvider := testhelpers.GetFakeKpackProvider(clientSet, defaultNamespace)
+		return applycmds.NewApplyCommand(clientSetProvider, registryfakes.UtilProvider{}, differ)
+	}
+
+	const manifest = `apiVersion: kp.kpack.io/v1
+kind: ImageManifest
+images:
+- name: some-image
+  tag: some-registry.io/some-repo
+  git:
+    url: some-git-url
+    revision: some-git-rev
+  clusterBuilder: some-cluster-builder
+  env:
+    some-key: some-val
+`
+
+	makeImage := func(env ...corev1.EnvVar) *v1alpha2.Image {
+		return &v1alpha2.Image{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       v1alpha2.ImageKind,
+				APIVersion: "kpack.io/v1alpha2",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "some-image",
+				Namespace:   defaultNamespace,
+				Annotations: map[string]string{},
+			},
+			Spec: v1alpha2.ImageSpec{
+				Tag: "some-registry.io/some-repo",
+				Builder: corev1.ObjectReference{
+					Kind: v1alpha2.ClusterBuilderKind,
+					Name: "some-cluster-builder",
+				},
+				ServiceAccountName: "default",
+				Source: corev1alpha1.SourceConfig{
+					Git: &corev1alpha1.Git{
+						URL:      "some-git-url",
+						Revision: "some-git-rev",
+					},
+				},
+				Build: &v1alpha2.ImageBuild{
+					Env: env,
+				},
+			},
+		}
+	}
+
+	when("the image does not exist", func() {
+		it("creates the image with the last applied configuration", func() {
+			expectedImage := makeImage(corev1.EnvVar{Name: "some-key", Value: "some-val"})
+			require.NoError(t, k8s.SetLastAppliedCfg(expectedImage))
+
+			testhelpers.CommandTest{
+				StdIn: manifest,
+				Args:  []string{"-f", "-"},
+				ExpectedOutput: `Applying Image Resource "some-image" in namespace "some-default-namespace"...
+some-diff
+Image Resource "some-image" created
+`,
+				ExpectCreates: []runtime.Object{
+					expectedImage,
+				},
+			}.TestKpack(t, cmdFunc)
+
+			arg0, arg1 := differ.Args()
+			require.Nil(t, arg0)
+			require.Equal(t, expectedImage.Spec, arg1)
+		})
+
+		it("does not create the image with --dry-run", func() {
+			testhelpers.CommandTest{
+				StdIn: manifest,
+				Args:  []string{"-f", "-", "--dry-run"},
+				ExpectedOutput: `Applying Image Resource "some-image" in namespace "some-default-namespace"... (dry run)
+some-diff
+Image Resource "some-image" created (dry run)
+`,
+			}.TestKpack(t, cmdFunc)
+		})
+	})
+
+	when("the image exists", func() {
+		it("removes fields that are no longer in the manifest", func() {
+			existingImage := makeImage(
+				corev1.EnvVar{Name: "some-key", Value: "some-val"},
+				corev1.EnvVar{Name: "some-other-key", Value: "some-other-val"},
+			)
+			require.NoError(t, k8s.SetLastAppliedCfg(existingImage))
+
+			testhelpers.CommandTest{
+				Objects: []runtime.Object{existingImage},
+				StdIn:   manifest,
+				Args:    []string{"-f", "-"},
+				ExpectedOutput: `Applying Image Resource "some-image" in namespace "some-default-namespace"...
+some-diff
+Image Resource "some-image" patched
+`,
+				ExpectPatches: []string{
+					`{"metadata":{"annotations":{"kubectl.kubernetes.io/last-applied-configuration":"{\"kind\":\"Image\",\"apiVersion\":\"kpack.io/v1alpha2\",\"metadata\":{\"name\":\"some-image\",\"namespace\":\"some-default-namespace\",\"creationTimestamp\":null},\"spec\":{\"tag\":\"some-registry.io/some-repo\",\"builder\":{\"kind\":\"ClusterBuilder\",\"name\":\"some-cluster-builder\"},\"serviceAccountName\":\"default\",\"source\":{\"git\":{\"url\":\"some-git-url\",\"revision\":\"some-git-rev\"}},\"build\":{\"env\":[{\"name\":\"some-key\",\"value\":\"some-val\"}],\"resources\":{}}},\"status\":{}}"}},"spec":{"build":{"env":[{"name":"some-key","value":"some-val"}]}}}`,
+				},
+			}.TestKpack(t, cmdFunc)
+
+			arg0, arg1 := differ.Args()
+			require.Equal(t, existingImage.Spec, arg0)
+			require.Equal(t, makeImage(corev1.EnvVar{Name: "some-key", Value: "some-val"}).Spec, arg1)
+		})
+
+		it("keeps fields that were not set by a previous apply", func() {
+			existingImage := makeImage(corev1.EnvVar{Name: "some-key", Value: "some-val"})
+			require.NoError(t, k8s.SetLastAppliedCfg(existingImage))
+			existingImage.Spec.AdditionalTags = []string{"some-registry.io/some-other-tag"}
+
+			testhelpers.CommandTest{
+				Objects: []runtime.Object{existingImage},
+				StdIn:   manifest,
+				Args:    []string{"-f", "-"},
+				ExpectedOutput: `Applying Image Resource "some-image" in namespace "some-default-namespace"...
+Image Resource "some-image" patched (no change)
+`,
+			}.TestKpack(t, cmdFunc)
+		})
+
+		it("fails when the tag changes", func() {
+			existingImage := makeImage(corev1.EnvVar{Name: "some-key", Value: "some-val"})
+			existingImage.Spec.Tag = "some-registry.io/some-other-repo"
+
+			testhelpers.CommandTest{
+				Objects:   []runtime.Object{existingImage},
+				StdIn:     manifest,
+				Args:      []string{"-f", "-"},
+				ExpectErr: true,
+				ExpectedOutput: `Applying Image Resource "some-image" in namespace "some-default-namespace"...
+`,
+				ExpectedErrorOutput: `Error: tag of image "some-image" cannot be changed from 'some-registry.io/some-other-repo' to 'some-registry.io/some-repo'
+`,
+			}.TestKpack(t, cmdFunc)
+		})
+	})
+
+	it("fails with an invalid manifest", func() {
+		testhelpers.CommandTest{
+			StdIn:     "apiVersion: v1\nkind: ConfigMap\n",
+			Args:      []string{"-f", "-"},
+			ExpectErr: true,
+			ExpectedErrorOutput: `Error: image manifest must have apiVersion kp.kpack.io/v1 and kind ImageManifest
+`,
+		}.TestKpack(t, cmdFunc)
+	})
+}
@@ -0,0 +1,126 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package build
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/pivotal/kpack/pkg/slsa"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/build"
+	"github.com/buildpacks-community/kpack-cli/pkg/commands"
+	"github.com/buildpacks-community/kpack-cli/pkg/dockercreds"
+	"github.com/buildpacks-community/kpack-cli/pkg/k8s"
+	"github.com/buildpacks-community/kpack-cli/pkg/provenance"
+	"github.com/buildpacks-community/kpack-cli/pkg/registry"
+)
+
+const keyPasswordEnv = "COSIGN_PASSWORD"
+
+func NewAttestCommand(clientSetProvider k8s.ClientSetProvider, rup registry.UtilProvider) *cobra.Command {
+	var (
+		namespace   string
+		buildNumber string
+		keyPath     string
+		attach      bool
+		tlsCfg      registry.TLSConfig
+	)
+
+	cmd := &cobra.Command{
+		Use:   "attest <image-name>",
+		Short: "Generate a provenance attestation for an image resource build",
+		Long: `Generates an in-toto statement with a SLSA v1 provenance predicate for a specific build of an image resource
+in the provided namespace.
+
+The statement records the source, the builder and run image digests, the buildpack versions, the build reason
+and the time the build started and finished. It is printed to stdout unless the flag --attach is used, which
+pushes it to the registry as an OCI artifact referring to the image built by the build.
+
+Use the flag --key to sign the statement with a local private key, the result is then a DSSE envelope.
+Cosign keys are decrypted with the password in the COSIGN_PASSWORD env var, other keys must be unencrypted
+PKCS8 RSA, ECDSA or ED25519 keys in PEM format.
+
+The build defaults to the latest build number.
+The namespace defaults to the kubernetes current-context namespace.
+
+Env vars can be used for registry auth as described in https://github.com/buildpacks-community/kpack-cli/blob/main/docs/auth.md`,
+		Example:      "kp build attest my-image\nkp build attest my-image -b 2 -n my-namespace --key cosign.key --attach",
+		Args:         commands.ExactArgsWithUsage(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cs, err := clientSetProvider.GetClientSet(namespace)
+			if err != nil {
+				return err
+			}
+
+			ch, err := commands.NewCommandHelper(cmd)
+			if err != nil {
+				return err
+			}
+
+			var signer slsa.Signer
+			if keyPath != "" {
+				signer, err = provenance.LoadSigner(keyPath, []byte(os.Getenv(keyPasswordEnv)))
+				if err != nil {
+					return err
+				}
+			}
+
+			buildList, err := cs.KpackClient.KpackV1alpha2().Builds(cs.Namespace).List(cmd.Context(), metav1.ListOptions{
+				LabelSelector: v1alpha2.ImageLabel + "=" + args[0],
+			})
+			if err != nil {
+				return err
+			}
+
+			if len(buildList.Items) == 0 {
+				return errors.New("no builds found")
+			}
+			sort.Slice(buildList.Items, build.Sort(buildList.Items))
+
+			bld, err := findBuild(buildList, buildNumber)
+			if err != nil {
+				return err
+			}
+
+			stmt, err := provenance.NewStatement(bld, signer != nil)
+			if err != nil {
+				return err
+			}
+
+			payload, mediaType, err := provenance.Encode(cmd.Context(), stmt, signer)
+			if err != nil {
+				return err
+			}
+
+			if !attach {
+				_, err = fmt.Fprintln(cmd.OutOrStdout(), string(payload))
+				return err
+			}
+
+			if err := ch.PrintStatus("Attaching provenance to '%s'...", bld.Status.LatestImage); err != nil {
+				return err
+			}
+
+			ref, err := rup.Attacher(tlsCfg).Attach(dockercreds.DefaultKeychain, bld.Status.LatestImage, mediaType, payload)
+			if err != nil {
+				return err
+			}
+
+			return ch.PrintResult("Provenance attached as '%s'", ref)
+		},
+	}
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "kubernetes namespace")
+	cmd.Flags().StringVarP(&buildNumber, "build", "b", "", "build number")
+	cmd.Flags().StringVar(&keyPath, "key", "", "path to a private key to sign the statement with")
+	cmd.Flags().BoolVar(&attach, "attach", false, "push the statement to the registry as an artifact referring to the built image")
+	commands.SetTLSFlags(cmd, &tlsCfg)
+	return cmd
+}
@@ -0,0 +1,91 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package build_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"testing"
+
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
+	"github.com/sclevine/spec"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/commands/build"
+	registryfakes "github.com/buildpacks-community/kpack-cli/pkg/registry/fakes"
+	"github.com/buildpacks-community/kpack-cli/pkg/testhelpers"
+)
+
+func TestBuildAttestCommand(t *testing.T) {
+	spec.Run(t, "TestBuildAttestCommand", testBuildAttestCommand)
+}
+
+func testBuildAttestCommand(t *testing.T, when spec.G, it spec.S) {
+	const (
+		image            = "test-image"
+		defaultNamespace = "some-default-namespace"
+		appImage         = "repo.com/image@sha256:1111111111111111111111111111111111111111111111111111111111111111"
+	)
+
+	var (
+		attacher *registryfakes.Attacher
+		builds   []runtime.Object
+	)
+
+	it.Before(func() {
+		attacher = &registryfakes.Attacher{}
+
+		testBuilds := testhelpers.MakeTestBuilds(image, defaultNamespace)
+		for _, bld := range testBuilds {
+			if bld.Labels[v1alpha2.BuildNumberLabel] == "3" {
+				bld.Status.LatestImage = appImage
+			}
+		}
+		builds = testhelpers.BuildsToRuntimeObjs(testBuilds)
+	})
+
+	cmdFunc := func(clientSet *fake.Clientset) *cobra.Command {
+		return build.NewAttestCommand(testhelpers.GetFakeKpackProvider(clientSet, defaultNamespace), registryfakes.UtilProvider{FakeAttacher: attacher})
+	}
+
+	it("prints the provenance statement of the latest build", func() {
+		cmd := cmdFunc(fake.NewSimpleClientset(builds...))
+		out := &bytes.Buffer{}
+		cmd.SetOut(out)
+		cmd.SetArgs([]string{image})
+
+		require.NoError(t, cmd.Execute())
+		require.Contains(t, out.String(), `"predicateType": "https://slsa.dev/provenance/v1"`)
+		require.Contains(t, out.String(), `"name": "repo.com/image"`)
+		require.Empty(t, attacher.Attached)
+	})
+
+	it("attaches the statement to the built image", func() {
+		cmd := cmdFunc(fake.NewSimpleClientset(builds...))
+		out := &bytes.Buffer{}
+		cmd.SetOut(out)
+		cmd.SetArgs([]string{image, "--attach"})
+
+		require.NoError(t, cmd.Execute())
+		require.Len(t, attacher.Attached, 1)
+		require.Equal(t, appImage, attacher.Attached[0].Subject)
+		require.Equal(t, "application/vnd.in-toto+json", attacher.Attached[0].ArtifactType)
+		require.Contains(t, string(attacher.Attached[0].Payload), `"predicateType": "https://slsa.dev/provenance/v1"`)
+		require.Equal(t, fmt.Sprintf("Attaching provenance to '%s'...\nProvenance attached as 'repo.com/image@sha256:%x'\n",
+			appImage, sha256.Sum256(attacher.Attached[0].Payload)), out.String())
+	})
+
+	it("fails when the build has not produced a digest image", func() {
+		testhelpers.CommandTest{
+			Objects:             builds,
+			Args:                []string{image, "-b", "1"},
+			ExpectErr:           true,
+			ExpectedErrorOutput: "Error: build \"1\" has not produced an image\n",
+		}.TestKpack(t, cmdFunc)
+	})
+}
@@ -0,0 +1,276 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package build
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/build"
+	"github.com/buildpacks-community/kpack-cli/pkg/commands"
+	"github.com/buildpacks-community/kpack-cli/pkg/k8s"
+)
+
+type Differ interface {
+	Diff(dOld, dNew interface{}) (string, error)
+}
+
+func NewDiffCommand(clientSetProvider k8s.ClientSetProvider, differ Differ) *cobra.Command {
+	var namespace string
+
+	cmd := &cobra.Command{
+		Use:   "diff <image-name> <build-number> <build-number>",
+		Short: "Display the differences between two builds of an image resource",
+		Long: `Compares two builds of an image resource in the provided namespace.
+
+The source, builder image, run image, buildpacks, environment variables, service bindings, status and reason
+of the builds are compared. Buildpacks are compared using the build metadata reported by each build.
+
+The namespace defaults to the kubernetes current-context namespace.`,
+		Example:      "kp build diff my-image 1 2\nkp build diff my-image 3 4 -n my-namespace --output json",
+		Args:         commands.ExactArgsWithUsage(3),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cs, err := clientSetProvider.GetClientSet(namespace)
+			if err != nil {
+				return err
+			}
+
+			buildList, err := cs.KpackClient.KpackV1alpha2().Builds(cs.Namespace).List(cmd.Context(), metav1.ListOptions{
+				LabelSelector: v1alpha2.ImageLabel + "=" + args[0],
+			})
+			if err != nil {
+				return err
+			}
+
+			if len(buildList.Items) == 0 {
+				return errors.New("no builds found")
+			}
+			sort.Slice(buildList.Items, build.Sort(buildList.Items))
+
+			from, err := findBuild(buildList, args[1])
+			if err != nil {
+				return err
+			}
+
+			to, err := findBuild(buildList, args[2])
+			if err != nil {
+				return err
+			}
+
+			fromCmp, toCmp := newBuildComparison(from), newBuildComparison(to)
+
+			printer, err := commands.NewDataPrinter(cmd)
+			if err != nil {
+				return err
+			} else if printer != nil {
+				changes, err := compareFields(fromCmp, toCmp)
+				if err != nil {
+					return err
+				}
+				return printer.Print(cmd.OutOrStdout(), buildDiffOutput{
+					Image:   args[0],
+					From:    from.Labels[v1alpha2.BuildNumberLabel],
+					To:      to.Labels[v1alpha2.BuildNumberLabel],
+					Changes: changes,
+				})
+			}
+
+			diff, err := differ.Diff(fromCmp, toCmp)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if diff == "" {
+				_, err = fmt.Fprintf(out, "Build %s and build %s of image %q have no differences\n",
+					from.Labels[v1alpha2.BuildNumberLabel], to.Labels[v1alpha2.BuildNumberLabel], args[0])
+				return err
+			}
+
+			_, err = fmt.Fprintf(out, "Differences between build %s and build %s of image %q:\n%s",
+				from.Labels[v1alpha2.BuildNumberLabel], to.Labels[v1alpha2.BuildNumberLabel], args[0], diff)
+			return err
+		},
+	}
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "kubernetes namespace")
+	commands.SetStatusOutputFlag(cmd)
+
+	return cmd
+}
+
+// buildComparison holds the parts of a build that are compared.
+type buildComparison struct {
+	Reason     string            `json:"reason"`
+	Status     string            `json:"status"`
+	Source     sourceOutput      `json:"source"`
+	Builder    string            `json:"builder"`
+	RunImage   string            `json:"runImage"`
+	Buildpacks map[string]string `json:"buildpacks,omitempty"`
+	Env        map[string]string `json:"env,omitempty"`
+	Services   []string          `json:"services,omitempty"`
+}
+
+type buildDiffOutput struct {
+	Image   string        `json:"image"`
+	From    string        `json:"from"`
+	To      string        `json:"to"`
+	Changes []fieldChange `json:"changes"`
+}
+
+type fieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from,omitempty"`
+	To    string `json:"to,omitempty"`
+}
+
+func newBuildComparison(bld v1alpha2.Build) buildComparison {
+	c := buildComparison{
+		Reason:   bld.Annotations[v1alpha2.BuildReasonAnnotation],
+		Status:   getStatus(bld),
+		Builder:  bld.Spec.Builder.Image,
+		RunImage: bld.Status.Stack.RunImage,
+		Source:   sourceOutput{Type: "Local Source"},
+	}
+
+	if bld.Spec.Source.Git != nil {
+		c.Source = sourceOutput{Type: "GitUrl", Url: bld.Spec.Source.Git.URL, Revision: bld.Spec.Source.Git.Revision}
+	} else if bld.Spec.Source.Blob != nil {
+		c.Source = sourceOutput{Type: "Blob", Url: bld.Spec.Source.Blob.URL}
+	} else if bld.Spec.Source.Registry != nil {
+		c.Source.Url = bld.Spec.Source.Registry.Image
+	}
+
+	for _, buildpack := range bld.Status.BuildMetadata {
+		if c.Buildpacks == nil {
+			c.Buildpacks = map[string]string{}
+		}
+		c.Buildpacks[buildpack.Id] = buildpack.Version
+	}
+
+	for _, env := range bld.Spec.Env {
+		if c.Env == nil {
+			c.Env = map[string]string{}
+		}
+		c.Env[env.Name] = envValue(env)
+	}
+
+	for _, service := range bld.Spec.Services {
+		c.Services = append(c.Services, fmt.Sprintf("%s:%s:%s", service.Kind, service.APIVersion, service.Name))
+	}
+	sort.Strings(c.Services)
+
+	return c
+}
+
+// envValue describes where the value of an environment variable comes from
+// when it is not set directly.
+func envValue(env corev1.EnvVar) string {
+	from := env.ValueFrom
+	switch {
+	case from == nil:
+		return env.Value
+	case from.ConfigMapKeyRef != nil:
+		return fmt.Sprintf("configMapKeyRef %s/%s", from.ConfigMapKeyRef.Name, from.ConfigMapKeyRef.Key)
+	case from.SecretKeyRef != nil:
+		return fmt.Sprintf("secretKeyRef %s/%s", from.SecretKeyRef.Name, from.SecretKeyRef.Key)
+	case from.FieldRef != nil:
+		return fmt.Sprintf("fieldRef %s", from.FieldRef.FieldPath)
+	case from.ResourceFieldRef != nil:
+		return fmt.Sprintf("resourceFieldRef %s/%s", from.ResourceFieldRef.ContainerName, from.ResourceFieldRef.Resource)
+	default:
+		return env.Value
+	}
+}
+
+// compareFields flattens both comparisons into field paths and returns
+// the fields whose values differ, sorted by field.
+func compareFields(from, to buildComparison) ([]fieldChange, error) {
+	fromFields, err := flattenFields(from)
+	if err != nil {
+		return nil, err
+	}
+
+	toFields, err := flattenFields(to)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]struct{}{}
+	for field := range fromFields {
+		fields[field] = struct{}{}
+	}
+	for field := range toFields {
+		fields[field] = struct{}{}
+	}
+
+	changes := []fieldChange{}
+	for field := range fields {
+		if fromFields[field] != toFields[field] {
+			changes = append(changes, fieldChange{Field: field, From: fromFields[field], To: toFields[field]})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+
+	return changes, nil
+}
+
+func flattenFields(c buildComparison) (map[string]string, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+
+	var obj map[string]interface{}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+
+	fields := map[string]string{}
+	flatten("", obj, fields)
+	return fields, nil
+}
+
+var plainFieldKeyRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// fieldPath appends key to the path, using a quoted bracket segment for keys
+// such as buildpack ids that contain dots or other separators.
+func fieldPath(prefix, key string) string {
+	if !plainFieldKeyRegex.MatchString(key) {
+		return fmt.Sprintf("%s[%s]", prefix, strconv.Quote(key))
+	}
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+func flatten(prefix string, value interface{}, fields map[string]string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range v {
+			flatten(fieldPath(prefix, key), nested, fields)
+		}
+	case []interface{}:
+		var items []string
+		for _, item := range v {
+			items = append(items, fmt.Sprint(item))
+		}
+		fields[prefix] = strings.Join(items, ",")
+	default:
+		if s := fmt.Sprint(v); s != "" {
+			fields[prefix] = s
+		}
+	}
+}
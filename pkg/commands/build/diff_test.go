@@ -0,0 +1,217 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package build_test
+
+import (
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
+	"github.com/sclevine/spec"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/commands/build"
+	commandsfakes "github.com/buildpacks-community/kpack-cli/pkg/commands/fakes"
+	"github.com/buildpacks-community/kpack-cli/pkg/testhelpers"
+)
+
+func TestBuildDiffCommand(t *testing.T) {
+	spec.Run(t, "TestBuildDiffCommand", testBuildDiffCommand)
+}
+
+func testBuildDiffCommand(t *testing.T, when spec.G, it spec.S) {
+	const (
+		image            = "test-image"
+		defaultNamespace = "some-default-namespace"
+	)
+
+	makeBuild := func(number, reason, revision, bpVersion, runImage string, env ...corev1.EnvVar) *v1alpha2.Build {
+		return &v1alpha2.Build{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "build-" + number,
+				Namespace: defaultNamespace,
+				Labels: map[string]string{
+					v1alpha2.ImageLabel:       image,
+					v1alpha2.BuildNumberLabel: number,
+				},
+				Annotations: map[string]string{v1alpha2.BuildReasonAnnotation: reason},
+			},
+			Spec: v1alpha2.BuildSpec{
+				Builder: corev1alpha1.BuildBuilderSpec{Image: "some-repo.com/my-builder@sha256:123"},
+				Source: corev1alpha1.SourceConfig{
+					Git: &corev1alpha1.Git{URL: "https://github.com/some-org/some-repo", Revision: revision},
+				},
+				Env: env,
+				Services: v1alpha2.Services{
+					{Kind: "Secret", APIVersion: "v1", Name: "some-binding"},
+				},
+			},
+			Status: v1alpha2.BuildStatus{
+				Status: corev1alpha1.Status{
+					Conditions: corev1alpha1.Conditions{
+						{Type: corev1alpha1.ConditionSucceeded, Status: corev1.ConditionTrue},
+					},
+				},
+				BuildMetadata: corev1alpha1.BuildpackMetadataList{
+					{Id: "bp-id-1", Version: "1.0.0"},
+					{Id: "bp-id-2", Version: bpVersion},
+				},
+				Stack: corev1alpha1.BuildStack{RunImage: runImage},
+			},
+		}
+	}
+
+	builds := []runtime.Object{
+		makeBuild("1", "CONFIG", "abc123", "2.0.0", "some-repo.com/run-image@sha256:aaa",
+			corev1.EnvVar{Name: "BP_KEEP", Value: "same"}, corev1.EnvVar{Name: "BP_REMOVED", Value: "gone"}),
+		makeBuild("2", "COMMIT,STACK", "def456", "2.1.0", "some-repo.com/run-image@sha256:bbb",
+			corev1.EnvVar{Name: "BP_KEEP", Value: "same"}),
+		makeBuild("3", "TRIGGER", "def456", "2.1.0", "some-repo.com/run-image@sha256:bbb",
+			corev1.EnvVar{Name: "BP_KEEP", Value: "same"}),
+	}
+
+	var differ *commandsfakes.FakeDiffer
+
+	it.Before(func() {
+		differ = &commandsfakes.FakeDiffer{DiffResult: "some-diff\n"}
+	})
+
+	cmdFunc := func(clientSet *fake.Clientset) *cobra.Command {
+		return build.NewDiffCommand(testhelpers.GetFakeKpackProvider(clientSet, defaultNamespace), differ)
+	}
+
+	it("prints the differences between the builds", func() {
+		testhelpers.CommandTest{
+			Objects: builds,
+			Args:    []string{image, "1", "2"},
+			ExpectedOutput: `Differences between build 1 and build 2 of image "test-image":
+some-diff
+`,
+		}.TestKpack(t, cmdFunc)
+
+		from, to := differ.Args()
+		fromYaml, err := yaml.Marshal(from)
+		require.NoError(t, err)
+		require.Contains(t, string(fromYaml), "revision: abc123")
+		require.Contains(t, string(fromYaml), "BP_REMOVED: gone")
+		require.Contains(t, string(fromYaml), "bp-id-2: 2.0.0")
+		require.Contains(t, string(fromYaml), "- Secret:v1:some-binding")
+
+		toYaml, err := yaml.Marshal(to)
+		require.NoError(t, err)
+		require.Contains(t, string(toYaml), "reason: COMMIT,STACK")
+		require.Contains(t, string(toYaml), "runImage: some-repo.com/run-image@sha256:bbb")
+	})
+
+	it("prints a message when the builds have no differences", func() {
+		differ.DiffResult = ""
+
+		testhelpers.CommandTest{
+			Objects:        builds,
+			Args:           []string{image, "2", "2"},
+			ExpectedOutput: "Build 2 and build 2 of image \"test-image\" have no differences\n",
+		}.TestKpack(t, cmdFunc)
+	})
+
+	when("an output format is provided", func() {
+		it("prints each changed field", func() {
+			testhelpers.CommandTest{
+				Objects: builds,
+				Args:    []string{image, "1", "3", "-o", "json"},
+				ExpectedOutput: `{
+    "image": "test-image",
+    "from": "1",
+    "to": "3",
+    "changes": [
+        {
+            "field": "buildpacks.bp-id-2",
+            "from": "2.0.0",
+            "to": "2.1.0"
+        },
+        {
+            "field": "env.BP_REMOVED",
+            "from": "gone"
+        },
+        {
+            "field": "reason",
+            "from": "CONFIG",
+            "to": "TRIGGER"
+        },
+        {
+            "field": "runImage",
+            "from": "some-repo.com/run-image@sha256:aaa",
+            "to": "some-repo.com/run-image@sha256:bbb"
+        },
+        {
+            "field": "source.revision",
+            "from": "abc123",
+            "to": "def456"
+        }
+    ]
+}
+`,
+			}.TestKpack(t, cmdFunc)
+		})
+
+		it("quotes field keys with separators and compares env values from references", func() {
+			from := makeBuild("1", "CONFIG", "abc123", "2.0.0", "some-repo.com/run-image@sha256:aaa",
+				corev1.EnvVar{Name: "BP_SECRET", ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "some-secret"}, Key: "some-key"},
+				}})
+			from.Status.BuildMetadata[1].Id = "some.org/bp-id-2"
+
+			to := makeBuild("2", "CONFIG", "abc123", "2.1.0", "some-repo.com/run-image@sha256:aaa",
+				corev1.EnvVar{Name: "BP_SECRET", ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "other-secret"}, Key: "some-key"},
+				}})
+			to.Status.BuildMetadata[1].Id = "some.org/bp-id-2"
+
+			testhelpers.CommandTest{
+				Objects: []runtime.Object{from, to},
+				Args:    []string{image, "1", "2", "-o", "json"},
+				ExpectedOutput: `{
+    "image": "test-image",
+    "from": "1",
+    "to": "2",
+    "changes": [
+        {
+            "field": "buildpacks[\"some.org/bp-id-2\"]",
+            "from": "2.0.0",
+            "to": "2.1.0"
+        },
+        {
+            "field": "env.BP_SECRET",
+            "from": "secretKeyRef some-secret/some-key",
+            "to": "secretKeyRef other-secret/some-key"
+        }
+    ]
+}
+`,
+			}.TestKpack(t, cmdFunc)
+		})
+	})
+
+	it("fails when a build does not exist", func() {
+		testhelpers.CommandTest{
+			Objects:             builds,
+			Args:                []string{image, "1", "9"},
+			ExpectErr:           true,
+			ExpectedErrorOutput: "Error: build \"9\" not found\n",
+		}.TestKpack(t, cmdFunc)
+	})
+
+	it("fails when the image has no builds", func() {
+		testhelpers.CommandTest{
+			Args:                []string{image, "1", "2"},
+			ExpectErr:           true,
+			ExpectedErrorOutput: "Error: no builds found\n",
+		}.TestKpack(t, cmdFunc)
+	})
+}
@@ -7,7 +7,6 @@ import (
 	"sort"
 
 	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
-	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -58,7 +57,7 @@ The namespace defaults to the kubernetes current-context namespace.`,
 			}
 
 			if len(buildList.Items) == 0 {
-				return errors.New("no builds found")
+				return commands.EmptyList(cmd, "no builds found")
 			} else {
 				sort.Slice(buildList.Items, build.Sort(buildList.Items))
 				return displayBuildsTable(cmd, buildList)
@@ -67,24 +66,45 @@ The namespace defaults to the kubernetes current-context namespace.`,
 	}
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "kubernetes namespace")
 	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "Return objects found in all namespaces")
+	commands.SetListOutputFlag(cmd)
 
 	return cmd
 }
 
+type buildRow struct {
+	Build         string `json:"build"`
+	Status        string `json:"status"`
+	BuiltImage    string `json:"builtImage"`
+	Reason        string `json:"reason"`
+	ImageResource string `json:"imageResource"`
+}
+
 func displayBuildsTable(cmd *cobra.Command, buildList *v1alpha2.BuildList) error {
-	writer, err := commands.NewTableWriter(cmd.OutOrStdout(), "Build", "Status", "Built Image", "Reason", "Image Resource")
+	rows := make([]buildRow, 0, len(buildList.Items))
+	for _, bld := range buildList.Items {
+		rows = append(rows, buildRow{
+			Build:         bld.Labels[v1alpha2.BuildNumberLabel],
+			Status:        getStatus(bld),
+			BuiltImage:    bld.Status.LatestImage,
+			Reason:        getTruncatedReason(bld),
+			ImageResource: bld.Labels[v1alpha2.ImageLabel],
+		})
+	}
+
+	printer, err := commands.NewDataPrinter(cmd)
 	if err != nil {
 		return err
+	} else if printer != nil {
+		return printer.Print(cmd.OutOrStdout(), rows)
 	}
 
-	for _, bld := range buildList.Items {
-		err := writer.AddRow(
-			bld.Labels[v1alpha2.BuildNumberLabel],
-			getStatus(bld),
-			bld.Status.LatestImage,
-			getTruncatedReason(bld),
-			bld.Labels[v1alpha2.ImageLabel],
-		)
+	writer, err := commands.NewCommandTableWriter(cmd, "Build", "Status", "Built Image", "Reason", "Image Resource")
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		err := writer.AddRow(row.Build, row.Status, row.BuiltImage, row.Reason, row.ImageResource)
 		if err != nil {
 			return err
 		}
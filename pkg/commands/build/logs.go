@@ -5,7 +5,10 @@ package build
 
 import (
 	"context"
+	"fmt"
 	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	"github.com/pivotal/kpack/pkg/logs"
@@ -22,6 +25,7 @@ func NewLogsCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 	var (
 		namespace   string
 		buildNumber string
+		outputDir   string
 	)
 
 	cmd := &cobra.Command{
@@ -32,8 +36,12 @@ func NewLogsCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 The build defaults to the latest build number.
 The namespace defaults to the kubernetes current-context namespace.
 
-Use the flag --timestamps to include the timestamps for the logs`,
-		Example:      "kp build logs my-image\nkp build logs my-image -b 2 -n my-namespace",
+Use the flag --timestamps to include the timestamps for the logs
+
+Use the flag --output-dir to save the logs instead of tailing them. The logs of each build step are saved
+to their own file in a build-<number> directory, along with a metadata.yaml file taken from the build status.
+With --output-dir, --build also accepts a range of build numbers such as 3-7.`,
+		Example:      "kp build logs my-image\nkp build logs my-image -b 2 -n my-namespace\nkp build logs my-image -b 3-7 --output-dir ./logs",
 		Args:         commands.ExactArgsWithUsage(1),
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -53,7 +61,20 @@ Use the flag --timestamps to include the timestamps for the logs`,
 				return errors.New("no builds found")
 			} else {
 				ch, err := commands.NewCommandHelper(cmd)
+				if err != nil {
+					return err
+				}
+
 				sort.Slice(buildList.Items, build.Sort(buildList.Items))
+
+				if outputDir != "" {
+					return saveBuildLogs(cmd, ch, build.NewLogSaver(cs.K8sClient), buildList, buildNumber, outputDir)
+				}
+
+				if strings.Contains(buildNumber, "-") {
+					return errors.New("build ranges can only be used with --output-dir")
+				}
+
 				bld, err := findBuild(buildList, buildNumber)
 				if err != nil {
 					return err
@@ -65,5 +86,81 @@ Use the flag --timestamps to include the timestamps for the logs`,
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "kubernetes namespace")
 	cmd.Flags().StringVarP(&buildNumber, "build", "b", "", "build number")
 	cmd.Flags().BoolP("timestamps", "t", false, "show log timestamps")
+	cmd.Flags().StringVar(&outputDir, "output-dir", "", "directory to save the logs of each build step to")
 	return cmd
 }
+
+func saveBuildLogs(cmd *cobra.Command, ch *commands.CommandHelper, saver *build.LogSaver, buildList *v1alpha2.BuildList, buildNumber, outputDir string) error {
+	var builds []v1alpha2.Build
+	if strings.Contains(buildNumber, "-") {
+		var err error
+		builds, err = findBuildRange(buildList, buildNumber)
+		if err != nil {
+			return err
+		}
+	} else {
+		bld, err := findBuild(buildList, buildNumber)
+		if err != nil {
+			return err
+		}
+		builds = []v1alpha2.Build{bld}
+	}
+
+	if len(builds) == 1 {
+		return saveBuild(cmd, ch, saver, builds[0], outputDir)
+	}
+
+	// a build that cannot be saved does not stop the rest of the range
+	failed := 0
+	for _, bld := range builds {
+		if err := saveBuild(cmd, ch, saver, bld, outputDir); err != nil {
+			failed++
+			if _, err := fmt.Fprintf(cmd.ErrOrStderr(), "Failed to save logs for build \"%s\": %s\n", bld.Labels[v1alpha2.BuildNumberLabel], err); err != nil {
+				return err
+			}
+		}
+	}
+
+	if failed > 0 {
+		return errors.Errorf("failed to save logs for %d of %d builds", failed, len(builds))
+	}
+	return nil
+}
+
+func saveBuild(cmd *cobra.Command, ch *commands.CommandHelper, saver *build.LogSaver, bld v1alpha2.Build, outputDir string) error {
+	dir, err := saver.Save(cmd.Context(), bld, outputDir, ch.ShowTimestamp())
+	if err != nil {
+		return err
+	}
+
+	return ch.Printlnf("Saved logs for build \"%s\" to %s", bld.Labels[v1alpha2.BuildNumberLabel], dir)
+}
+
+func findBuildRange(buildList *v1alpha2.BuildList, buildRange string) ([]v1alpha2.Build, error) {
+	bounds := strings.SplitN(buildRange, "-", 2)
+	first, err := strconv.Atoi(bounds[0])
+	if err != nil {
+		return nil, errors.Errorf("build range should be two integers separated by '-': %v", buildRange)
+	}
+	last, err := strconv.Atoi(bounds[1])
+	if err != nil || last < first {
+		return nil, errors.Errorf("build range should be two integers separated by '-': %v", buildRange)
+	}
+
+	var builds []v1alpha2.Build
+	for _, b := range buildList.Items {
+		val, err := strconv.Atoi(b.Labels[v1alpha2.BuildNumberLabel])
+		if err != nil {
+			return nil, err
+		}
+
+		if val >= first && val <= last {
+			builds = append(builds, b)
+		}
+	}
+
+	if len(builds) == 0 {
+		return nil, errors.Errorf("no builds found in range \"%s\"", buildRange)
+	}
+	return builds, nil
+}
@@ -4,11 +4,18 @@
 package build_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
 	"github.com/sclevine/spec"
 	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfakes "k8s.io/client-go/kubernetes/fake"
 
 	"github.com/buildpacks-community/kpack-cli/pkg/commands/build"
 	"github.com/buildpacks-community/kpack-cli/pkg/testhelpers"
@@ -81,4 +88,125 @@ func testBuildLogsCommand(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 	})
+
+	when("saving build logs to a directory", func() {
+		var outputDir string
+
+		k8sCmdFunc := func(k8sClientSet *k8sfakes.Clientset, kpackClientSet *fake.Clientset) *cobra.Command {
+			clientSetProvider := testhelpers.GetFakeProvider(k8sClientSet, kpackClientSet, defaultNamespace)
+			return build.NewLogsCommand(clientSetProvider)
+		}
+
+		buildPod := func(name string) *corev1.Pod {
+			return &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: defaultNamespace,
+				},
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{{Name: "prepare"}, {Name: "analyze"}, {Name: "detect"}},
+					Containers:     []corev1.Container{{Name: "completion"}},
+				},
+				Status: corev1.PodStatus{
+					InitContainerStatuses: []corev1.ContainerStatus{
+						{Name: "prepare", State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}}},
+						{Name: "analyze", State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+						{Name: "detect", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{}}},
+					},
+					ContainerStatuses: []corev1.ContainerStatus{
+						{Name: "completion", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{}}},
+					},
+				},
+			}
+		}
+
+		builds := func() []runtime.Object {
+			return testhelpers.BuildsToRuntimeObjs(testhelpers.MakeTestBuilds(image, defaultNamespace))
+		}
+
+		it.Before(func() {
+			outputDir = t.TempDir()
+		})
+
+		it("saves the logs of each started build step and the build metadata", func() {
+			testhelpers.CommandTest{
+				Objects:        append(builds(), buildPod("pod-three")),
+				Args:           []string{image, "--output-dir", outputDir},
+				ExpectedOutput: "Saved logs for build \"3\" to " + filepath.Join(outputDir, "build-3") + "\n",
+			}.TestK8sAndKpack(t, k8sCmdFunc)
+
+			entries, err := os.ReadDir(filepath.Join(outputDir, "build-3"))
+			require.NoError(t, err)
+			var files []string
+			for _, entry := range entries {
+				files = append(files, entry.Name())
+			}
+			require.Equal(t, []string{"analyze.log", "metadata.yaml", "prepare.log"}, files)
+
+			logs, err := os.ReadFile(filepath.Join(outputDir, "build-3", "prepare.log"))
+			require.NoError(t, err)
+			require.Equal(t, "fake logs", string(logs))
+
+			metadata, err := os.ReadFile(filepath.Join(outputDir, "build-3", "metadata.yaml"))
+			require.NoError(t, err)
+			require.Contains(t, string(metadata), "name: build-three\n")
+			require.Contains(t, string(metadata), "buildNumber: \"3\"\n")
+			require.Contains(t, string(metadata), "podName: pod-three\n")
+		})
+
+		it("saves the logs of every build in a range", func() {
+			testhelpers.CommandTest{
+				Objects: append(builds(), buildPod("pod-one"), buildPod("pod-two"), buildPod("pod-three")),
+				Args:    []string{image, "-b", "1-2", "--output-dir", outputDir},
+				ExpectedOutput: "Saved logs for build \"1\" to " + filepath.Join(outputDir, "build-1") + "\n" +
+					"Saved logs for build \"2\" to " + filepath.Join(outputDir, "build-2") + "\n",
+			}.TestK8sAndKpack(t, k8sCmdFunc)
+
+			require.FileExists(t, filepath.Join(outputDir, "build-1", "prepare.log"))
+			require.FileExists(t, filepath.Join(outputDir, "build-2", "prepare.log"))
+			require.NoDirExists(t, filepath.Join(outputDir, "build-3"))
+		})
+
+		it("saves the rest of a range when a build cannot be saved", func() {
+			testhelpers.CommandTest{
+				Objects:   append(builds(), buildPod("pod-one"), buildPod("pod-three")),
+				Args:      []string{image, "-b", "1-3", "--output-dir", outputDir},
+				ExpectErr: true,
+				ExpectedOutput: "Saved logs for build \"1\" to " + filepath.Join(outputDir, "build-1") + "\n" +
+					"Saved logs for build \"3\" to " + filepath.Join(outputDir, "build-3") + "\n",
+				ExpectedErrorOutput: "Failed to save logs for build \"2\": logs for build \"2\" are no longer available, pod \"pod-two\" not found\n" +
+					"Error: failed to save logs for 1 of 3 builds\n",
+			}.TestK8sAndKpack(t, k8sCmdFunc)
+
+			require.FileExists(t, filepath.Join(outputDir, "build-1", "prepare.log"))
+			require.FileExists(t, filepath.Join(outputDir, "build-3", "prepare.log"))
+		})
+
+		it("errors when the build pod no longer exists", func() {
+			testhelpers.CommandTest{
+				Objects:             builds(),
+				Args:                []string{image, "--output-dir", outputDir},
+				ExpectErr:           true,
+				ExpectedErrorOutput: "Error: logs for build \"3\" are no longer available, pod \"pod-three\" not found\n",
+			}.TestK8sAndKpack(t, k8sCmdFunc)
+		})
+
+		it("errors when the range has no builds", func() {
+			testhelpers.CommandTest{
+				Objects:             builds(),
+				Args:                []string{image, "-b", "7-9", "--output-dir", outputDir},
+				ExpectErr:           true,
+				ExpectedErrorOutput: "Error: no builds found in range \"7-9\"\n",
+			}.TestK8sAndKpack(t, k8sCmdFunc)
+		})
+
+		it("errors when a range is used without an output directory", func() {
+			testhelpers.CommandTest{
+				Objects:             builds(),
+				Args:                []string{image, "-b", "1-2"},
+				ExpectErr:           true,
+				ExpectedErrorOutput: "Error: build ranges can only be used with --output-dir\n",
+			}.TestKpack(t, cmdFunc)
+		})
+	})
 }
@@ -0,0 +1,160 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package build
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/build"
+	"github.com/buildpacks-community/kpack-cli/pkg/commands"
+	"github.com/buildpacks-community/kpack-cli/pkg/dockercreds"
+	"github.com/buildpacks-community/kpack-cli/pkg/k8s"
+	"github.com/buildpacks-community/kpack-cli/pkg/registry"
+	"github.com/buildpacks-community/kpack-cli/pkg/sbom"
+)
+
+func NewSBOMCommand(clientSetProvider k8s.ClientSetProvider, rup registry.UtilProvider) *cobra.Command {
+	var (
+		namespace   string
+		buildNumber string
+		outputDir   string
+		formats     []string
+		merge       bool
+		tlsCfg      registry.TLSConfig
+	)
+
+	cmd := &cobra.Command{
+		Use:   "sbom <image-name>",
+		Short: "Download the software bill of materials of an image resource build",
+		Long: `Downloads the software bill of materials (SBOM) of a specific build of an image resource in the provided namespace.
+
+The SBOM documents written by the buildpacks and the lifecycle are read from the app image built by the build,
+which must have been built with platform api 0.8 or newer.
+They are saved to a build-<number> directory under the directory provided with --output-dir,
+keeping the paths they have in the app image.
+
+Use the flag --format to select the CycloneDX, SPDX or Syft JSON documents, all formats are saved by default.
+Use the flag --merge to combine the documents of each format into a single sbom.<format>.json file for the build.
+
+The build defaults to the latest build number.
+The namespace defaults to the kubernetes current-context namespace.
+
+Env vars can be used for registry auth as described in https://github.com/buildpacks-community/kpack-cli/blob/main/docs/auth.md`,
+		Example:      "kp build sbom my-image\nkp build sbom my-image -b 2 -n my-namespace --format cyclonedx --merge --output-dir ./sbom",
+		Args:         commands.ExactArgsWithUsage(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := sbom.ValidateFormats(formats); err != nil {
+				return err
+			}
+
+			cs, err := clientSetProvider.GetClientSet(namespace)
+			if err != nil {
+				return err
+			}
+
+			ch, err := commands.NewCommandHelper(cmd)
+			if err != nil {
+				return err
+			}
+
+			buildList, err := cs.KpackClient.KpackV1alpha2().Builds(cs.Namespace).List(cmd.Context(), metav1.ListOptions{
+				LabelSelector: v1alpha2.ImageLabel + "=" + args[0],
+			})
+			if err != nil {
+				return err
+			}
+
+			if len(buildList.Items) == 0 {
+				return errors.New("no builds found")
+			}
+			sort.Slice(buildList.Items, build.Sort(buildList.Items))
+
+			bld, err := findBuild(buildList, buildNumber)
+			if err != nil {
+				return err
+			}
+
+			number := bld.Labels[v1alpha2.BuildNumberLabel]
+			if bld.Status.LatestImage == "" {
+				return errors.Errorf("build \"%s\" has not produced an image", number)
+			}
+
+			if err := ch.PrintStatus("Reading SBOM of build \"%s\" from '%s'...", number, bld.Status.LatestImage); err != nil {
+				return err
+			}
+
+			reader := &sbom.Reader{Fetcher: rup.Fetcher(tlsCfg)}
+			docs, err := reader.Read(dockercreds.DefaultKeychain, bld.Status.LatestImage, formats)
+			if err != nil {
+				return err
+			}
+
+			if len(docs) == 0 {
+				return errors.Errorf("no sbom documents found in image '%s'", bld.Status.LatestImage)
+			}
+
+			dir := filepath.Join(outputDir, fmt.Sprintf("build-%s", number))
+			if merge {
+				docs, err = mergeDocuments(bld.Status.LatestImage, formats, docs)
+				if err != nil {
+					return err
+				}
+			}
+
+			for _, doc := range docs {
+				path := filepath.Join(dir, filepath.FromSlash(doc.Path))
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					return err
+				}
+
+				if err := os.WriteFile(path, doc.Content, 0644); err != nil {
+					return err
+				}
+
+				if err := ch.Printlnf("Saved %s", path); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "kubernetes namespace")
+	cmd.Flags().StringVarP(&buildNumber, "build", "b", "", "build number")
+	cmd.Flags().StringVar(&outputDir, "output-dir", ".", "directory to save the sbom documents to")
+	cmd.Flags().StringSliceVar(&formats, "format", sbom.Formats, "sbom formats to save (cyclonedx, spdx or syft)")
+	cmd.Flags().BoolVar(&merge, "merge", false, "merge the documents of each format into a single document")
+	commands.SetTLSFlags(cmd, &tlsCfg)
+	return cmd
+}
+
+func mergeDocuments(image string, formats []string, docs []sbom.Document) ([]sbom.Document, error) {
+	found := map[string]bool{}
+	for _, doc := range docs {
+		found[doc.Format] = true
+	}
+
+	var merged []sbom.Document
+	for _, format := range formats {
+		if !found[format] {
+			continue
+		}
+		found[format] = false
+
+		content, err := sbom.Merge(format, image, docs)
+		if err != nil {
+			return nil, err
+		}
+		merged = append(merged, sbom.Document{Path: sbom.Extension(format), Format: format, Content: content})
+	}
+	return merged, nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package build_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
+	"github.com/sclevine/spec"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/commands/build"
+	registryfakes "github.com/buildpacks-community/kpack-cli/pkg/registry/fakes"
+	"github.com/buildpacks-community/kpack-cli/pkg/testhelpers"
+)
+
+func TestBuildSBOMCommand(t *testing.T) {
+	spec.Run(t, "TestBuildSBOMCommand", testBuildSBOMCommand)
+}
+
+func testBuildSBOMCommand(t *testing.T, when spec.G, it spec.S) {
+	const (
+		image            = "test-image"
+		defaultNamespace = "some-default-namespace"
+	)
+
+	var (
+		fetcher   *registryfakes.Fetcher
+		outputDir string
+	)
+
+	builds := testhelpers.BuildsToRuntimeObjs(testhelpers.MakeTestBuilds(image, defaultNamespace))
+
+	it.Before(func() {
+		outputDir = t.TempDir()
+		fetcher = &registryfakes.Fetcher{}
+		fetcher.AddImage("repo.com/image-3:tag", testhelpers.MakeSBOMImage(t, map[string]string{
+			"bp-id-1/sbom.cdx.json":             `{"bomFormat":"CycloneDX","specVersion":"1.3","components":[{"name":"a"}]}`,
+			"bp-id-2/some-layer/sbom.cdx.json":  `{"bomFormat":"CycloneDX","specVersion":"1.3","components":[{"name":"b"}]}`,
+			"bp-id-2/some-layer/sbom.spdx.json": `{"spdxVersion":"SPDX-2.2","packages":[{"SPDXID":"SPDXRef-b"}]}`,
+		}))
+	})
+
+	cmdFunc := func(clientSet *fake.Clientset) *cobra.Command {
+		return build.NewSBOMCommand(testhelpers.GetFakeKpackProvider(clientSet, defaultNamespace), registryfakes.UtilProvider{FakeFetcher: fetcher})
+	}
+
+	it("saves the sbom documents of the latest build", func() {
+		dir := filepath.Join(outputDir, "build-3")
+
+		testhelpers.CommandTest{
+			Objects: builds,
+			Args:    []string{image, "--output-dir", outputDir},
+			ExpectedOutput: `Reading SBOM of build "3" from 'repo.com/image-3:tag'...
+Saved ` + filepath.Join(dir, "bp-id-1", "sbom.cdx.json") + `
+Saved ` + filepath.Join(dir, "bp-id-2", "some-layer", "sbom.cdx.json") + `
+Saved ` + filepath.Join(dir, "bp-id-2", "some-layer", "sbom.spdx.json") + `
+`,
+		}.TestKpack(t, cmdFunc)
+
+		content, err := os.ReadFile(filepath.Join(dir, "bp-id-2", "some-layer", "sbom.spdx.json"))
+		require.NoError(t, err)
+		require.Equal(t, `{"spdxVersion":"SPDX-2.2","packages":[{"SPDXID":"SPDXRef-b"}]}`, string(content))
+	})
+
+	it("merges the documents of the selected formats", func() {
+		path := filepath.Join(outputDir, "build-3", "sbom.cdx.json")
+
+		testhelpers.CommandTest{
+			Objects: builds,
+			Args:    []string{image, "--output-dir", outputDir, "--format", "cyclonedx,syft", "--merge"},
+			ExpectedOutput: `Reading SBOM of build "3" from 'repo.com/image-3:tag'...
+Saved ` + path + `
+`,
+		}.TestKpack(t, cmdFunc)
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.3",
+  "metadata": {"component": {"type": "container", "name": "repo.com/image-3:tag"}},
+  "components": [{"name": "a"}, {"name": "b"}]
+}`, string(content))
+	})
+
+	it("fails when the build has not produced an image", func() {
+		bld := testhelpers.MakeTestBuilds(image, defaultNamespace)[2]
+		bld.Status.LatestImage = ""
+
+		testhelpers.CommandTest{
+			Objects:             testhelpers.BuildsToRuntimeObjs([]*v1alpha2.Build{bld}),
+			Args:                []string{image},
+			ExpectErr:           true,
+			ExpectedErrorOutput: "Error: build \"" + bld.Labels[v1alpha2.BuildNumberLabel] + "\" has not produced an image\n",
+		}.TestKpack(t, cmdFunc)
+	})
+
+	it("fails with an invalid format", func() {
+		testhelpers.CommandTest{
+			Objects:             builds,
+			Args:                []string{image, "--format", "xml"},
+			ExpectErr:           true,
+			ExpectedErrorOutput: "Error: invalid sbom format 'xml', must be one of cyclonedx, spdx, syft\n",
+		}.TestKpack(t, cmdFunc)
+	})
+}
@@ -61,12 +61,24 @@ The namespace defaults to the kubernetes current-context namespace.`,
 					return err
 				}
 
+				printer, err := commands.NewDataPrinter(cmd)
+				if err != nil {
+					return err
+				} else if printer != nil {
+					output, err := newBuildStatusOutput(bld)
+					if err != nil {
+						return err
+					}
+					return printer.Print(cmd.OutOrStdout(), output)
+				}
+
 				return displayBuildStatus(cmd, bld)
 			}
 		},
 	}
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "kubernetes namespace")
 	cmd.Flags().StringVarP(&buildNumber, "build", "b", "", "build number")
+	commands.SetStatusOutputFlag(cmd)
 
 	return cmd
 }
@@ -97,7 +109,7 @@ func findBuild(buildList *v1alpha2.BuildList, buildNumberString string) (v1alpha
 }
 
 func displayBuildStatus(cmd *cobra.Command, bld v1alpha2.Build) error {
-	statusWriter := commands.NewStatusWriter(cmd.OutOrStdout())
+	statusWriter := commands.NewCommandStatusWriter(cmd)
 
 	reason, err := buildReason(bld)
 	if err != nil {
@@ -177,7 +189,7 @@ func displayBuildStatus(cmd *cobra.Command, bld v1alpha2.Build) error {
 		return err
 	}
 
-	tableWriter, err := commands.NewTableWriter(cmd.OutOrStdout(), "Buildpack Id", "Buildpack Version", "Homepage")
+	tableWriter, err := commands.NewCommandTableWriter(cmd, "Buildpack Id", "Buildpack Version", "Homepage")
 	if err != nil {
 		return err
 	}
@@ -192,6 +204,69 @@ func displayBuildStatus(cmd *cobra.Command, bld v1alpha2.Build) error {
 	return tableWriter.Write()
 }
 
+type buildStatusOutput struct {
+	Image         string                   `json:"image"`
+	Status        string                   `json:"status"`
+	Reason        string                   `json:"reason"`
+	Changes       string                   `json:"changes,omitempty"`
+	StatusReason  string                   `json:"statusReason,omitempty"`
+	StatusMessage string                   `json:"statusMessage,omitempty"`
+	Started       string                   `json:"started"`
+	Finished      string                   `json:"finished"`
+	PodName       string                   `json:"podName"`
+	Builder       string                   `json:"builder"`
+	RunImage      string                   `json:"runImage"`
+	Source        sourceOutput             `json:"source"`
+	Buildpacks    []commands.BuildpackInfo `json:"buildpacks"`
+}
+
+type sourceOutput struct {
+	Type     string `json:"type"`
+	Url      string `json:"url,omitempty"`
+	Revision string `json:"revision,omitempty"`
+}
+
+func newBuildStatusOutput(bld v1alpha2.Build) (buildStatusOutput, error) {
+	output := buildStatusOutput{
+		Image:      bld.Status.LatestImage,
+		Status:     getStatus(bld),
+		Reason:     bld.Annotations[v1alpha2.BuildReasonAnnotation],
+		Started:    getStarted(bld),
+		Finished:   getFinished(bld),
+		PodName:    bld.Status.PodName,
+		Builder:    bld.Spec.Builder.Image,
+		RunImage:   bld.Status.Stack.RunImage,
+		Source:     sourceOutput{Type: "Local Source"},
+		Buildpacks: []commands.BuildpackInfo{},
+	}
+
+	if changes, ok := bld.Annotations[v1alpha2.BuildChangesAnnotation]; ok {
+		reasons, changesStr, err := reasonsAndChanges(changes)
+		if err != nil {
+			return buildStatusOutput{}, errors.Wrapf(err, "error generating build reason from string '%s'", changes)
+		}
+		output.Reason = reasons
+		output.Changes = changesStr
+	}
+
+	if cond := bld.Status.GetCondition(corev1alpha1.ConditionSucceeded); cond != nil {
+		output.StatusReason = cond.Reason
+		output.StatusMessage = cond.Message
+	}
+
+	if bld.Spec.Source.Git != nil {
+		output.Source = sourceOutput{Type: "GitUrl", Url: bld.Spec.Source.Git.URL, Revision: bld.Spec.Source.Git.Revision}
+	} else if bld.Spec.Source.Blob != nil {
+		output.Source = sourceOutput{Type: "Blob", Url: bld.Spec.Source.Blob.URL}
+	}
+
+	for _, buildpack := range bld.Status.BuildMetadata {
+		output.Buildpacks = append(output.Buildpacks, commands.BuildpackInfo{Id: buildpack.Id, Version: buildpack.Version, Homepage: buildpack.Homepage})
+	}
+
+	return output, nil
+}
+
 func buildReason(bld v1alpha2.Build) (string, error) {
 	var err error
 	var reasonsStr, changesStr string
@@ -93,6 +93,16 @@ bp-id-2         bp-version-2         mysupercoolsite2.com
 					})
 				})
 
+				when("an output format is provided", func() {
+					it("prints the selected fields of the build status", func() {
+						testhelpers.CommandTest{
+							Objects:        builds,
+							Args:           []string{image, "-b", "1", "-o", "jsonpath={.status} {.podName} {.buildpacks[*].id}"},
+							ExpectedOutput: "SUCCESS pod-one bp-id-1 bp-id-2",
+						}.TestKpack(t, cmdFunc)
+					})
+				})
+
 				when("the build flag is not provided", func() {
 					it("shows the build status of the most recent build", func() {
 						testhelpers.CommandTest{
@@ -8,7 +8,6 @@ import (
 
 	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
-	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -41,7 +40,7 @@ The namespace defaults to the kubernetes current-context namespace.`,
 			}
 
 			if len(builderList.Items) == 0 {
-				return errors.New("no builders found")
+				return commands.EmptyList(cmd, "no builders found")
 			} else {
 				sort.Slice(builderList.Items, Sort(builderList.Items))
 				return displayClusterBuildersTable(cmd, builderList)
@@ -50,24 +49,43 @@ The namespace defaults to the kubernetes current-context namespace.`,
 	}
 
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "kubernetes namespace")
+	commands.SetListOutputFlag(cmd)
 
 	return cmd
 }
 
+type builderRow struct {
+	Name  string `json:"name"`
+	Ready string `json:"ready"`
+	Stack string `json:"stack"`
+	Image string `json:"image"`
+}
+
 func displayClusterBuildersTable(cmd *cobra.Command, builderList *v1alpha2.BuilderList) error {
-	writer, err := commands.NewTableWriter(cmd.OutOrStdout(), "Name", "Ready", "Stack", "Image")
+	rows := make([]builderRow, 0, len(builderList.Items))
+	for _, bldr := range builderList.Items {
+		rows = append(rows, builderRow{
+			Name:  bldr.ObjectMeta.Name,
+			Ready: getStatus(bldr),
+			Stack: bldr.Status.Stack.ID,
+			Image: bldr.Status.LatestImage,
+		})
+	}
+
+	printer, err := commands.NewDataPrinter(cmd)
 	if err != nil {
 		return err
+	} else if printer != nil {
+		return printer.Print(cmd.OutOrStdout(), rows)
 	}
 
-	for _, bldr := range builderList.Items {
-		err := writer.AddRow(
-			bldr.ObjectMeta.Name,
-			getStatus(bldr),
-			bldr.Status.Stack.ID,
-			bldr.Status.LatestImage,
-		)
+	writer, err := commands.NewCommandTableWriter(cmd, "Name", "Ready", "Stack", "Image")
+	if err != nil {
+		return err
+	}
 
+	for _, row := range rows {
+		err := writer.AddRow(row.Name, row.Ready, row.Stack, row.Image)
 		if err != nil {
 			return err
 		}
@@ -5,7 +5,6 @@ package builder
 
 import (
 	"fmt"
-	"io"
 
 	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
@@ -43,29 +42,37 @@ The namespace defaults to the kubernetes current-context namespace.`,
 				return err
 			}
 
-			return displayBuilderStatus(bldr, cmd.OutOrStdout())
+			printer, err := commands.NewDataPrinter(cmd)
+			if err != nil {
+				return err
+			} else if printer != nil {
+				return printer.Print(cmd.OutOrStdout(), builder.NewStatus(bldr.Spec.BuilderSpec, bldr.Status))
+			}
+
+			return displayBuilderStatus(bldr, cmd)
 		},
 	}
 
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "kubernetes namespace")
+	commands.SetStatusOutputFlag(cmd)
 
 	return cmd
 }
 
-func displayBuilderStatus(bldr *v1alpha2.Builder, writer io.Writer) error {
+func displayBuilderStatus(bldr *v1alpha2.Builder, cmd *cobra.Command) error {
 	if cond := bldr.Status.GetCondition(corev1alpha1.ConditionReady); cond != nil {
 		if cond.Status == corev1.ConditionTrue {
-			return printBuilderReadyStatus(bldr, writer)
+			return printBuilderReadyStatus(bldr, cmd)
 		} else {
-			return printBuilderNotReadyStatus(bldr, writer)
+			return printBuilderNotReadyStatus(bldr, cmd)
 		}
 	} else {
-		return printBuilderConditionUnknownStatus(bldr, writer)
+		return printBuilderConditionUnknownStatus(bldr, cmd)
 	}
 }
 
-func printBuilderConditionUnknownStatus(_ *v1alpha2.Builder, writer io.Writer) error {
-	statusWriter := commands.NewStatusWriter(writer)
+func printBuilderConditionUnknownStatus(_ *v1alpha2.Builder, cmd *cobra.Command) error {
+	statusWriter := commands.NewCommandStatusWriter(cmd)
 
 	return statusWriter.AddBlock(
 		"",
@@ -73,8 +80,8 @@ func printBuilderConditionUnknownStatus(_ *v1alpha2.Builder, writer io.Writer) e
 	)
 }
 
-func printBuilderNotReadyStatus(bldr *v1alpha2.Builder, writer io.Writer) error {
-	statusWriter := commands.NewStatusWriter(writer)
+func printBuilderNotReadyStatus(bldr *v1alpha2.Builder, cmd *cobra.Command) error {
+	statusWriter := commands.NewCommandStatusWriter(cmd)
 
 	condReady := bldr.Status.GetCondition(corev1alpha1.ConditionReady)
 
@@ -85,8 +92,8 @@ func printBuilderNotReadyStatus(bldr *v1alpha2.Builder, writer io.Writer) error
 	)
 }
 
-func printBuilderReadyStatus(bldr *v1alpha2.Builder, writer io.Writer) error {
-	statusWriter := commands.NewStatusWriter(writer)
+func printBuilderReadyStatus(bldr *v1alpha2.Builder, cmd *cobra.Command) error {
+	statusWriter := commands.NewCommandStatusWriter(cmd)
 
 	err := statusWriter.AddBlock(
 		"",
@@ -112,7 +119,7 @@ func printBuilderReadyStatus(bldr *v1alpha2.Builder, writer io.Writer) error {
 		return err
 	}
 
-	bpTableWriter, err := commands.NewTableWriter(writer, "buildpack id", "version", "homepage")
+	bpTableWriter, err := commands.NewCommandTableWriter(cmd, "buildpack id", "version", "homepage")
 	if err != nil {
 		return nil
 	}
@@ -129,12 +136,12 @@ func printBuilderReadyStatus(bldr *v1alpha2.Builder, writer io.Writer) error {
 		return err
 	}
 
-	_, err = writer.Write([]byte("\n"))
+	_, err = cmd.OutOrStdout().Write([]byte("\n"))
 	if err != nil {
 		return err
 	}
 
-	cpTableWriter, err := commands.NewTableWriter(writer, "Buildpack Name", "     Buildpack Kind")
+	cpTableWriter, err := commands.NewCommandTableWriter(cmd, "Buildpack Name", "     Buildpack Kind")
 	if err != nil {
 		return nil
 	}
@@ -151,7 +158,7 @@ func printBuilderReadyStatus(bldr *v1alpha2.Builder, writer io.Writer) error {
 		cpTableWriter.Write()
 	}
 
-	orderTableWriter, err := commands.NewTableWriter(writer, "Detection Order", "")
+	orderTableWriter, err := commands.NewCommandTableWriter(cmd, "Detection Order", "")
 	if err != nil {
 		return nil
 	}
@@ -4,11 +4,14 @@ import (
 	"context"
 	"k8s.io/apimachinery/pkg/runtime"
 
+	"github.com/buildpacks-community/kpack-cli/pkg/clusterbuildpack"
+	"github.com/buildpacks-community/kpack-cli/pkg/commands"
+	"github.com/buildpacks-community/kpack-cli/pkg/dockercreds"
+	"github.com/buildpacks-community/kpack-cli/pkg/k8s"
+	"github.com/buildpacks-community/kpack-cli/pkg/registry"
 	buildv1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
 	"github.com/spf13/cobra"
-	"github.com/buildpacks-community/kpack-cli/pkg/commands"
-	"github.com/buildpacks-community/kpack-cli/pkg/k8s"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/dynamic"
 )
@@ -18,12 +21,13 @@ const (
 )
 
 type CommandFlags struct {
+	source         clusterbuildpack.SourceConfig
 	image          string
 	namespace      string
 	serviceAccount string
 }
 
-func NewCreateCommand(clientSetProvider k8s.ClientSetProvider, newWaiter func(dynamic.Interface) commands.ResourceWaiter) *cobra.Command {
+func NewCreateCommand(clientSetProvider k8s.ClientSetProvider, rup registry.UtilProvider, newWaiter func(dynamic.Interface) commands.ResourceWaiter) *cobra.Command {
 	var (
 		flags CommandFlags
 	)
@@ -34,6 +38,9 @@ func NewCreateCommand(clientSetProvider k8s.ClientSetProvider, newWaiter func(dy
 		Long: `Create a buildpack by providing command line arguments.
 The buildpack will be created only if it does not exist in the provided namespace.
 
+Use --platform to upload only the given platforms of a multi-platform buildpackage to the default repository, otherwise the image is referenced as given.
+Use --verify-key to upload the buildpackage to the default repository only when it has a cosign signature made with the matching private key.
+
 The namespace defaults to the kubernetes current-context namespace.`,
 		Example: `kp buildpack create my-buildpack --image gcr.io/paketo-buildpacks/java
 kp buildpack create my-buildpack --image gcr.io/paketo-buildpacks/java:8.9.0
@@ -52,6 +59,13 @@ kp buildpack create my-buildpack --image gcr.io/paketo-buildpacks/java@sha256:fc
 				return err
 			}
 
+			if flags.image != "" {
+				flags.image, err = flags.source.ResolveImage(cmd.Context(), dockercreds.DefaultKeychain, ch, rup, cs.K8sClient, flags.image)
+				if err != nil {
+					return err
+				}
+			}
+
 			name := args[0]
 			flags.namespace = cs.Namespace
 
@@ -63,7 +77,10 @@ kp buildpack create my-buildpack --image gcr.io/paketo-buildpacks/java@sha256:fc
 	cmd.Flags().StringVarP(&flags.image, "image", "i", "", "registry location where the buildpack is located")
 	cmd.Flags().StringVarP(&flags.namespace, "namespace", "n", "", "kubernetes namespace")
 	cmd.Flags().StringVar(&flags.serviceAccount, "service-account", defaultServiceAccount, "service account name to use")
-	commands.SetDryRunOutputFlags(cmd)
+	commands.SetImgUploadDryRunOutputFlags(cmd)
+	commands.SetTLSFlags(cmd, &flags.source.TLSConfig)
+	commands.SetPlatformFlags(cmd, &flags.source.Platforms)
+	commands.SetVerificationFlags(cmd, &flags.source.Verification)
 	_ = cmd.MarkFlagRequired("image")
 	return cmd
 }
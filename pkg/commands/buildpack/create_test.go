@@ -17,6 +17,8 @@ import (
 	"github.com/buildpacks-community/kpack-cli/pkg/commands/buildpack"
 	commandsfakes "github.com/buildpacks-community/kpack-cli/pkg/commands/fakes"
 	"github.com/buildpacks-community/kpack-cli/pkg/k8s"
+	"github.com/buildpacks-community/kpack-cli/pkg/registry"
+	registryfakes "github.com/buildpacks-community/kpack-cli/pkg/registry/fakes"
 	"github.com/buildpacks-community/kpack-cli/pkg/testhelpers"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -36,7 +38,7 @@ func setLastAppliedAnnotation(b *buildv1alpha2.Buildpack) error {
 	return nil
 }
 
-func testCreateCommand(cmd func(clientSetProvider k8s.ClientSetProvider, newWaiter func(dynamic.Interface) commands.ResourceWaiter) *cobra.Command) func(t *testing.T, when spec.G, it spec.S) {
+func testCreateCommand(cmd func(clientSetProvider k8s.ClientSetProvider, rup registry.UtilProvider, newWaiter func(dynamic.Interface) commands.ResourceWaiter) *cobra.Command) func(t *testing.T, when spec.G, it spec.S) {
 	return func(t *testing.T, when spec.G, it spec.S) {
 		const defaultNamespace = "some-default-namespace"
 		var expectedBuildpack *buildv1alpha2.Buildpack
@@ -62,10 +64,11 @@ func testCreateCommand(cmd func(clientSetProvider k8s.ClientSetProvider, newWait
 		})
 
 		fakeWaiter := &commandsfakes.FakeWaiter{}
+		fakeFetcher := &registryfakes.Fetcher{}
 
 		cmdFunc := func(clientSet *fake.Clientset) *cobra.Command {
 			clientSetProvider := testhelpers.GetFakeKpackProvider(clientSet, defaultNamespace)
-			return cmd(clientSetProvider, func(dynamic.Interface) commands.ResourceWaiter {
+			return cmd(clientSetProvider, registryfakes.UtilProvider{FakeFetcher: fakeFetcher}, func(dynamic.Interface) commands.ResourceWaiter {
 				return fakeWaiter
 			})
 		}
@@ -8,7 +8,6 @@ import (
 
 	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
-	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -41,7 +40,7 @@ The namespace defaults to the kubernetes current-context namespace.`,
 			}
 
 			if len(bpList.Items) == 0 {
-				return errors.New("no buildpacks found")
+				return commands.EmptyList(cmd, "no buildpacks found")
 			} else {
 				sort.Slice(bpList.Items, Sort(bpList.Items))
 
@@ -51,23 +50,41 @@ The namespace defaults to the kubernetes current-context namespace.`,
 	}
 
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "kubernetes namespace")
+	commands.SetListOutputFlag(cmd)
 
 	return cmd
 }
 
+type buildpackRow struct {
+	Name  string `json:"name"`
+	Ready string `json:"ready"`
+	Image string `json:"image"`
+}
+
 func displayBuildpacksTable(cmd *cobra.Command, bpList *v1alpha2.BuildpackList) error {
-	writer, err := commands.NewTableWriter(cmd.OutOrStdout(), "Name", "Ready", "Image")
+	rows := make([]buildpackRow, 0, len(bpList.Items))
+	for _, bp := range bpList.Items {
+		rows = append(rows, buildpackRow{
+			Name:  bp.Name,
+			Ready: getStatus(bp),
+			Image: bp.Spec.Image,
+		})
+	}
+
+	printer, err := commands.NewDataPrinter(cmd)
 	if err != nil {
 		return err
+	} else if printer != nil {
+		return printer.Print(cmd.OutOrStdout(), rows)
 	}
 
-	for _, bp := range bpList.Items {
-		err := writer.AddRow(
-			bp.Name,
-			getStatus(bp),
-			bp.Spec.Image,
-		)
+	writer, err := commands.NewCommandTableWriter(cmd, "Name", "Ready", "Image")
+	if err != nil {
+		return err
+	}
 
+	for _, row := range rows {
+		err := writer.AddRow(row.Name, row.Ready, row.Image)
 		if err != nil {
 			return err
 		}
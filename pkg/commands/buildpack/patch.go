@@ -14,10 +14,12 @@ import (
 	"k8s.io/client-go/dynamic"
 
 	"github.com/buildpacks-community/kpack-cli/pkg/commands"
+	"github.com/buildpacks-community/kpack-cli/pkg/dockercreds"
 	"github.com/buildpacks-community/kpack-cli/pkg/k8s"
+	"github.com/buildpacks-community/kpack-cli/pkg/registry"
 )
 
-func NewPatchCommand(clientSetProvider k8s.ClientSetProvider, newWaiter func(dynamic.Interface) commands.ResourceWaiter) *cobra.Command {
+func NewPatchCommand(clientSetProvider k8s.ClientSetProvider, rup registry.UtilProvider, newWaiter func(dynamic.Interface) commands.ResourceWaiter) *cobra.Command {
 	var (
 		flags CommandFlags
 	)
@@ -43,6 +45,13 @@ kp buildpack patch my-buildpack --service-account my-other-sa`,
 				return err
 			}
 
+			if flags.image != "" {
+				flags.image, err = flags.source.ResolveImage(cmd.Context(), dockercreds.DefaultKeychain, ch, rup, cs.K8sClient, flags.image)
+				if err != nil {
+					return err
+				}
+			}
+
 			name := args[0]
 			flags.namespace = cs.Namespace
 
@@ -60,7 +69,10 @@ kp buildpack patch my-buildpack --service-account my-other-sa`,
 	cmd.Flags().StringVarP(&flags.image, "image", "i", "", "registry location where the buildpack is located")
 	cmd.Flags().StringVarP(&flags.namespace, "namespace", "n", "", "kubernetes namespace")
 	cmd.Flags().StringVar(&flags.serviceAccount, "service-account", "", "service account name to use")
-	commands.SetDryRunOutputFlags(cmd)
+	commands.SetImgUploadDryRunOutputFlags(cmd)
+	commands.SetTLSFlags(cmd, &flags.source.TLSConfig)
+	commands.SetPlatformFlags(cmd, &flags.source.Platforms)
+	commands.SetVerificationFlags(cmd, &flags.source.Verification)
 	return cmd
 }
 
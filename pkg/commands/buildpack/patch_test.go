@@ -20,6 +20,8 @@ import (
 	"github.com/buildpacks-community/kpack-cli/pkg/commands/buildpack"
 	commandsfakes "github.com/buildpacks-community/kpack-cli/pkg/commands/fakes"
 	"github.com/buildpacks-community/kpack-cli/pkg/k8s"
+	"github.com/buildpacks-community/kpack-cli/pkg/registry"
+	registryfakes "github.com/buildpacks-community/kpack-cli/pkg/registry/fakes"
 	"github.com/buildpacks-community/kpack-cli/pkg/testhelpers"
 )
 
@@ -27,7 +29,7 @@ func TestBuildpackPatchCommand(t *testing.T) {
 	spec.Run(t, "TestBuildpackPatchCommand", testPatchCommand(buildpack.NewPatchCommand))
 }
 
-func testPatchCommand(cmd func(clientSetProvider k8s.ClientSetProvider, newWaiter func(dynamic.Interface) commands.ResourceWaiter) *cobra.Command) func(t *testing.T, when spec.G, it spec.S) {
+func testPatchCommand(cmd func(clientSetProvider k8s.ClientSetProvider, rup registry.UtilProvider, newWaiter func(dynamic.Interface) commands.ResourceWaiter) *cobra.Command) func(t *testing.T, when spec.G, it spec.S) {
 	return func(t *testing.T, when spec.G, it spec.S) {
 		const defaultNamespace = "some-default-namespace"
 
@@ -51,10 +53,11 @@ func testPatchCommand(cmd func(clientSetProvider k8s.ClientSetProvider, newWaite
 		)
 
 		fakeWaiter := &commandsfakes.FakeWaiter{}
+		fakeFetcher := &registryfakes.Fetcher{}
 
 		cmdFunc := func(clientSet *fake.Clientset) *cobra.Command {
 			clientSetProvider := testhelpers.GetFakeKpackProvider(clientSet, defaultNamespace)
-			return cmd(clientSetProvider, func(dynamic.Interface) commands.ResourceWaiter {
+			return cmd(clientSetProvider, registryfakes.UtilProvider{FakeFetcher: fakeFetcher}, func(dynamic.Interface) commands.ResourceWaiter {
 				return fakeWaiter
 			})
 		}
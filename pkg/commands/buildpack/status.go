@@ -4,8 +4,6 @@
 package buildpack
 
 import (
-	"io"
-
 	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
 	"github.com/spf13/cobra"
@@ -41,29 +39,37 @@ The namespace defaults to the kubernetes current-context namespace.`,
 				return err
 			}
 
-			return displayBuildpackStatus(bp, cmd.OutOrStdout())
+			printer, err := commands.NewDataPrinter(cmd)
+			if err != nil {
+				return err
+			} else if printer != nil {
+				return printer.Print(cmd.OutOrStdout(), newBuildpackStatusOutput(bp))
+			}
+
+			return displayBuildpackStatus(bp, cmd)
 		},
 	}
 
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "kubernetes namespace")
+	commands.SetStatusOutputFlag(cmd)
 
 	return cmd
 }
 
-func displayBuildpackStatus(bp *v1alpha2.Buildpack, writer io.Writer) error {
+func displayBuildpackStatus(bp *v1alpha2.Buildpack, cmd *cobra.Command) error {
 	if cond := bp.Status.GetCondition(corev1alpha1.ConditionReady); cond != nil {
 		if cond.Status == corev1.ConditionTrue {
-			return printBuildpackReadyStatus(bp, writer)
+			return printBuildpackReadyStatus(bp, cmd)
 		} else {
-			return printBuildpackNotReadyStatus(bp, writer)
+			return printBuildpackNotReadyStatus(bp, cmd)
 		}
 	} else {
-		return printBuildpackConditionUnknownStatus(bp, writer)
+		return printBuildpackConditionUnknownStatus(bp, cmd)
 	}
 }
 
-func printBuildpackConditionUnknownStatus(_ *v1alpha2.Buildpack, writer io.Writer) error {
-	statusWriter := commands.NewStatusWriter(writer)
+func printBuildpackConditionUnknownStatus(_ *v1alpha2.Buildpack, cmd *cobra.Command) error {
+	statusWriter := commands.NewCommandStatusWriter(cmd)
 
 	return statusWriter.AddBlock(
 		"",
@@ -71,8 +77,8 @@ func printBuildpackConditionUnknownStatus(_ *v1alpha2.Buildpack, writer io.Write
 	)
 }
 
-func printBuildpackNotReadyStatus(bp *v1alpha2.Buildpack, writer io.Writer) error {
-	statusWriter := commands.NewStatusWriter(writer)
+func printBuildpackNotReadyStatus(bp *v1alpha2.Buildpack, cmd *cobra.Command) error {
+	statusWriter := commands.NewCommandStatusWriter(cmd)
 
 	condReady := bp.Status.GetCondition(corev1alpha1.ConditionReady)
 
@@ -83,8 +89,8 @@ func printBuildpackNotReadyStatus(bp *v1alpha2.Buildpack, writer io.Writer) erro
 	)
 }
 
-func printBuildpackReadyStatus(bp *v1alpha2.Buildpack, writer io.Writer) error {
-	statusWriter := commands.NewStatusWriter(writer)
+func printBuildpackReadyStatus(bp *v1alpha2.Buildpack, cmd *cobra.Command) error {
+	statusWriter := commands.NewCommandStatusWriter(cmd)
 
 	err := statusWriter.AddBlock(
 		"",
@@ -95,7 +101,7 @@ func printBuildpackReadyStatus(bp *v1alpha2.Buildpack, writer io.Writer) error {
 		return err
 	}
 
-	bpTableWriter, err := commands.NewTableWriter(writer, "buildpack id", "version", "homepage")
+	bpTableWriter, err := commands.NewCommandTableWriter(cmd, "buildpack id", "version", "homepage")
 	if err != nil {
 		return nil
 	}
@@ -109,3 +115,25 @@ func printBuildpackReadyStatus(bp *v1alpha2.Buildpack, writer io.Writer) error {
 
 	return bpTableWriter.Write()
 }
+
+type buildpackStatusOutput struct {
+	Status     string                   `json:"status"`
+	Reason     string                   `json:"reason,omitempty"`
+	Source     string                   `json:"source,omitempty"`
+	Buildpacks []commands.BuildpackInfo `json:"buildpacks,omitempty"`
+}
+
+func newBuildpackStatusOutput(bp *v1alpha2.Buildpack) buildpackStatusOutput {
+	cond := bp.Status.GetCondition(corev1alpha1.ConditionReady)
+	if cond == nil {
+		return buildpackStatusOutput{Status: "Unknown"}
+	} else if cond.Status != corev1.ConditionTrue {
+		return buildpackStatusOutput{Status: "Not Ready", Reason: cond.Message}
+	}
+
+	output := buildpackStatusOutput{Status: "Ready", Source: bp.Spec.Image}
+	for _, bpStatus := range bp.Status.Buildpacks {
+		output.Buildpacks = append(output.Buildpacks, commands.BuildpackInfo{Id: bpStatus.Id, Version: bpStatus.Version, Homepage: bpStatus.Homepage})
+	}
+	return output
+}
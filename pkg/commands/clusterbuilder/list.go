@@ -8,7 +8,6 @@ import (
 
 	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
-	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -36,7 +35,7 @@ func NewListCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 			}
 
 			if len(clusterBuilderList.Items) == 0 {
-				return errors.New("no clusterbuilders found")
+				return commands.EmptyList(cmd, "no clusterbuilders found")
 			} else {
 				sort.Slice(clusterBuilderList.Items, Sort(clusterBuilderList.Items))
 				return displayClusterBuildersTable(cmd, clusterBuilderList)
@@ -44,23 +43,43 @@ func NewListCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 		},
 	}
 
+	commands.SetListOutputFlag(cmd)
+
 	return cmd
 }
 
+type builderRow struct {
+	Name  string `json:"name"`
+	Ready string `json:"ready"`
+	Stack string `json:"stack"`
+	Image string `json:"image"`
+}
+
 func displayClusterBuildersTable(cmd *cobra.Command, builderList *v1alpha2.ClusterBuilderList) error {
-	writer, err := commands.NewTableWriter(cmd.OutOrStdout(), "Name", "Ready", "Stack", "Image")
+	rows := make([]builderRow, 0, len(builderList.Items))
+	for _, bldr := range builderList.Items {
+		rows = append(rows, builderRow{
+			Name:  bldr.ObjectMeta.Name,
+			Ready: getStatus(bldr),
+			Stack: bldr.Status.Stack.ID,
+			Image: bldr.Status.LatestImage,
+		})
+	}
+
+	printer, err := commands.NewDataPrinter(cmd)
 	if err != nil {
 		return err
+	} else if printer != nil {
+		return printer.Print(cmd.OutOrStdout(), rows)
 	}
 
-	for _, bldr := range builderList.Items {
-		err := writer.AddRow(
-			bldr.ObjectMeta.Name,
-			getStatus(bldr),
-			bldr.Status.Stack.ID,
-			bldr.Status.LatestImage,
-		)
+	writer, err := commands.NewCommandTableWriter(cmd, "Name", "Ready", "Stack", "Image")
+	if err != nil {
+		return err
+	}
 
+	for _, row := range rows {
+		err := writer.AddRow(row.Name, row.Ready, row.Stack, row.Image)
 		if err != nil {
 			return err
 		}
@@ -5,7 +5,6 @@ package clusterbuilder
 
 import (
 	"fmt"
-	"io"
 
 	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
@@ -38,27 +37,36 @@ func NewStatusCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 				return err
 			}
 
-			return displayBuilderStatus(bldr, cmd.OutOrStdout())
+			printer, err := commands.NewDataPrinter(cmd)
+			if err != nil {
+				return err
+			} else if printer != nil {
+				return printer.Print(cmd.OutOrStdout(), builder.NewStatus(bldr.Spec.BuilderSpec, bldr.Status))
+			}
+
+			return displayBuilderStatus(bldr, cmd)
 		},
 	}
 
+	commands.SetStatusOutputFlag(cmd)
+
 	return cmd
 }
 
-func displayBuilderStatus(bldr *v1alpha2.ClusterBuilder, writer io.Writer) error {
+func displayBuilderStatus(bldr *v1alpha2.ClusterBuilder, cmd *cobra.Command) error {
 	if cond := bldr.Status.GetCondition(corev1alpha1.ConditionReady); cond != nil {
 		if cond.Status == corev1.ConditionTrue {
-			return printBuilderReadyStatus(bldr, writer)
+			return printBuilderReadyStatus(bldr, cmd)
 		} else {
-			return printBuilderNotReadyStatus(bldr, writer)
+			return printBuilderNotReadyStatus(bldr, cmd)
 		}
 	} else {
-		return printBuilderConditionUnknownStatus(bldr, writer)
+		return printBuilderConditionUnknownStatus(bldr, cmd)
 	}
 }
 
-func printBuilderConditionUnknownStatus(_ *v1alpha2.ClusterBuilder, writer io.Writer) error {
-	statusWriter := commands.NewStatusWriter(writer)
+func printBuilderConditionUnknownStatus(_ *v1alpha2.ClusterBuilder, cmd *cobra.Command) error {
+	statusWriter := commands.NewCommandStatusWriter(cmd)
 
 	return statusWriter.AddBlock(
 		"",
@@ -66,8 +74,8 @@ func printBuilderConditionUnknownStatus(_ *v1alpha2.ClusterBuilder, writer io.Wr
 	)
 }
 
-func printBuilderNotReadyStatus(bldr *v1alpha2.ClusterBuilder, writer io.Writer) error {
-	statusWriter := commands.NewStatusWriter(writer)
+func printBuilderNotReadyStatus(bldr *v1alpha2.ClusterBuilder, cmd *cobra.Command) error {
+	statusWriter := commands.NewCommandStatusWriter(cmd)
 
 	condReady := bldr.Status.GetCondition(corev1alpha1.ConditionReady)
 
@@ -78,8 +86,8 @@ func printBuilderNotReadyStatus(bldr *v1alpha2.ClusterBuilder, writer io.Writer)
 	)
 }
 
-func printBuilderReadyStatus(bldr *v1alpha2.ClusterBuilder, writer io.Writer) error {
-	statusWriter := commands.NewStatusWriter(writer)
+func printBuilderReadyStatus(bldr *v1alpha2.ClusterBuilder, cmd *cobra.Command) error {
+	statusWriter := commands.NewCommandStatusWriter(cmd)
 
 	err := statusWriter.AddBlock(
 		"",
@@ -105,7 +113,7 @@ func printBuilderReadyStatus(bldr *v1alpha2.ClusterBuilder, writer io.Writer) er
 		return err
 	}
 
-	bpTableWriter, err := commands.NewTableWriter(writer, "buildpack id", "version", "homepage")
+	bpTableWriter, err := commands.NewCommandTableWriter(cmd, "buildpack id", "version", "homepage")
 	if err != nil {
 		return nil
 	}
@@ -122,12 +130,12 @@ func printBuilderReadyStatus(bldr *v1alpha2.ClusterBuilder, writer io.Writer) er
 		return err
 	}
 
-	_, err = writer.Write([]byte("\n"))
+	_, err = cmd.OutOrStdout().Write([]byte("\n"))
 	if err != nil {
 		return err
 	}
 
-	cpTableWriter, err := commands.NewTableWriter(writer, "ClusterBuildpack Name", "     ClusterBuildpack Kind")
+	cpTableWriter, err := commands.NewCommandTableWriter(cmd, "ClusterBuildpack Name", "     ClusterBuildpack Kind")
 	if err != nil {
 		return nil
 	}
@@ -144,7 +152,7 @@ func printBuilderReadyStatus(bldr *v1alpha2.ClusterBuilder, writer io.Writer) er
 		cpTableWriter.Write()
 	}
 
-	orderTableWriter, err := commands.NewTableWriter(writer, "Detection Order", "")
+	orderTableWriter, err := commands.NewCommandTableWriter(cmd, "Detection Order", "")
 	if err != nil {
 		return nil
 	}
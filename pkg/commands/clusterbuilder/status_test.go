@@ -335,6 +335,46 @@ Reason:    this builder is not ready for the purpose of a test
 					})
 
 				})
+
+				it("prints the status as json when --output is json", func() {
+					testhelpers.CommandTest{
+						Objects: []runtime.Object{readyClusterBuilder},
+						Args:    []string{"test-builder-1", "-o", "json"},
+						ExpectedOutput: `{
+    "status": "Ready",
+    "image": "some-registry.com/test-builder-1:tag",
+    "stackId": "io.buildpacks.stacks.centos",
+    "runImage": "gcr.io/paketo-buildpacks/run@sha256:iweuryaksdjhf9203847098234",
+    "stackRef": {
+        "name": "test-stack",
+        "kind": "ClusterStack"
+    },
+    "storeRef": {
+        "name": "test-store",
+        "kind": "ClusterStore"
+    },
+    "buildpacks": [
+        {
+            "id": "org.cloudfoundry.nodejs",
+            "version": "v0.2.1",
+            "homepage": "https://github.com/paketo-buildpacks/nodejs"
+        },
+        {
+            "id": "org.cloudfoundry.go",
+            "version": "v0.0.3",
+            "homepage": "https://github.com/paketo-buildpacks/go"
+        }
+    ],
+    "buildpackRefs": [
+        {
+            "name": "sample-cluster-buildpack",
+            "kind": "ClusterBuildpack"
+        }
+    ]
+}
+`,
+					}.TestKpack(t, cmdFunc)
+				})
 			})
 
 			when("the builder is not ready", func() {
@@ -4,12 +4,15 @@ import (
 	"context"
 	"k8s.io/apimachinery/pkg/runtime"
 
-	buildv1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
-	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
-	"github.com/spf13/cobra"
+	"github.com/buildpacks-community/kpack-cli/pkg/clusterbuildpack"
 	"github.com/buildpacks-community/kpack-cli/pkg/commands"
 	"github.com/buildpacks-community/kpack-cli/pkg/config"
+	"github.com/buildpacks-community/kpack-cli/pkg/dockercreds"
 	"github.com/buildpacks-community/kpack-cli/pkg/k8s"
+	"github.com/buildpacks-community/kpack-cli/pkg/registry"
+	buildv1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/dynamic"
 )
@@ -19,10 +22,11 @@ const (
 )
 
 type CommandFlags struct {
-	image string
+	source clusterbuildpack.SourceConfig
+	image  string
 }
 
-func NewCreateCommand(clientSetProvider k8s.ClientSetProvider, newWaiter func(dynamic.Interface) commands.ResourceWaiter) *cobra.Command {
+func NewCreateCommand(clientSetProvider k8s.ClientSetProvider, rup registry.UtilProvider, newWaiter func(dynamic.Interface) commands.ResourceWaiter) *cobra.Command {
 	var (
 		flags CommandFlags
 	)
@@ -33,6 +37,9 @@ func NewCreateCommand(clientSetProvider k8s.ClientSetProvider, newWaiter func(dy
 		Long: `Create a cluster buildpack by providing command line arguments.
 
 The default service account used is read from the "default.repository.serviceaccount" key in the "kp-config" ConfigMap within "kpack" namespace.
+
+Use --platform to upload only the given platforms of a multi-platform buildpackage to the default repository, otherwise the image is referenced as given.
+Use --verify-key to upload the buildpackage to the default repository only when it has a cosign signature made with the matching private key.
 `,
 		Example: `kp clusterbuildpack create my-cluster-buildpack --image gcr.io/paketo-buildpacks/java
 kp clusterbuildpack create my-cluster-buildpack --image gcr.io/paketo-buildpacks/java:8.9.0
@@ -51,6 +58,13 @@ kp clusterbuildpack create my-cluster-buildpack --image gcr.io/paketo-buildpacks
 				return err
 			}
 
+			if flags.image != "" {
+				flags.image, err = flags.source.ResolveImage(cmd.Context(), dockercreds.DefaultKeychain, ch, rup, cs.K8sClient, flags.image)
+				if err != nil {
+					return err
+				}
+			}
+
 			name := args[0]
 
 			ctx := cmd.Context()
@@ -59,7 +73,10 @@ kp clusterbuildpack create my-cluster-buildpack --image gcr.io/paketo-buildpacks
 	}
 
 	cmd.Flags().StringVarP(&flags.image, "image", "i", "", "registry location where the cluster buildpack is located")
-	commands.SetDryRunOutputFlags(cmd)
+	commands.SetImgUploadDryRunOutputFlags(cmd)
+	commands.SetTLSFlags(cmd, &flags.source.TLSConfig)
+	commands.SetPlatformFlags(cmd, &flags.source.Platforms)
+	commands.SetVerificationFlags(cmd, &flags.source.Verification)
 	_ = cmd.MarkFlagRequired("image")
 	return cmd
 }
@@ -4,9 +4,11 @@
 package clusterbuildpack_test
 
 import (
+	"crypto/ecdsa"
 	"encoding/json"
 	"testing"
 
+	v1 "github.com/google/go-containerregistry/pkg/v1"
 	buildv1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
 	kpackfakes "github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
@@ -23,6 +25,8 @@ import (
 	"github.com/buildpacks-community/kpack-cli/pkg/commands/clusterbuildpack"
 	commandsfakes "github.com/buildpacks-community/kpack-cli/pkg/commands/fakes"
 	"github.com/buildpacks-community/kpack-cli/pkg/k8s"
+	"github.com/buildpacks-community/kpack-cli/pkg/registry"
+	registryfakes "github.com/buildpacks-community/kpack-cli/pkg/registry/fakes"
 	"github.com/buildpacks-community/kpack-cli/pkg/testhelpers"
 )
 
@@ -39,7 +43,7 @@ func setLastAppliedAnnotation(b *buildv1alpha2.ClusterBuildpack) error {
 	return nil
 }
 
-func testCreateCommand(cmd func(clientSetProvider k8s.ClientSetProvider, newWaiter func(dynamic.Interface) commands.ResourceWaiter) *cobra.Command) func(t *testing.T, when spec.G, it spec.S) {
+func testCreateCommand(cmd func(clientSetProvider k8s.ClientSetProvider, rup registry.UtilProvider, newWaiter func(dynamic.Interface) commands.ResourceWaiter) *cobra.Command) func(t *testing.T, when spec.G, it spec.S) {
 	return func(t *testing.T, when spec.G, it spec.S) {
 		var (
 			expectedClusterBuildpack *buildv1alpha2.ClusterBuildpack
@@ -50,6 +54,7 @@ func testCreateCommand(cmd func(clientSetProvider k8s.ClientSetProvider, newWait
 					Namespace: "kpack",
 				},
 				Data: map[string]string{
+					"default.repository":                          "default-registry.io/default-repo",
 					"default.repository.serviceaccount":           "some-serviceaccount",
 					"default.repository.serviceaccount.namespace": "some-namespace",
 				},
@@ -79,10 +84,11 @@ func testCreateCommand(cmd func(clientSetProvider k8s.ClientSetProvider, newWait
 		})
 
 		fakeWaiter := &commandsfakes.FakeWaiter{}
+		fakeFetcher := &registryfakes.Fetcher{}
 
 		cmdFunc := func(k8sClientset *k8sfakes.Clientset, kpackClientSet *kpackfakes.Clientset) *cobra.Command {
 			clientSetProvider := testhelpers.GetFakeClusterProvider(k8sClientset, kpackClientSet)
-			return cmd(clientSetProvider, func(dynamic.Interface) commands.ResourceWaiter {
+			return cmd(clientSetProvider, registryfakes.UtilProvider{FakeFetcher: fakeFetcher}, func(dynamic.Interface) commands.ResourceWaiter {
 				return fakeWaiter
 			})
 		}
@@ -106,6 +112,130 @@ func testCreateCommand(cmd func(clientSetProvider k8s.ClientSetProvider, newWait
 			require.Len(t, fakeWaiter.WaitCalls, 1)
 		})
 
+		when("platform flag is used", func() {
+			it("uploads the buildpackage to the default repository", func() {
+				fakeFetcher.AddBuildpackImages(registryfakes.BuildpackImgInfo{
+					Id: "test-buildpack-id",
+					ImageInfo: registryfakes.ImageInfo{
+						Ref:    "some-registry.com/test-buildpack",
+						Digest: "buildpack-digest",
+					},
+				})
+				expectedClusterBuildpack.Spec.Image = "default-registry.io/default-repo@sha256:buildpack-digest"
+				require.NoError(t, setLastAppliedAnnotation(expectedClusterBuildpack))
+
+				testhelpers.CommandTest{
+					Objects: []runtime.Object{
+						config,
+					},
+					Args: []string{
+						expectedClusterBuildpack.Name,
+						"--image", "some-registry.com/test-buildpack",
+						"--platform", "linux/amd64",
+					},
+					ExpectedOutput: `Uploading to 'default-registry.io/default-repo'...
+	Uploading 'default-registry.io/default-repo@sha256:buildpack-digest'
+Cluster Buildpack "test-buildpack" created
+`,
+					ExpectCreates: []runtime.Object{
+						expectedClusterBuildpack,
+					},
+				}.TestK8sAndKpack(t, cmdFunc)
+			})
+
+			it("fails with an invalid platform", func() {
+				testhelpers.CommandTest{
+					Objects: []runtime.Object{
+						config,
+					},
+					Args: []string{
+						expectedClusterBuildpack.Name,
+						"--image", "some-registry.com/test-buildpack",
+						"--platform", "linux/amd64/v8/extra",
+					},
+					ExpectErr:           true,
+					ExpectedErrorOutput: "Error: invalid platform 'linux/amd64/v8/extra': too many slashes in platform spec: linux/amd64/v8/extra\n",
+				}.TestK8sAndKpack(t, cmdFunc)
+			})
+		})
+
+		when("verify-key flag is used", func() {
+			var publicKey string
+
+			it.Before(func() {
+				var key *ecdsa.PrivateKey
+				key, publicKey = testhelpers.MakeCosignKey(t)
+
+				fakeFetcher.AddBuildpackImages(registryfakes.BuildpackImgInfo{
+					Id: "test-buildpack-id",
+					ImageInfo: registryfakes.ImageInfo{
+						Ref:    "some-registry.com/test-buildpack",
+						Digest: "buildpack-digest",
+					},
+				})
+				fakeFetcher.AddImage(
+					"some-registry.com/test-buildpack:sha256-buildpack-digest.sig",
+					testhelpers.MakeCosignSignature(t, key, v1.Hash{Algorithm: "sha256", Hex: "buildpack-digest"}),
+				)
+			})
+
+			it("uploads the verified buildpackage to the default repository", func() {
+				expectedClusterBuildpack.Spec.Image = "default-registry.io/default-repo@sha256:buildpack-digest"
+				require.NoError(t, setLastAppliedAnnotation(expectedClusterBuildpack))
+
+				testhelpers.CommandTest{
+					Objects: []runtime.Object{
+						config,
+					},
+					Args: []string{
+						expectedClusterBuildpack.Name,
+						"--image", "some-registry.com/test-buildpack",
+						"--verify-key", publicKey,
+					},
+					ExpectedOutput: `Uploading to 'default-registry.io/default-repo'...
+	Uploading 'default-registry.io/default-repo@sha256:buildpack-digest'
+Cluster Buildpack "test-buildpack" created
+`,
+					ExpectCreates: []runtime.Object{
+						expectedClusterBuildpack,
+					},
+				}.TestK8sAndKpack(t, cmdFunc)
+			})
+
+			it("fails when the buildpackage is not signed with the matching key", func() {
+				_, otherPublicKey := testhelpers.MakeCosignKey(t)
+
+				testhelpers.CommandTest{
+					Objects: []runtime.Object{
+						config,
+					},
+					Args: []string{
+						expectedClusterBuildpack.Name,
+						"--image", "some-registry.com/test-buildpack",
+						"--verify-key", otherPublicKey,
+					},
+					ExpectErr:           true,
+					ExpectedErrorOutput: "Error: invalid buildpack image: image 'some-registry.com/test-buildpack' failed signature verification: no signature matches the public key\n",
+				}.TestK8sAndKpack(t, cmdFunc)
+			})
+
+			it("cannot be used with the platform flag", func() {
+				testhelpers.CommandTest{
+					Objects: []runtime.Object{
+						config,
+					},
+					Args: []string{
+						expectedClusterBuildpack.Name,
+						"--image", "some-registry.com/test-buildpack",
+						"--verify-key", publicKey,
+						"--platform", "linux/amd64",
+					},
+					ExpectErr:           true,
+					ExpectedErrorOutput: "Error: platforms cannot be filtered when verifying signatures, the filtered images would not match the signed digests\n",
+				}.TestK8sAndKpack(t, cmdFunc)
+			})
+		})
+
 		when("output flag is used", func() {
 			it("can output in yaml format", func() {
 				require.NoError(t, setLastAppliedAnnotation(expectedClusterBuildpack))
@@ -8,7 +8,6 @@ import (
 
 	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
-	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -36,7 +35,7 @@ func NewListCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 			}
 
 			if len(cbpList.Items) == 0 {
-				return errors.New("no cluster buildpacks found")
+				return commands.EmptyList(cmd, "no cluster buildpacks found")
 			} else {
 				sort.Slice(cbpList.Items, Sort(cbpList.Items))
 
@@ -45,22 +44,41 @@ func NewListCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 		},
 	}
 
+	commands.SetListOutputFlag(cmd)
+
 	return cmd
 }
 
+type buildpackRow struct {
+	Name  string `json:"name"`
+	Ready string `json:"ready"`
+	Image string `json:"image"`
+}
+
 func displayClusterBuildpacksTable(cmd *cobra.Command, cbpList *v1alpha2.ClusterBuildpackList) error {
-	writer, err := commands.NewTableWriter(cmd.OutOrStdout(), "Name", "Ready", "Image")
+	rows := make([]buildpackRow, 0, len(cbpList.Items))
+	for _, cbp := range cbpList.Items {
+		rows = append(rows, buildpackRow{
+			Name:  cbp.Name,
+			Ready: getStatus(cbp),
+			Image: cbp.Spec.Image,
+		})
+	}
+
+	printer, err := commands.NewDataPrinter(cmd)
 	if err != nil {
 		return err
+	} else if printer != nil {
+		return printer.Print(cmd.OutOrStdout(), rows)
 	}
 
-	for _, cbp := range cbpList.Items {
-		err := writer.AddRow(
-			cbp.Name,
-			getStatus(cbp),
-			cbp.Spec.Image,
-		)
+	writer, err := commands.NewCommandTableWriter(cmd, "Name", "Ready", "Image")
+	if err != nil {
+		return err
+	}
 
+	for _, row := range rows {
+		err := writer.AddRow(row.Name, row.Ready, row.Image)
 		if err != nil {
 			return err
 		}
@@ -14,10 +14,12 @@ import (
 	"k8s.io/client-go/dynamic"
 
 	"github.com/buildpacks-community/kpack-cli/pkg/commands"
+	"github.com/buildpacks-community/kpack-cli/pkg/dockercreds"
 	"github.com/buildpacks-community/kpack-cli/pkg/k8s"
+	"github.com/buildpacks-community/kpack-cli/pkg/registry"
 )
 
-func NewPatchCommand(clientSetProvider k8s.ClientSetProvider, newWaiter func(dynamic.Interface) commands.ResourceWaiter) *cobra.Command {
+func NewPatchCommand(clientSetProvider k8s.ClientSetProvider, rup registry.UtilProvider, newWaiter func(dynamic.Interface) commands.ResourceWaiter) *cobra.Command {
 	var (
 		flags CommandFlags
 	)
@@ -40,6 +42,13 @@ func NewPatchCommand(clientSetProvider k8s.ClientSetProvider, newWaiter func(dyn
 				return err
 			}
 
+			if flags.image != "" {
+				flags.image, err = flags.source.ResolveImage(cmd.Context(), dockercreds.DefaultKeychain, ch, rup, cs.K8sClient, flags.image)
+				if err != nil {
+					return err
+				}
+			}
+
 			name := args[0]
 
 			ctx := cmd.Context()
@@ -53,7 +62,10 @@ func NewPatchCommand(clientSetProvider k8s.ClientSetProvider, newWaiter func(dyn
 	}
 
 	cmd.Flags().StringVarP(&flags.image, "image", "i", "", "registry location where the buildpack is located")
-	commands.SetDryRunOutputFlags(cmd)
+	commands.SetImgUploadDryRunOutputFlags(cmd)
+	commands.SetTLSFlags(cmd, &flags.source.TLSConfig)
+	commands.SetPlatformFlags(cmd, &flags.source.Platforms)
+	commands.SetVerificationFlags(cmd, &flags.source.Verification)
 	return cmd
 }
 
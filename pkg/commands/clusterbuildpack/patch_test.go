@@ -6,6 +6,7 @@ package clusterbuildpack_test
 import (
 	"testing"
 
+	v1 "github.com/google/go-containerregistry/pkg/v1"
 	buildv1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
 	"github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
@@ -16,11 +17,14 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/dynamic"
+	k8sfakes "k8s.io/client-go/kubernetes/fake"
 
 	"github.com/buildpacks-community/kpack-cli/pkg/commands"
 	"github.com/buildpacks-community/kpack-cli/pkg/commands/clusterbuildpack"
 	commandsfakes "github.com/buildpacks-community/kpack-cli/pkg/commands/fakes"
 	"github.com/buildpacks-community/kpack-cli/pkg/k8s"
+	"github.com/buildpacks-community/kpack-cli/pkg/registry"
+	registryfakes "github.com/buildpacks-community/kpack-cli/pkg/registry/fakes"
 	"github.com/buildpacks-community/kpack-cli/pkg/testhelpers"
 )
 
@@ -28,7 +32,7 @@ func TestClusterBuildpackPatchCommand(t *testing.T) {
 	spec.Run(t, "TestClusterBuildpackPatchCommand", testPatchCommand(clusterbuildpack.NewPatchCommand))
 }
 
-func testPatchCommand(cmd func(clientSetProvider k8s.ClientSetProvider, newWaiter func(dynamic.Interface) commands.ResourceWaiter) *cobra.Command) func(t *testing.T, when spec.G, it spec.S) {
+func testPatchCommand(cmd func(clientSetProvider k8s.ClientSetProvider, rup registry.UtilProvider, newWaiter func(dynamic.Interface) commands.ResourceWaiter) *cobra.Command) func(t *testing.T, when spec.G, it spec.S) {
 	return func(t *testing.T, when spec.G, it spec.S) {
 		var (
 			config = &corev1.ConfigMap{
@@ -64,10 +68,11 @@ func testPatchCommand(cmd func(clientSetProvider k8s.ClientSetProvider, newWaite
 		)
 
 		fakeWaiter := &commandsfakes.FakeWaiter{}
+		fakeFetcher := &registryfakes.Fetcher{}
 
 		cmdFunc := func(clientSet *fake.Clientset) *cobra.Command {
 			clientSetProvider := testhelpers.GetFakeKpackClusterProvider(clientSet)
-			return cmd(clientSetProvider, func(dynamic.Interface) commands.ResourceWaiter {
+			return cmd(clientSetProvider, registryfakes.UtilProvider{FakeFetcher: fakeFetcher}, func(dynamic.Interface) commands.ResourceWaiter {
 				return fakeWaiter
 			})
 		}
@@ -108,6 +113,74 @@ func testPatchCommand(cmd func(clientSetProvider k8s.ClientSetProvider, newWaite
 			}.TestKpack(t, cmdFunc)
 		})
 
+		when("verify-key flag is used", func() {
+			k8sCmdFunc := func(k8sClientSet *k8sfakes.Clientset, kpackClientSet *fake.Clientset) *cobra.Command {
+				clientSetProvider := testhelpers.GetFakeClusterProvider(k8sClientSet, kpackClientSet)
+				return cmd(clientSetProvider, registryfakes.UtilProvider{FakeFetcher: fakeFetcher}, func(dynamic.Interface) commands.ResourceWaiter {
+					return fakeWaiter
+				})
+			}
+
+			it("patches the image with the verified buildpackage in the default repository", func() {
+				key, publicKey := testhelpers.MakeCosignKey(t)
+				fakeFetcher.AddBuildpackImages(registryfakes.BuildpackImgInfo{
+					Id: "test-buildpack-id",
+					ImageInfo: registryfakes.ImageInfo{
+						Ref:    "some-registry.com/some-other-buildpack",
+						Digest: "other-buildpack-digest",
+					},
+				})
+				fakeFetcher.AddImage(
+					"some-registry.com/some-other-buildpack:sha256-other-buildpack-digest.sig",
+					testhelpers.MakeCosignSignature(t, key, v1.Hash{Algorithm: "sha256", Hex: "other-buildpack-digest"}),
+				)
+
+				testhelpers.CommandTest{
+					Objects: []runtime.Object{
+						cbp,
+						config,
+					},
+					Args: []string{
+						cbp.Name,
+						"--image", "some-registry.com/some-other-buildpack",
+						"--verify-key", publicKey,
+					},
+					ExpectedOutput: `Uploading to 'default-registry.io/default-repo'...
+	Uploading 'default-registry.io/default-repo@sha256:other-buildpack-digest'
+Cluster Buildpack "test-buildpack" patched
+`,
+					ExpectPatches: []string{
+						`{"spec":{"image":"default-registry.io/default-repo@sha256:other-buildpack-digest"}}`,
+					},
+				}.TestK8sAndKpack(t, k8sCmdFunc)
+			})
+
+			it("does not patch when the buildpackage is not signed", func() {
+				_, publicKey := testhelpers.MakeCosignKey(t)
+				fakeFetcher.AddBuildpackImages(registryfakes.BuildpackImgInfo{
+					Id: "test-buildpack-id",
+					ImageInfo: registryfakes.ImageInfo{
+						Ref:    "some-registry.com/unsigned-buildpack",
+						Digest: "unsigned-buildpack-digest",
+					},
+				})
+
+				testhelpers.CommandTest{
+					Objects: []runtime.Object{
+						cbp,
+						config,
+					},
+					Args: []string{
+						cbp.Name,
+						"--image", "some-registry.com/unsigned-buildpack",
+						"--verify-key", publicKey,
+					},
+					ExpectErr:           true,
+					ExpectedErrorOutput: "Error: invalid buildpack image: image 'some-registry.com/unsigned-buildpack' failed signature verification: no signature found at 'some-registry.com/unsigned-buildpack:sha256-unsigned-buildpack-digest.sig'\n",
+				}.TestK8sAndKpack(t, k8sCmdFunc)
+			})
+		})
+
 		when("output flag is used", func() {
 			it("can output in yaml format", func() {
 				const resourceYAML = `apiVersion: kpack.io/v1alpha2
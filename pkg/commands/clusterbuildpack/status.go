@@ -4,8 +4,6 @@
 package clusterbuildpack
 
 import (
-	"io"
-
 	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
 	"github.com/spf13/cobra"
@@ -41,29 +39,37 @@ The namespace defaults to the kubernetes current-context namespace.`,
 				return err
 			}
 
-			return displayClusterBuildpackStatus(cbp, cmd.OutOrStdout())
+			printer, err := commands.NewDataPrinter(cmd)
+			if err != nil {
+				return err
+			} else if printer != nil {
+				return printer.Print(cmd.OutOrStdout(), newClusterBuildpackStatusOutput(cbp))
+			}
+
+			return displayClusterBuildpackStatus(cbp, cmd)
 		},
 	}
 
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "kubernetes namespace")
+	commands.SetStatusOutputFlag(cmd)
 
 	return cmd
 }
 
-func displayClusterBuildpackStatus(cbp *v1alpha2.ClusterBuildpack, writer io.Writer) error {
+func displayClusterBuildpackStatus(cbp *v1alpha2.ClusterBuildpack, cmd *cobra.Command) error {
 	if cond := cbp.Status.GetCondition(corev1alpha1.ConditionReady); cond != nil {
 		if cond.Status == corev1.ConditionTrue {
-			return printClusterBuildpackReadyStatus(cbp, writer)
+			return printClusterBuildpackReadyStatus(cbp, cmd)
 		} else {
-			return printClusterBuildpackNotReadyStatus(cbp, writer)
+			return printClusterBuildpackNotReadyStatus(cbp, cmd)
 		}
 	} else {
-		return printClusterBuildpackConditionUnknownStatus(cbp, writer)
+		return printClusterBuildpackConditionUnknownStatus(cbp, cmd)
 	}
 }
 
-func printClusterBuildpackConditionUnknownStatus(_ *v1alpha2.ClusterBuildpack, writer io.Writer) error {
-	statusWriter := commands.NewStatusWriter(writer)
+func printClusterBuildpackConditionUnknownStatus(_ *v1alpha2.ClusterBuildpack, cmd *cobra.Command) error {
+	statusWriter := commands.NewCommandStatusWriter(cmd)
 
 	return statusWriter.AddBlock(
 		"",
@@ -71,8 +77,8 @@ func printClusterBuildpackConditionUnknownStatus(_ *v1alpha2.ClusterBuildpack, w
 	)
 }
 
-func printClusterBuildpackNotReadyStatus(cbp *v1alpha2.ClusterBuildpack, writer io.Writer) error {
-	statusWriter := commands.NewStatusWriter(writer)
+func printClusterBuildpackNotReadyStatus(cbp *v1alpha2.ClusterBuildpack, cmd *cobra.Command) error {
+	statusWriter := commands.NewCommandStatusWriter(cmd)
 
 	condReady := cbp.Status.GetCondition(corev1alpha1.ConditionReady)
 
@@ -83,8 +89,8 @@ func printClusterBuildpackNotReadyStatus(cbp *v1alpha2.ClusterBuildpack, writer
 	)
 }
 
-func printClusterBuildpackReadyStatus(cbp *v1alpha2.ClusterBuildpack, writer io.Writer) error {
-	statusWriter := commands.NewStatusWriter(writer)
+func printClusterBuildpackReadyStatus(cbp *v1alpha2.ClusterBuildpack, cmd *cobra.Command) error {
+	statusWriter := commands.NewCommandStatusWriter(cmd)
 
 	err := statusWriter.AddBlock(
 		"",
@@ -95,7 +101,7 @@ func printClusterBuildpackReadyStatus(cbp *v1alpha2.ClusterBuildpack, writer io.
 		return err
 	}
 
-	cbpTableWriter, err := commands.NewTableWriter(writer, "buildpack id", "version", "homepage")
+	cbpTableWriter, err := commands.NewCommandTableWriter(cmd, "buildpack id", "version", "homepage")
 	if err != nil {
 		return nil
 	}
@@ -109,3 +115,25 @@ func printClusterBuildpackReadyStatus(cbp *v1alpha2.ClusterBuildpack, writer io.
 
 	return cbpTableWriter.Write()
 }
+
+type buildpackStatusOutput struct {
+	Status     string                   `json:"status"`
+	Reason     string                   `json:"reason,omitempty"`
+	Source     string                   `json:"source,omitempty"`
+	Buildpacks []commands.BuildpackInfo `json:"buildpacks,omitempty"`
+}
+
+func newClusterBuildpackStatusOutput(cbp *v1alpha2.ClusterBuildpack) buildpackStatusOutput {
+	cond := cbp.Status.GetCondition(corev1alpha1.ConditionReady)
+	if cond == nil {
+		return buildpackStatusOutput{Status: "Unknown"}
+	} else if cond.Status != corev1.ConditionTrue {
+		return buildpackStatusOutput{Status: "Not Ready", Reason: cond.Message}
+	}
+
+	output := buildpackStatusOutput{Status: "Ready", Source: cbp.Spec.Image}
+	for _, bpStatus := range cbp.Status.Buildpacks {
+		output.Buildpacks = append(output.Buildpacks, commands.BuildpackInfo{Id: bpStatus.Id, Version: bpStatus.Version, Homepage: bpStatus.Homepage})
+	}
+	return output
+}
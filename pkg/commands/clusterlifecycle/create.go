@@ -21,8 +21,10 @@ import (
 
 func NewCreateCommand(clientSetProvider k8s.ClientSetProvider, rup registry.UtilProvider, newWaiter func(dynamic.Interface) commands.ResourceWaiter) *cobra.Command {
 	var (
-		imageRef string
-		tlsCfg   registry.TLSConfig
+		imageRef  string
+		tlsCfg    registry.TLSConfig
+		platforms []string
+		verifyCfg registry.VerificationConfig
 	)
 
 	cmd := &cobra.Command{
@@ -56,7 +58,12 @@ The default service account used is read from the "default.repository.serviceacc
 
 			ctx := cmd.Context()
 
-			factory := clusterlifecycle.NewFactory(ch, rup.Relocator(ch.Writer(), tlsCfg, ch.IsUploading()), rup.Fetcher(tlsCfg))
+			fetcher, err := registry.NewSourceFetcher(rup.Fetcher(tlsCfg), platforms, verifyCfg)
+			if err != nil {
+				return err
+			}
+
+			factory := clusterlifecycle.NewFactory(ch, rup.Relocator(ch.Writer(), tlsCfg, ch.IsUploading()), fetcher)
 
 			name := args[0]
 			return create(ctx, name, imageRef, factory, ch, cs, newWaiter(cs.DynamicClient))
@@ -65,6 +72,8 @@ The default service account used is read from the "default.repository.serviceacc
 	cmd.Flags().StringVarP(&imageRef, "image", "i", "", "image tag or local tar file path")
 	commands.SetImgUploadDryRunOutputFlags(cmd)
 	commands.SetTLSFlags(cmd, &tlsCfg)
+	commands.SetPlatformFlags(cmd, &platforms)
+	commands.SetVerificationFlags(cmd, &verifyCfg)
 	_ = cmd.MarkFlagRequired("image")
 	return cmd
 }
@@ -6,7 +6,6 @@ package clusterlifecycle
 import (
 	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
-	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -33,7 +32,7 @@ func NewListCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 			}
 
 			if len(lifecycleList.Items) == 0 {
-				return errors.New("no clusterlifecycles found")
+				return commands.EmptyList(cmd, "no clusterlifecycles found")
 			} else {
 				return displayLifecyclesTable(cmd, lifecycleList)
 			}
@@ -41,17 +40,43 @@ func NewListCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 		},
 	}
 
+	commands.SetListOutputFlag(cmd)
+
 	return cmd
 }
 
+type lifecycleRow struct {
+	Name    string `json:"name"`
+	Ready   string `json:"ready"`
+	Version string `json:"version"`
+	Image   string `json:"image"`
+}
+
 func displayLifecyclesTable(cmd *cobra.Command, lifecycleList *v1alpha2.ClusterLifecycleList) error {
-	writer, err := commands.NewTableWriter(cmd.OutOrStdout(), "NAME", "READY", "VERSION", "IMAGE")
+	rows := make([]lifecycleRow, 0, len(lifecycleList.Items))
+	for _, l := range lifecycleList.Items {
+		rows = append(rows, lifecycleRow{
+			Name:    l.Name,
+			Ready:   getReadyText(l),
+			Version: l.Status.Version,
+			Image:   l.Status.Image.LatestImage,
+		})
+	}
+
+	printer, err := commands.NewDataPrinter(cmd)
 	if err != nil {
 		return err
+	} else if printer != nil {
+		return printer.Print(cmd.OutOrStdout(), rows)
 	}
 
-	for _, l := range lifecycleList.Items {
-		err := writer.AddRow(l.Name, getReadyText(l), l.Status.Version, l.Status.Image.LatestImage)
+	writer, err := commands.NewCommandTableWriter(cmd, "NAME", "READY", "VERSION", "IMAGE")
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		err := writer.AddRow(row.Name, row.Ready, row.Version, row.Image)
 		if err != nil {
 			return err
 		}
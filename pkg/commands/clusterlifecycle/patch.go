@@ -25,8 +25,10 @@ import (
 
 func NewPatchCommand(clientSetProvider k8s.ClientSetProvider, rup registry.UtilProvider, newWaiter func(dynamic.Interface) commands.ResourceWaiter) *cobra.Command {
 	var (
-		imageRef string
-		tlsCfg   registry.TLSConfig
+		imageRef  string
+		tlsCfg    registry.TLSConfig
+		platforms []string
+		verifyCfg registry.VerificationConfig
 	)
 
 	cmd := &cobra.Command{
@@ -63,7 +65,12 @@ The default service account used is read from the "default.repository.serviceacc
 				return err
 			}
 
-			factory := clusterlifecycle.NewFactory(ch, rup.Relocator(ch.Writer(), tlsCfg, ch.IsUploading()), rup.Fetcher(tlsCfg))
+			fetcher, err := registry.NewSourceFetcher(rup.Fetcher(tlsCfg), platforms, verifyCfg)
+			if err != nil {
+				return err
+			}
+
+			factory := clusterlifecycle.NewFactory(ch, rup.Relocator(ch.Writer(), tlsCfg, ch.IsUploading()), fetcher)
 
 			return patch(ctx, dockercreds.DefaultKeychain, lifecycle, imageRef, factory, ch, cs, newWaiter(cs.DynamicClient))
 		},
@@ -72,6 +79,8 @@ The default service account used is read from the "default.repository.serviceacc
 	cmd.Flags().StringVarP(&imageRef, "image", "i", "", "image tag or local tar file path")
 	commands.SetImgUploadDryRunOutputFlags(cmd)
 	commands.SetTLSFlags(cmd, &tlsCfg)
+	commands.SetPlatformFlags(cmd, &platforms)
+	commands.SetVerificationFlags(cmd, &verifyCfg)
 	_ = cmd.MarkFlagRequired("image")
 	return cmd
 }
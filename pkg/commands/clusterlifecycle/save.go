@@ -18,8 +18,10 @@ import (
 
 func NewSaveCommand(clientSetProvider k8s.ClientSetProvider, rup registry.UtilProvider, newWaiter func(dynamic.Interface) commands.ResourceWaiter) *cobra.Command {
 	var (
-		imageRef string
-		tlsCfg   registry.TLSConfig
+		imageRef  string
+		tlsCfg    registry.TLSConfig
+		platforms []string
+		verifyCfg registry.VerificationConfig
 	)
 
 	cmd := &cobra.Command{
@@ -53,7 +55,12 @@ The default service account used is read from the "default.repository.serviceacc
 				return err
 			}
 
-			factory := clusterlifecycle.NewFactory(ch, rup.Relocator(ch.Writer(), tlsCfg, ch.IsUploading()), rup.Fetcher(tlsCfg))
+			fetcher, err := registry.NewSourceFetcher(rup.Fetcher(tlsCfg), platforms, verifyCfg)
+			if err != nil {
+				return err
+			}
+
+			factory := clusterlifecycle.NewFactory(ch, rup.Relocator(ch.Writer(), tlsCfg, ch.IsUploading()), fetcher)
 
 			name := args[0]
 			cLifecycle, err := cs.KpackClient.KpackV1alpha2().ClusterLifecycles().Get(ctx, name, metav1.GetOptions{})
@@ -69,6 +76,8 @@ The default service account used is read from the "default.repository.serviceacc
 	cmd.Flags().StringVarP(&imageRef, "image", "i", "", "image tag or local tar file path")
 	commands.SetImgUploadDryRunOutputFlags(cmd)
 	commands.SetTLSFlags(cmd, &tlsCfg)
+	commands.SetPlatformFlags(cmd, &platforms)
+	commands.SetVerificationFlags(cmd, &verifyCfg)
 	_ = cmd.MarkFlagRequired("image")
 	return cmd
 }
@@ -4,7 +4,6 @@
 package clusterlifecycle
 
 import (
-	"io"
 	"strings"
 
 	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
@@ -40,17 +39,25 @@ func NewStatusCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 				return err
 			}
 
-			return displayLifecycleStatus(cmd.OutOrStdout(), lifecycle, verbose)
+			printer, err := commands.NewDataPrinter(cmd)
+			if err != nil {
+				return err
+			} else if printer != nil {
+				return printer.Print(cmd.OutOrStdout(), newLifecycleStatusOutput(lifecycle, verbose))
+			}
+
+			return displayLifecycleStatus(cmd, lifecycle, verbose)
 		},
 	}
 
 	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "display supported and deprecated buildpack APIs")
+	commands.SetStatusOutputFlag(cmd)
 
 	return cmd
 }
 
-func displayLifecycleStatus(out io.Writer, l *v1alpha2.ClusterLifecycle, verbose bool) error {
-	writer := commands.NewStatusWriter(out)
+func displayLifecycleStatus(cmd *cobra.Command, l *v1alpha2.ClusterLifecycle, verbose bool) error {
+	writer := commands.NewCommandStatusWriter(cmd)
 
 	items := []string{
 		"Status", getStatusText(l),
@@ -70,6 +77,29 @@ func displayLifecycleStatus(out io.Writer, l *v1alpha2.ClusterLifecycle, verbose
 	return writer.Write()
 }
 
+type lifecycleStatusOutput struct {
+	Status                  string   `json:"status"`
+	Image                   string   `json:"image"`
+	Version                 string   `json:"version"`
+	SupportedBuildpackAPIs  []string `json:"supportedBuildpackApis,omitempty"`
+	DeprecatedBuildpackAPIs []string `json:"deprecatedBuildpackApis,omitempty"`
+}
+
+func newLifecycleStatusOutput(l *v1alpha2.ClusterLifecycle, verbose bool) lifecycleStatusOutput {
+	output := lifecycleStatusOutput{
+		Status:  getStatusText(l),
+		Image:   l.Status.Image.LatestImage,
+		Version: l.Status.Version,
+	}
+
+	if verbose {
+		output.SupportedBuildpackAPIs = l.Status.APIs.Buildpack.Supported
+		output.DeprecatedBuildpackAPIs = l.Status.APIs.Buildpack.Deprecated
+	}
+
+	return output
+}
+
 func getStatusText(l *v1alpha2.ClusterLifecycle) string {
 	if cond := l.Status.GetCondition(corev1alpha1.ConditionReady); cond != nil {
 		if cond.Status == corev1.ConditionTrue {
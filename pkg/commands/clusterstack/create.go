@@ -24,6 +24,8 @@ func NewCreateCommand(clientSetProvider k8s.ClientSetProvider, rup registry.Util
 		buildImageRef string
 		runImageRef   string
 		tlsCfg        registry.TLSConfig
+		platforms     []string
+		verifyCfg     registry.VerificationConfig
 	)
 
 	cmd := &cobra.Command{
@@ -57,7 +59,12 @@ kp clusterstack create my-stack --build-image ../path/to/build.tar --run-image .
 
 			ctx := cmd.Context()
 
-			factory := clusterstack.NewFactory(ch, rup.Relocator(ch.Writer(), tlsCfg, ch.IsUploading()), rup.Fetcher(tlsCfg))
+			fetcher, err := registry.NewSourceFetcher(rup.Fetcher(tlsCfg), platforms, verifyCfg)
+			if err != nil {
+				return err
+			}
+
+			factory := clusterstack.NewFactory(ch, rup.Relocator(ch.Writer(), tlsCfg, ch.IsUploading()), fetcher)
 
 			name := args[0]
 			return create(ctx, name, buildImageRef, runImageRef, factory, ch, cs, newWaiter(cs.DynamicClient))
@@ -67,6 +74,8 @@ kp clusterstack create my-stack --build-image ../path/to/build.tar --run-image .
 	cmd.Flags().StringVarP(&runImageRef, "run-image", "r", "", "run image tag or local tar file path")
 	commands.SetImgUploadDryRunOutputFlags(cmd)
 	commands.SetTLSFlags(cmd, &tlsCfg)
+	commands.SetPlatformFlags(cmd, &platforms)
+	commands.SetVerificationFlags(cmd, &verifyCfg)
 	_ = cmd.MarkFlagRequired("build-image")
 	_ = cmd.MarkFlagRequired("run-image")
 	return cmd
@@ -6,7 +6,6 @@ package clusterstack
 import (
 	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
-	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -33,7 +32,7 @@ func NewListCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 			}
 
 			if len(stackList.Items) == 0 {
-				return errors.New("no clusterstacks found")
+				return commands.EmptyList(cmd, "no clusterstacks found")
 			} else {
 				return displayStacksTable(cmd, stackList)
 			}
@@ -41,17 +40,41 @@ func NewListCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 		},
 	}
 
+	commands.SetListOutputFlag(cmd)
+
 	return cmd
 }
 
+type stackRow struct {
+	Name  string `json:"name"`
+	Ready string `json:"ready"`
+	ID    string `json:"id"`
+}
+
 func displayStacksTable(cmd *cobra.Command, stackList *v1alpha2.ClusterStackList) error {
-	writer, err := commands.NewTableWriter(cmd.OutOrStdout(), "NAME", "READY", "ID")
+	rows := make([]stackRow, 0, len(stackList.Items))
+	for _, s := range stackList.Items {
+		rows = append(rows, stackRow{
+			Name:  s.Name,
+			Ready: getReadyText(s),
+			ID:    s.Status.Id,
+		})
+	}
+
+	printer, err := commands.NewDataPrinter(cmd)
 	if err != nil {
 		return err
+	} else if printer != nil {
+		return printer.Print(cmd.OutOrStdout(), rows)
 	}
 
-	for _, s := range stackList.Items {
-		err := writer.AddRow(s.Name, getReadyText(s), s.Status.Id)
+	writer, err := commands.NewCommandTableWriter(cmd, "NAME", "READY", "ID")
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		err := writer.AddRow(row.Name, row.Ready, row.ID)
 		if err != nil {
 			return err
 		}
@@ -101,6 +101,87 @@ test-stack-3    Unknown    stack-id-3
 			}.TestKpack(t, cmdFunc)
 		})
 
+		when("an output format is provided", func() {
+			makeStack := func(name, id string, ready corev1.ConditionStatus) *v1alpha2.ClusterStack {
+				return &v1alpha2.ClusterStack{
+					ObjectMeta: v1.ObjectMeta{
+						Name: name,
+					},
+					Status: v1alpha2.ClusterStackStatus{
+						Status: corev1alpha1.Status{
+							Conditions: []corev1alpha1.Condition{
+								{
+									Type:   corev1alpha1.ConditionReady,
+									Status: ready,
+								},
+							},
+						},
+						ResolvedClusterStack: v1alpha2.ResolvedClusterStack{
+							Id: id,
+						},
+					},
+				}
+			}
+			stack1 := makeStack("test-stack-1", "stack-id-1", corev1.ConditionFalse)
+			stack2 := makeStack("test-stack-2", "stack-id-2", corev1.ConditionTrue)
+
+			it("prints the stacks as json", func() {
+				testhelpers.CommandTest{
+					Objects: []runtime.Object{stack1, stack2},
+					Args:    []string{"-o", "json"},
+					ExpectedOutput: `[
+    {
+        "name": "test-stack-1",
+        "ready": "False",
+        "id": "stack-id-1"
+    },
+    {
+        "name": "test-stack-2",
+        "ready": "True",
+        "id": "stack-id-2"
+    }
+]
+`,
+				}.TestKpack(t, cmdFunc)
+			})
+
+			it("prints the stacks using a jsonpath template", func() {
+				testhelpers.CommandTest{
+					Objects:        []runtime.Object{stack1, stack2},
+					Args:           []string{"-o", `jsonpath={range [*]}{.name}={.id}{"\n"}{end}`},
+					ExpectedOutput: "test-stack-1=stack-id-1\ntest-stack-2=stack-id-2\n",
+				}.TestKpack(t, cmdFunc)
+			})
+
+			it("prints the stacks using custom columns", func() {
+				testhelpers.CommandTest{
+					Objects: []runtime.Object{stack1, stack2},
+					Args:    []string{"--output", "custom-columns=STACK:.name,STACK ID:.id"},
+					ExpectedOutput: `STACK           STACK ID
+test-stack-1    stack-id-1
+test-stack-2    stack-id-2
+
+`,
+				}.TestKpack(t, cmdFunc)
+			})
+
+			it("prints an empty list when there are no stacks", func() {
+				testhelpers.CommandTest{
+					Args:           []string{"-o", "json"},
+					ExpectedOutput: "[]\n",
+				}.TestKpack(t, cmdFunc)
+			})
+
+			it("errors for an unsupported format", func() {
+				testhelpers.CommandTest{
+					Objects:             []runtime.Object{stack1},
+					Args:                []string{"-o", "xml"},
+					ExpectErr:           true,
+					ExpectedErrorOutput: "Error: unsupported output format: \"xml\", supported formats are json, yaml, jsonpath=<template>, custom-columns=<spec>\n",
+				}.TestKpack(t, cmdFunc)
+			})
+		})
+
 		when("there are no stacks", func() {
 			it("returns a message that no stacks were found", func() {
 				testhelpers.CommandTest{
@@ -20,6 +20,7 @@ import (
 	"github.com/buildpacks-community/kpack-cli/pkg/commands"
 	"github.com/buildpacks-community/kpack-cli/pkg/config"
 	"github.com/buildpacks-community/kpack-cli/pkg/dockercreds"
+	"github.com/buildpacks-community/kpack-cli/pkg/impact"
 	"github.com/buildpacks-community/kpack-cli/pkg/k8s"
 	"github.com/buildpacks-community/kpack-cli/pkg/registry"
 )
@@ -37,6 +38,9 @@ func NewPatchCommand(clientSetProvider k8s.ClientSetProvider, rup registry.UtilP
 		buildImageRef string
 		runImageRef   string
 		tlsCfg        registry.TLSConfig
+		platforms     []string
+		verifyCfg     registry.VerificationConfig
+		showImpact    bool
 	)
 
 	cmd := &cobra.Command{
@@ -51,9 +55,12 @@ Therefore, you must have credentials to access the registry on your machine.
 Env vars can be used for registry auth as described in https://github.com/buildpacks-community/kpack-cli/blob/main/docs/auth.md
 
 The default repository is read from the "default.repository" key in the "kp-config" ConfigMap within "kpack" namespace.
-The default service account used is read from the "default.repository.serviceaccount" key in the "kp-config" ConfigMap within "kpack" namespace.`,
+The default service account used is read from the "default.repository.serviceaccount" key in the "kp-config" ConfigMap within "kpack" namespace.
+
+Use --impact to list the images that would rebase onto the new run image, with their count per namespace, without uploading the images or patching the stack.`,
 		Example: `kp clusterstack patch my-stack --build-image my-registry.com/build --run-image my-registry.com/run
-kp clusterstack patch my-stack --build-image ../path/to/build.tar --run-image ../path/to/run.tar`,
+kp clusterstack patch my-stack --build-image ../path/to/build.tar --run-image ../path/to/run.tar
+kp clusterstack patch my-stack --build-image my-registry.com/build --run-image my-registry.com/run --impact`,
 		Args:         commands.ExactArgsWithUsage(1),
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -74,7 +81,16 @@ kp clusterstack patch my-stack --build-image ../path/to/build.tar --run-image ..
 				return err
 			}
 
-			factory := clusterstack.NewFactory(ch, rup.Relocator(ch.Writer(), tlsCfg, ch.IsUploading()), rup.Fetcher(tlsCfg))
+			fetcher, err := registry.NewSourceFetcher(rup.Fetcher(tlsCfg), platforms, verifyCfg)
+			if err != nil {
+				return err
+			}
+
+			factory := clusterstack.NewFactory(ch, rup.Relocator(ch.Writer(), tlsCfg, ch.IsUploading() && !showImpact), fetcher)
+
+			if showImpact {
+				return previewImpact(ctx, dockercreds.DefaultKeychain, stack, buildImageRef, runImageRef, factory, ch, cs)
+			}
 
 			return patch(ctx, dockercreds.DefaultKeychain, stack, buildImageRef, runImageRef, factory, ch, cs, newWaiter(cs.DynamicClient))
 		},
@@ -84,6 +100,9 @@ kp clusterstack patch my-stack --build-image ../path/to/build.tar --run-image ..
 	cmd.Flags().StringVarP(&runImageRef, "run-image", "r", "", "run image tag or local tar file path")
 	commands.SetImgUploadDryRunOutputFlags(cmd)
 	commands.SetTLSFlags(cmd, &tlsCfg)
+	commands.SetPlatformFlags(cmd, &platforms)
+	commands.SetVerificationFlags(cmd, &verifyCfg)
+	cmd.Flags().BoolVar(&showImpact, "impact", false, "list the images that would rebase, without patching the stack")
 	_ = cmd.MarkFlagRequired("build-image")
 	_ = cmd.MarkFlagRequired("run-image")
 	return cmd
@@ -125,3 +144,23 @@ func patch(ctx context.Context, keychain authn.Keychain, stack *v1alpha2.Cluster
 
 	return ch.PrintChangeResult(hasUpdates, "ClusterStack %q updated", updatedStack.Name)
 }
+
+func previewImpact(ctx context.Context, keychain authn.Keychain, stack *v1alpha2.ClusterStack, buildImageRef, runImageRef string, factory *clusterstack.Factory, ch *commands.CommandHelper, cs k8s.ClientSet) error {
+	if err := ch.PrintStatus("Analyzing impact of ClusterStack update..."); err != nil {
+		return err
+	}
+
+	kpConfig := config.NewKpConfigProvider(cs.K8sClient).GetKpConfig(ctx)
+
+	updatedStack, err := factory.UpdateStack(keychain, stack, buildImageRef, runImageRef, kpConfig)
+	if err != nil {
+		return err
+	}
+
+	images, err := impact.Analyze(ctx, cs.KpackClient, impact.StackChange(stack, updatedStack))
+	if err != nil {
+		return err
+	}
+
+	return impact.Write(ch.Writer(), images)
+}
@@ -28,6 +28,7 @@ import (
 
 func TestUpdateCommand(t *testing.T) {
 	spec.Run(t, "TestUpdateCommand", testUpdateCommand(clusterstackcmds.NewPatchCommand))
+	spec.Run(t, "TestUpdateCommandImpact", testUpdateCommandImpact)
 }
 
 func testUpdateCommand(imageCommand func(clientSetProvider k8s.ClientSetProvider, rup registry.UtilProvider, newWaiter func(dynamic.Interface) commands.ResourceWaiter) *cobra.Command) func(t *testing.T, when spec.G, it spec.S) {
@@ -488,3 +489,107 @@ Uploading to 'default-registry.io/default-repo'... (dry run with image upload)
 		})
 	}
 }
+
+func testUpdateCommandImpact(t *testing.T, when spec.G, it spec.S) {
+	fakeRegistryUtilProvider := &registryfakes.UtilProvider{
+		FakeFetcher: registryfakes.NewStackImagesFetcher(
+			registryfakes.StackInfo{
+				StackID: "stack-id",
+				BuildImg: registryfakes.ImageInfo{
+					Ref:    "some-registry.io/repo/new-build",
+					Digest: "new-build-image-digest",
+				},
+				RunImg: registryfakes.ImageInfo{
+					Ref:    "some-registry.io/repo/new-run",
+					Digest: "new-run-image-digest",
+				},
+			},
+		),
+	}
+
+	stack := &v1alpha2.ClusterStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "stack-name",
+		},
+		Spec: v1alpha2.ClusterStackSpec{
+			Id: "stack-id",
+			BuildImage: v1alpha2.ClusterStackSpecImage{
+				Image: "default-registry.io/default-repo@sha256:build-image-digest",
+			},
+			RunImage: v1alpha2.ClusterStackSpecImage{
+				Image: "default-registry.io/default-repo@sha256:run-image-digest",
+			},
+		},
+	}
+
+	config := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kp-config",
+			Namespace: "kpack",
+		},
+		Data: map[string]string{
+			"default.repository": "default-registry.io/default-repo",
+		},
+	}
+
+	builder := &v1alpha2.ClusterBuilder{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "some-builder",
+		},
+		Spec: v1alpha2.ClusterBuilderSpec{
+			BuilderSpec: v1alpha2.BuilderSpec{
+				Stack: corev1.ObjectReference{Kind: v1alpha2.ClusterStackKind, Name: "stack-name"},
+			},
+		},
+	}
+
+	image := &v1alpha2.Image{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "some-image",
+			Namespace: "some-namespace",
+		},
+		Spec: v1alpha2.ImageSpec{
+			Builder: corev1.ObjectReference{Kind: v1alpha2.ClusterBuilderKind, Name: "some-builder"},
+		},
+	}
+
+	fakeWaiter := &commandsfakes.FakeWaiter{}
+
+	cmdFunc := func(k8sClientSet *k8sfakes.Clientset, kpackClientSet *kpackfakes.Clientset) *cobra.Command {
+		clientSetProvider := testhelpers.GetFakeClusterProvider(k8sClientSet, kpackClientSet)
+		return clusterstackcmds.NewPatchCommand(clientSetProvider, fakeRegistryUtilProvider, func(dynamic.Interface) commands.ResourceWaiter {
+			return fakeWaiter
+		})
+	}
+
+	it("lists the images that would rebase without uploading or patching", func() {
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{
+				config,
+				stack,
+				builder,
+				image,
+			},
+			Args: []string{
+				"stack-name",
+				"--build-image", "some-registry.io/repo/new-build",
+				"--run-image", "some-registry.io/repo/new-run",
+				"--impact",
+			},
+			ExpectedOutput: `Analyzing impact of ClusterStack update...
+Uploading to 'default-registry.io/default-repo'...
+	Skipping 'default-registry.io/default-repo@sha256:new-build-image-digest'
+	Skipping 'default-registry.io/default-repo@sha256:new-run-image-digest'
+Impact
+
+NAMESPACE         IMAGE         BUILDER                        ACTION
+some-namespace    some-image    ClusterBuilder/some-builder    rebase
+
+NAMESPACE         REBASE    REBUILD
+some-namespace    1         0
+
+`,
+		}.TestK8sAndKpack(t, cmdFunc)
+		require.Len(t, fakeWaiter.WaitCalls, 0)
+	})
+}
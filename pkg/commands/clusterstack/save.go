@@ -21,6 +21,8 @@ func NewSaveCommand(clientSetProvider k8s.ClientSetProvider, rup registry.UtilPr
 		buildImageRef string
 		runImageRef   string
 		tlsCfg        registry.TLSConfig
+		platforms     []string
+		verifyCfg     registry.VerificationConfig
 	)
 
 	cmd := &cobra.Command{
@@ -55,7 +57,12 @@ kp clusterstack save my-stack --build-image ../path/to/build.tar --run-image ../
 				return err
 			}
 
-			factory := clusterstack.NewFactory(ch, rup.Relocator(ch.Writer(), tlsCfg, ch.IsUploading()), rup.Fetcher(tlsCfg))
+			fetcher, err := registry.NewSourceFetcher(rup.Fetcher(tlsCfg), platforms, verifyCfg)
+			if err != nil {
+				return err
+			}
+
+			factory := clusterstack.NewFactory(ch, rup.Relocator(ch.Writer(), tlsCfg, ch.IsUploading()), fetcher)
 
 			name := args[0]
 			cStack, err := cs.KpackClient.KpackV1alpha2().ClusterStacks().Get(ctx, name, metav1.GetOptions{})
@@ -72,6 +79,8 @@ kp clusterstack save my-stack --build-image ../path/to/build.tar --run-image ../
 	cmd.Flags().StringVarP(&runImageRef, "run-image", "r", "", "run image tag or local tar file path")
 	commands.SetImgUploadDryRunOutputFlags(cmd)
 	commands.SetTLSFlags(cmd, &tlsCfg)
+	commands.SetPlatformFlags(cmd, &platforms)
+	commands.SetVerificationFlags(cmd, &verifyCfg)
 	_ = cmd.MarkFlagRequired("build-image")
 	_ = cmd.MarkFlagRequired("run-image")
 	return cmd
@@ -4,7 +4,6 @@
 package clusterstack
 
 import (
-	"io"
 	"strings"
 
 	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
@@ -40,17 +39,25 @@ func NewStatusCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 				return err
 			}
 
-			return displayStackStatus(cmd.OutOrStdout(), stack, verbose)
+			printer, err := commands.NewDataPrinter(cmd)
+			if err != nil {
+				return err
+			} else if printer != nil {
+				return printer.Print(cmd.OutOrStdout(), newStackStatusOutput(stack, verbose))
+			}
+
+			return displayStackStatus(cmd, stack, verbose)
 		},
 	}
 
 	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "display mixins")
+	commands.SetStatusOutputFlag(cmd)
 
 	return cmd
 }
 
-func displayStackStatus(out io.Writer, s *v1alpha2.ClusterStack, verbose bool) error {
-	writer := commands.NewStatusWriter(out)
+func displayStackStatus(cmd *cobra.Command, s *v1alpha2.ClusterStack, verbose bool) error {
+	writer := commands.NewCommandStatusWriter(cmd)
 
 	items := []string{
 		"Status", getStatusText(s),
@@ -70,6 +77,29 @@ func displayStackStatus(out io.Writer, s *v1alpha2.ClusterStack, verbose bool) e
 	return writer.Write()
 }
 
+type stackStatusOutput struct {
+	Status     string   `json:"status"`
+	Id         string   `json:"id"`
+	RunImage   string   `json:"runImage"`
+	BuildImage string   `json:"buildImage"`
+	Mixins     []string `json:"mixins,omitempty"`
+}
+
+func newStackStatusOutput(s *v1alpha2.ClusterStack, verbose bool) stackStatusOutput {
+	output := stackStatusOutput{
+		Status:     getStatusText(s),
+		Id:         s.Status.Id,
+		RunImage:   s.Status.RunImage.LatestImage,
+		BuildImage: s.Status.BuildImage.LatestImage,
+	}
+
+	if verbose {
+		output.Mixins = s.Status.Mixins
+	}
+
+	return output
+}
+
 func getStatusText(s *v1alpha2.ClusterStack) string {
 	if cond := s.Status.GetCondition(corev1alpha1.ConditionReady); cond != nil {
 		if cond.Status == corev1.ConditionTrue {
@@ -79,6 +79,23 @@ Mixins:         mixin1, mixin2
 			}.TestKpack(t, cmdFunc)
 		})
 
+		it("prints the stack details as yaml when --output is yaml", func() {
+			const expectedOutput = `buildImage: some-run-image
+id: some-stack-id
+mixins:
+- mixin1
+- mixin2
+runImage: some-build-image
+status: Unknown
+`
+
+			testhelpers.CommandTest{
+				Objects:        []runtime.Object{stck},
+				Args:           []string{"some-stack", "--verbose", "-o", "yaml"},
+				ExpectedOutput: expectedOutput,
+			}.TestKpack(t, cmdFunc)
+		})
+
 		when("the status is not ready", func() {
 			it("prints the status message", func() {
 				stck.Status.Conditions = append(stck.Status.Conditions, corev1alpha1.Condition{
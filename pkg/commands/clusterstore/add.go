@@ -19,6 +19,7 @@ import (
 	"github.com/buildpacks-community/kpack-cli/pkg/commands"
 	"github.com/buildpacks-community/kpack-cli/pkg/config"
 	"github.com/buildpacks-community/kpack-cli/pkg/dockercreds"
+	"github.com/buildpacks-community/kpack-cli/pkg/impact"
 	"github.com/buildpacks-community/kpack-cli/pkg/k8s"
 	"github.com/buildpacks-community/kpack-cli/pkg/registry"
 )
@@ -27,6 +28,9 @@ func NewAddCommand(clientSetProvider k8s.ClientSetProvider, rup registry.UtilPro
 	var (
 		buildpackages []string
 		tlsCfg        registry.TLSConfig
+		platforms     []string
+		verifyCfg     registry.VerificationConfig
+		showImpact    bool
 	)
 
 	cmd := &cobra.Command{
@@ -40,10 +44,13 @@ Therefore, you must have credentials to access the registry on your machine.
 Env vars can be used for registry auth as described in https://github.com/buildpacks-community/kpack-cli/blob/main/docs/auth.md
 
 The default repository is read from the "default.repository" key in the "kp-config" ConfigMap within "kpack" namespace.
+
+Use --impact to list the images that would rebuild with the new buildpacks, with their count per namespace, without uploading the buildpackages or updating the store.
 `,
 		Example: `kp clusterstore add my-store -b my-registry.com/my-buildpackage
 kp clusterstore add my-store -b my-registry.com/my-buildpackage -b my-registry.com/my-other-buildpackage -b my-registry.com/my-third-buildpackage
-kp clusterstore add my-store -b ../path/to/my-local-buildpackage.cnb`,
+kp clusterstore add my-store -b ../path/to/my-local-buildpackage.cnb
+kp clusterstore add my-store -b my-registry.com/my-buildpackage --impact`,
 		Args:         commands.ExactArgsWithUsage(1),
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -67,10 +74,17 @@ kp clusterstore add my-store -b ../path/to/my-local-buildpackage.cnb`,
 				return err
 			}
 
-			relocator := rup.Relocator(ch.Writer(), tlsCfg, ch.IsUploading())
-			fetcher := rup.Fetcher(tlsCfg)
+			relocator := rup.Relocator(ch.Writer(), tlsCfg, ch.IsUploading() && !showImpact)
+			fetcher, err := registry.NewSourceFetcher(rup.Fetcher(tlsCfg), platforms, verifyCfg)
+			if err != nil {
+				return err
+			}
 			factory := clusterstore.NewFactory(ch, relocator, fetcher)
 
+			if showImpact {
+				return previewImpact(ctx, store, buildpackages, factory, ch, cs)
+			}
+
 			return update(ctx, store, buildpackages, factory, ch, cs, newWaiter(cs.DynamicClient))
 		},
 	}
@@ -78,6 +92,9 @@ kp clusterstore add my-store -b ../path/to/my-local-buildpackage.cnb`,
 	cmd.Flags().StringArrayVarP(&buildpackages, "buildpackage", "b", []string{}, "location of the buildpackage")
 	commands.SetImgUploadDryRunOutputFlags(cmd)
 	commands.SetTLSFlags(cmd, &tlsCfg)
+	commands.SetPlatformFlags(cmd, &platforms)
+	commands.SetVerificationFlags(cmd, &verifyCfg)
+	cmd.Flags().BoolVar(&showImpact, "impact", false, "list the images that would rebuild, without updating the store")
 	return cmd
 }
 
@@ -117,3 +134,23 @@ func update(ctx context.Context, store *v1alpha2.ClusterStore, buildpackages []s
 
 	return ch.PrintChangeResult(hasPatch, "ClusterStore %q updated", updatedStore.Name)
 }
+
+func previewImpact(ctx context.Context, store *v1alpha2.ClusterStore, buildpackages []string, factory *clusterstore.Factory, ch *commands.CommandHelper, cs k8s.ClientSet) error {
+	if err := ch.PrintStatus("Analyzing impact of adding to ClusterStore..."); err != nil {
+		return err
+	}
+
+	kpConfig := config.NewKpConfigProvider(cs.K8sClient).GetKpConfig(ctx)
+
+	updatedStore, err := factory.AddToStore(dockercreds.DefaultKeychain, store, kpConfig, buildpackages...)
+	if err != nil {
+		return err
+	}
+
+	images, err := impact.Analyze(ctx, cs.KpackClient, impact.StoreChange(store, updatedStore))
+	if err != nil {
+		return err
+	}
+
+	return impact.Write(ch.Writer(), images)
+}
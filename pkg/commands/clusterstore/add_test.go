@@ -29,6 +29,7 @@ import (
 func TestClusterStoreAddCommand(t *testing.T) {
 	spec.Run(t, "TestClusterStoreAddCommand", testAddCommand(storecmds.NewAddCommand))
 	spec.Run(t, "TestClusterStoreAddCommandDNEError", testAddCommandDNEError)
+	spec.Run(t, "TestClusterStoreAddCommandImpact", testAddCommandImpact)
 }
 
 func testAddCommand(clusterStackCommand func(clientSetProvider k8s.ClientSetProvider, rup registry.UtilProvider, newWaiter func(dynamic.Interface) commands.ResourceWaiter) *cobra.Command) func(t *testing.T, when spec.G, it spec.S) {
@@ -472,3 +473,98 @@ func testAddCommandDNEError(t *testing.T, when spec.G, it spec.S) {
 		}.TestK8sAndKpack(t, cmdFunc)
 	})
 }
+
+func testAddCommandImpact(t *testing.T, when spec.G, it spec.S) {
+	fakeRegistryUtilProvider := &registryfakes.UtilProvider{
+		FakeFetcher: registryfakes.NewBuildpackImagesFetcher(
+			registryfakes.BuildpackImgInfo{
+				Id: "new-buildpack-id",
+				ImageInfo: registryfakes.ImageInfo{
+					Ref:    "some-registry.io/repo/new-buildpack",
+					Digest: "new-buildpack-digest",
+				},
+			},
+		),
+	}
+
+	existingStore := &v1alpha2.ClusterStore{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "store-name",
+		},
+		Spec: v1alpha2.ClusterStoreSpec{
+			Sources: []corev1alpha1.ImageSource{
+				{Image: "default-registry.io/default-repo/old-buildpack-id@sha256:old-buildpack-digest"},
+			},
+		},
+	}
+
+	config := &corev1.ConfigMap{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "kp-config",
+			Namespace: "kpack",
+		},
+		Data: map[string]string{
+			"default.repository": "default-registry.io/default-repo",
+		},
+	}
+
+	builder := &v1alpha2.Builder{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "some-builder",
+			Namespace: "some-namespace",
+		},
+		Spec: v1alpha2.NamespacedBuilderSpec{
+			BuilderSpec: v1alpha2.BuilderSpec{
+				Store: corev1.ObjectReference{Kind: v1alpha2.ClusterStoreKind, Name: "store-name"},
+			},
+		},
+	}
+
+	image := &v1alpha2.Image{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "some-image",
+			Namespace: "some-namespace",
+		},
+		Spec: v1alpha2.ImageSpec{
+			Builder: corev1.ObjectReference{Kind: v1alpha2.BuilderKind, Name: "some-builder"},
+		},
+	}
+
+	fakeWaiter := &commandsfakes.FakeWaiter{}
+
+	cmdFunc := func(k8sClientSet *k8sfakes.Clientset, kpackClientSet *kpackfakes.Clientset) *cobra.Command {
+		clientSetProvider := testhelpers.GetFakeClusterProvider(k8sClientSet, kpackClientSet)
+		return storecmds.NewAddCommand(clientSetProvider, fakeRegistryUtilProvider, func(dynamic.Interface) commands.ResourceWaiter {
+			return fakeWaiter
+		})
+	}
+
+	it("lists the images that would rebuild without uploading or updating the store", func() {
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{
+				config,
+				existingStore,
+				builder,
+				image,
+			},
+			Args: []string{
+				"store-name",
+				"-b", "some-registry.io/repo/new-buildpack",
+				"--impact",
+			},
+			ExpectedOutput: `Analyzing impact of adding to ClusterStore...
+	Skipping 'default-registry.io/default-repo@sha256:new-buildpack-digest'
+	Added Buildpackage
+Impact
+
+NAMESPACE         IMAGE         BUILDER                 ACTION
+some-namespace    some-image    Builder/some-builder    rebuild
+
+NAMESPACE         REBASE    REBUILD
+some-namespace    0         1
+
+`,
+		}.TestK8sAndKpack(t, cmdFunc)
+		require.Len(t, fakeWaiter.WaitCalls, 0)
+	})
+}
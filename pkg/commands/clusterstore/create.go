@@ -23,6 +23,8 @@ func NewCreateCommand(clientSetProvider k8s.ClientSetProvider, rup registry.Util
 	var (
 		buildpackages []string
 		tlsCfg        registry.TLSConfig
+		platforms     []string
+		verifyCfg     registry.VerificationConfig
 	)
 
 	cmd := &cobra.Command{
@@ -57,7 +59,12 @@ kp clusterstore create my-store -b ../path/to/my-local-buildpackage.cnb`,
 
 			ctx := cmd.Context()
 
-			factory := clusterstore.NewFactory(ch, rup.Relocator(ch.Writer(), tlsCfg, ch.IsUploading()), rup.Fetcher(tlsCfg))
+			fetcher, err := registry.NewSourceFetcher(rup.Fetcher(tlsCfg), platforms, verifyCfg)
+			if err != nil {
+				return err
+			}
+
+			factory := clusterstore.NewFactory(ch, rup.Relocator(ch.Writer(), tlsCfg, ch.IsUploading()), fetcher)
 
 			name := args[0]
 			return create(ctx, name, buildpackages, factory, ch, cs, newWaiter(cs.DynamicClient))
@@ -67,6 +74,8 @@ kp clusterstore create my-store -b ../path/to/my-local-buildpackage.cnb`,
 	cmd.Flags().StringArrayVarP(&buildpackages, "buildpackage", "b", []string{}, "location of the buildpackage")
 	commands.SetImgUploadDryRunOutputFlags(cmd)
 	commands.SetTLSFlags(cmd, &tlsCfg)
+	commands.SetPlatformFlags(cmd, &platforms)
+	commands.SetVerificationFlags(cmd, &verifyCfg)
 	return cmd
 }
 
@@ -118,6 +118,62 @@ ClusterStore "store-name" created
 			require.Len(t, fakeWaiter.WaitCalls, 1)
 		})
 
+		it("skips buildpackages without a valid signature with the skip verification policy", func() {
+			_, publicKey := testhelpers.MakeCosignKey(t)
+
+			testhelpers.CommandTest{
+				Objects: []runtime.Object{
+					config,
+				},
+				Args: []string{
+					"store-name",
+					"--buildpackage", "some-registry.io/repo/buildpack",
+					"-b", localCNBPath,
+					"--verify-key", publicKey,
+					"--verify-policy", "skip",
+				},
+				ExpectedOutput: `Creating ClusterStore...
+	Skipping buildpackage: image 'some-registry.io/repo/buildpack' failed signature verification: no signature found at 'some-registry.io/repo/buildpack:sha256-buildpack-digest.sig'
+	Uploading 'default-registry.io/default-repo@sha256:37d646bec2453ab05fe57288ede904dfd12f988dbc964e3e764c41c1bd3b58bf'
+ClusterStore "store-name" created
+`,
+				ExpectCreates: []runtime.Object{
+					&v1alpha2.ClusterStore{
+						TypeMeta: expectedStore.TypeMeta,
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "store-name",
+							Annotations: map[string]string{
+								"kubectl.kubernetes.io/last-applied-configuration": `{"kind":"ClusterStore","apiVersion":"kpack.io/v1alpha2","metadata":{"name":"store-name","creationTimestamp":null},"spec":{"sources":[{"image":"default-registry.io/default-repo@sha256:37d646bec2453ab05fe57288ede904dfd12f988dbc964e3e764c41c1bd3b58bf"}],"serviceAccountRef":{"namespace":"some-namespace","name":"some-serviceaccount"}},"status":{}}`,
+							},
+						},
+						Spec: v1alpha2.ClusterStoreSpec{
+							ServiceAccountRef: expectedStore.Spec.ServiceAccountRef,
+							Sources:           expectedStore.Spec.Sources[1:],
+						},
+					},
+				},
+			}.TestK8sAndKpack(t, cmdFunc)
+		})
+
+		it("fails when a buildpackage does not have a valid signature", func() {
+			_, publicKey := testhelpers.MakeCosignKey(t)
+
+			testhelpers.CommandTest{
+				Objects: []runtime.Object{
+					config,
+				},
+				Args: []string{
+					"store-name",
+					"--buildpackage", "some-registry.io/repo/buildpack",
+					"--verify-key", publicKey,
+				},
+				ExpectErr: true,
+				ExpectedOutput: `Creating ClusterStore...
+`,
+				ExpectedErrorOutput: "Error: image 'some-registry.io/repo/buildpack' failed signature verification: no signature found at 'some-registry.io/repo/buildpack:sha256-buildpack-digest.sig'\n",
+			}.TestK8sAndKpack(t, cmdFunc)
+		})
+
 		it("fails when default.repository key is not found in kp-config configmap", func() {
 			badConfig := &corev1.ConfigMap{
 				ObjectMeta: metav1.ObjectMeta{
@@ -4,8 +4,6 @@
 package clusterstore
 
 import (
-	"errors"
-
 	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
 	"github.com/spf13/cobra"
@@ -33,7 +31,7 @@ func NewListCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 			}
 
 			if len(storeList.Items) == 0 {
-				return errors.New("no ClusterStores found")
+				return commands.EmptyList(cmd, "no ClusterStores found")
 			} else {
 				return displayStoresTable(cmd, storeList)
 			}
@@ -42,17 +40,39 @@ func NewListCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 		SilenceUsage: true,
 	}
 
+	commands.SetListOutputFlag(cmd)
+
 	return cmd
 }
 
+type storeRow struct {
+	Name  string `json:"name"`
+	Ready string `json:"ready"`
+}
+
 func displayStoresTable(cmd *cobra.Command, storeList *v1alpha2.ClusterStoreList) error {
-	writer, err := commands.NewTableWriter(cmd.OutOrStdout(), "NAME", "READY")
+	rows := make([]storeRow, 0, len(storeList.Items))
+	for _, s := range storeList.Items {
+		rows = append(rows, storeRow{
+			Name:  s.Name,
+			Ready: getReadyText(s),
+		})
+	}
+
+	printer, err := commands.NewDataPrinter(cmd)
 	if err != nil {
 		return err
+	} else if printer != nil {
+		return printer.Print(cmd.OutOrStdout(), rows)
 	}
 
-	for _, s := range storeList.Items {
-		err := writer.AddRow(s.Name, getReadyText(s))
+	writer, err := commands.NewCommandTableWriter(cmd, "NAME", "READY")
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		err := writer.AddRow(row.Name, row.Ready)
 		if err != nil {
 			return err
 		}
@@ -5,7 +5,6 @@ package clusterstore
 
 import (
 	"fmt"
-	"io"
 	"sort"
 
 	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
@@ -41,15 +40,23 @@ func NewStatusCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 				return err
 			}
 
+			printer, err := commands.NewDataPrinter(cmd)
+			if err != nil {
+				return err
+			} else if printer != nil {
+				return printer.Print(cmd.OutOrStdout(), newStoreStatusOutput(store, verbose))
+			}
+
 			if verbose {
-				return displayBuildpackagesDetailed(cmd.OutOrStdout(), store)
+				return displayBuildpackagesDetailed(cmd, store)
 			} else {
-				return displayBuildpackages(cmd.OutOrStdout(), store)
+				return displayBuildpackages(cmd, store)
 			}
 		},
 	}
 
 	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "includes buildpacks and detection order")
+	commands.SetStatusOutputFlag(cmd)
 	return cmd
 }
 
@@ -59,8 +66,8 @@ type buildpackageInfo struct {
 	homepage string
 }
 
-func displayStatus(out io.Writer, s *v1alpha2.ClusterStore) error {
-	statusWriter := commands.NewStatusWriter(out)
+func displayStatus(cmd *cobra.Command, s *v1alpha2.ClusterStore) error {
+	statusWriter := commands.NewCommandStatusWriter(cmd)
 	status := getStatusText(s)
 	if err := statusWriter.AddBlock("", "Status", status); err != nil {
 		return err
@@ -79,8 +86,8 @@ func getStatusText(s *v1alpha2.ClusterStore) string {
 	return "Unknown"
 }
 
-func displayBuildpackages(out io.Writer, s *v1alpha2.ClusterStore) error {
-	if err := displayStatus(out, s); err != nil {
+func displayBuildpackages(cmd *cobra.Command, s *v1alpha2.ClusterStore) error {
+	if err := displayStatus(cmd, s); err != nil {
 		return err
 	}
 
@@ -90,7 +97,7 @@ func displayBuildpackages(out io.Writer, s *v1alpha2.ClusterStore) error {
 		return nil
 	}
 
-	writer, err := commands.NewTableWriter(out, "BUILDPACKAGE ID", "VERSION", "HOMEPAGE")
+	writer, err := commands.NewCommandTableWriter(cmd, "BUILDPACKAGE ID", "VERSION", "HOMEPAGE")
 	if err != nil {
 		return err
 	}
@@ -104,11 +111,18 @@ func displayBuildpackages(out io.Writer, s *v1alpha2.ClusterStore) error {
 	return writer.Write()
 }
 
-func displayBuildpackagesDetailed(out io.Writer, s *v1alpha2.ClusterStore) error {
-	if err := displayStatus(out, s); err != nil {
+func displayBuildpackagesDetailed(cmd *cobra.Command, s *v1alpha2.ClusterStore) error {
+	if err := displayStatus(cmd, s); err != nil {
 		return err
 	}
 
+	buildpackages, buildpackageBps := groupBuildpackages(s)
+	return displayBuildpacks(cmd, buildpackages, buildpackageBps)
+}
+
+// groupBuildpackages returns the buildpackages in the store keyed by id@version,
+// and the buildpacks each of them contains.
+func groupBuildpackages(s *v1alpha2.ClusterStore) (map[string]corev1alpha1.BuildpackStatus, map[string][]corev1alpha1.BuildpackStatus) {
 	buildpackages := map[string]corev1alpha1.BuildpackStatus{}
 	buildpackageBps := map[string][]corev1alpha1.BuildpackStatus{}
 
@@ -126,7 +140,7 @@ func displayBuildpackagesDetailed(out io.Writer, s *v1alpha2.ClusterStore) error
 		}
 	}
 
-	return displayBuildpacks(out, buildpackages, buildpackageBps)
+	return buildpackages, buildpackageBps
 }
 
 func getBuildpackageInfos(store *v1alpha2.ClusterStore) []buildpackageInfo {
@@ -159,7 +173,7 @@ func getBuildpackageInfos(store *v1alpha2.ClusterStore) []buildpackageInfo {
 	return buildpackageInfos
 }
 
-func displayBuildpacks(out io.Writer, buildpackage map[string]corev1alpha1.BuildpackStatus, buildpacks map[string][]corev1alpha1.BuildpackStatus) error {
+func displayBuildpacks(cmd *cobra.Command, buildpackage map[string]corev1alpha1.BuildpackStatus, buildpacks map[string][]corev1alpha1.BuildpackStatus) error {
 	var keys []string
 	for k := range buildpackage {
 		keys = append(keys, k)
@@ -168,10 +182,10 @@ func displayBuildpacks(out io.Writer, buildpackage map[string]corev1alpha1.Build
 
 	for i, k := range keys {
 		if i != 0 {
-			_, _ = fmt.Fprintln(out, "")
+			_, _ = fmt.Fprintln(cmd.OutOrStdout(), "")
 		}
 
-		statusWriter := commands.NewStatusWriter(out)
+		statusWriter := commands.NewCommandStatusWriter(cmd)
 
 		err := statusWriter.AddBlock("",
 			"Buildpackage", k,
@@ -187,7 +201,7 @@ func displayBuildpacks(out io.Writer, buildpackage map[string]corev1alpha1.Build
 			return err
 		}
 
-		tbWriter, err := commands.NewTableWriter(out, "Buildpack id", "version", "homepage")
+		tbWriter, err := commands.NewCommandTableWriter(cmd, "Buildpack id", "version", "homepage")
 		if err != nil {
 			return err
 		}
@@ -204,7 +218,7 @@ func displayBuildpacks(out io.Writer, buildpackage map[string]corev1alpha1.Build
 			return err
 		}
 
-		orderTableWriter, err := commands.NewTableWriter(out, "Detection Order", "")
+		orderTableWriter, err := commands.NewCommandTableWriter(cmd, "Detection Order", "")
 		if err != nil {
 			return nil
 		}
@@ -237,3 +251,78 @@ func displayBuildpacks(out io.Writer, buildpackage map[string]corev1alpha1.Build
 
 	return nil
 }
+
+type storeStatusOutput struct {
+	Status        string               `json:"status"`
+	Buildpackages []buildpackageOutput `json:"buildpackages"`
+}
+
+type buildpackageOutput struct {
+	Id             string                   `json:"id"`
+	Version        string                   `json:"version"`
+	Homepage       string                   `json:"homepage,omitempty"`
+	Image          string                   `json:"image,omitempty"`
+	Buildpacks     []commands.BuildpackInfo `json:"buildpacks,omitempty"`
+	DetectionOrder []detectionGroup         `json:"detectionOrder,omitempty"`
+}
+
+type detectionGroup struct {
+	Group []detectionRef `json:"group"`
+}
+
+type detectionRef struct {
+	Id       string `json:"id"`
+	Optional bool   `json:"optional,omitempty"`
+}
+
+func newStoreStatusOutput(s *v1alpha2.ClusterStore, verbose bool) storeStatusOutput {
+	output := storeStatusOutput{
+		Status:        getStatusText(s),
+		Buildpackages: []buildpackageOutput{},
+	}
+
+	if !verbose {
+		for _, info := range getBuildpackageInfos(s) {
+			output.Buildpackages = append(output.Buildpackages, buildpackageOutput{
+				Id:       info.id,
+				Version:  info.version,
+				Homepage: info.homepage,
+			})
+		}
+		return output
+	}
+
+	buildpackages, buildpackageBps := groupBuildpackages(s)
+
+	var keys []string
+	for k := range buildpackages {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		bp := buildpackages[k]
+		buildpackage := buildpackageOutput{
+			Id:       bp.Id,
+			Version:  bp.Version,
+			Homepage: bp.Homepage,
+			Image:    bp.StoreImage.Image,
+		}
+
+		for _, b := range buildpackageBps[k] {
+			buildpackage.Buildpacks = append(buildpackage.Buildpacks, commands.BuildpackInfo{Id: b.Id, Version: b.Version, Homepage: b.Homepage})
+		}
+
+		for _, entry := range bp.Order {
+			group := detectionGroup{Group: []detectionRef{}}
+			for _, ref := range entry.Group {
+				group.Group = append(group.Group, detectionRef{Id: ref.Id, Optional: ref.Optional})
+			}
+			buildpackage.DetectionOrder = append(buildpackage.DetectionOrder, group)
+		}
+
+		output.Buildpackages = append(output.Buildpackages, buildpackage)
+	}
+
+	return output
+}
@@ -13,12 +13,18 @@ import (
 )
 
 const (
-	caCertPathFlag  = "registry-ca-cert-path"
-	verifyCertsFlag = "registry-verify-certs"
-
-	caCertPathFlagUsage  = "add CA certificate for registry API (format: /tmp/ca.crt)"
-	verifyCertsFlagUsage = "set whether to verify server's certificate chain and host name"
-	dryRunUsage          = `perform validation with no side-effects; no objects are sent to the server.
+	caCertPathFlag   = "registry-ca-cert-path"
+	verifyCertsFlag  = "registry-verify-certs"
+	platformFlag     = "platform"
+	verifyKeyFlag    = "verify-key"
+	verifyPolicyFlag = "verify-policy"
+
+	caCertPathFlagUsage   = "add CA certificate for registry API (format: /tmp/ca.crt)"
+	verifyCertsFlagUsage  = "set whether to verify server's certificate chain and host name"
+	platformFlagUsage     = "platform to copy from multi-platform images, can be set more than once (format: linux/arm64). Copies all platforms by default"
+	verifyKeyFlagUsage    = "path to a cosign public key, images without a valid signature from the matching private key are not copied"
+	verifyPolicyFlagUsage = "what to do with images that fail signature verification; supported policies are: enforce, skip (default \"enforce\")"
+	dryRunUsage           = `perform validation with no side-effects; no objects are sent to the server.
   The --dry-run flag can be used in combination with the --output flag to
   view the Kubernetes resource(s) without sending anything to the server.`
 	dryRunImgUploadUsage = `similar to --dry-run, but with container image uploads allowed.
@@ -37,6 +43,15 @@ func SetTLSFlags(cmd *cobra.Command, cfg *registry.TLSConfig) {
 	cmd.Flags().BoolVar(&cfg.VerifyCerts, verifyCertsFlag, true, verifyCertsFlagUsage)
 }
 
+func SetPlatformFlags(cmd *cobra.Command, platforms *[]string) {
+	cmd.Flags().StringArrayVar(platforms, platformFlag, nil, platformFlagUsage)
+}
+
+func SetVerificationFlags(cmd *cobra.Command, cfg *registry.VerificationConfig) {
+	cmd.Flags().StringVar(&cfg.PublicKeyPath, verifyKeyFlag, "", verifyKeyFlagUsage)
+	cmd.Flags().StringVar(&cfg.Policy, verifyPolicyFlag, "", verifyPolicyFlagUsage)
+}
+
 func SetDryRunOutputFlags(cmd *cobra.Command) {
 	cmd.Flags().Bool(DryRunFlag, false, dryRunUsage)
 	cmd.Flags().String(OutputFlag, "", outputUsage)
@@ -0,0 +1,320 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/k8s"
+)
+
+const (
+	ContextFlag     = "context"
+	contextsFlag    = "contexts"
+	allContextsFlag = "all-contexts"
+)
+
+// NewMultiContextCommand returns the read command made by newCommand with
+// the --contexts and --all-contexts flags. When either is set, the command
+// runs against each context in parallel and the results are merged into one
+// table with a CLUSTER column.
+func NewMultiContextCommand(clientSetProvider k8s.ContextClientSetProvider, newCommand func(k8s.ClientSetProvider) *cobra.Command) *cobra.Command {
+	var (
+		contexts    []string
+		allContexts bool
+	)
+
+	cmd := newCommand(clientSetProvider)
+	runE := cmd.RunE
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if len(contexts) == 0 && !allContexts {
+			return runE(cmd, args)
+		}
+
+		if len(contexts) > 0 && allContexts {
+			return errors.Errorf("--%s and --%s cannot be used together", contextsFlag, allContextsFlag)
+		}
+
+		if f := cmd.Flag(ContextFlag); f != nil && f.Changed {
+			return errors.Errorf("--%s cannot be used with --%s or --%s", ContextFlag, contextsFlag, allContextsFlag)
+		}
+
+		if allContexts {
+			var err error
+			if contexts, err = clientSetProvider.Contexts(); err != nil {
+				return err
+			}
+		}
+
+		if len(contexts) == 0 {
+			return errors.New("no kubernetes contexts found")
+		}
+
+		printer, err := NewDataPrinter(cmd)
+		if err != nil {
+			return err
+		}
+
+		results := runInContexts(cmd, args, clientSetProvider, newCommand, contexts, printer != nil)
+
+		if printer != nil {
+			err = printContextResults(cmd, printer, results)
+		} else {
+			err = writeContextResults(cmd, results)
+		}
+		if err != nil {
+			return err
+		}
+
+		failed := 0
+		for _, result := range results {
+			if result.err != nil {
+				failed++
+				if _, err := fmt.Fprintf(cmd.ErrOrStderr(), "Error in context %q: %s\n", result.context, result.err); err != nil {
+					return err
+				}
+			}
+		}
+
+		if failed > 0 {
+			return errors.Errorf("%d of %d contexts failed", failed, len(results))
+		}
+		return allEmpty(results)
+	}
+
+	cmd.Flags().StringSliceVar(&contexts, contextsFlag, nil, "comma separated kubeconfig contexts to query, results are merged with a CLUSTER column")
+	cmd.Flags().BoolVar(&allContexts, allContextsFlag, false, "query all kubeconfig contexts, results are merged with a CLUSTER column")
+	return cmd
+}
+
+type contextResult struct {
+	context   string
+	collector *TableCollector
+	data      interface{}
+	empty     *EmptyListError
+	err       error
+}
+
+func runInContexts(cmd *cobra.Command, args []string, clientSetProvider k8s.ContextClientSetProvider, newCommand func(k8s.ClientSetProvider) *cobra.Command, contexts []string, structured bool) []contextResult {
+	results := make([]contextResult, len(contexts))
+	outputs := make([]*bytes.Buffer, len(contexts))
+	subs := make([]*cobra.Command, len(contexts))
+
+	// the flags of cmd are not safe to read concurrently, so the commands
+	// are set up before running them in parallel
+	for i, context := range contexts {
+		results[i] = contextResult{context: context, collector: &TableCollector{}}
+		outputs[i] = &bytes.Buffer{}
+
+		sub := newCommand(clientSetProvider.ForContext(context))
+		if err := copyFlags(cmd, sub); err != nil {
+			results[i].err = err
+			continue
+		}
+
+		if structured {
+			if err := sub.Flags().Set(OutputFlag, "json"); err != nil {
+				results[i].err = err
+				continue
+			}
+			sub.SetOut(outputs[i])
+			sub.SetContext(cmd.Context())
+		} else {
+			sub.SetOut(io.Discard)
+			sub.SetContext(withTableCollector(cmd.Context(), results[i].collector))
+		}
+		sub.SetErr(&bytes.Buffer{})
+		subs[i] = sub
+	}
+
+	var wg sync.WaitGroup
+	for i, sub := range subs {
+		if sub == nil {
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, sub *cobra.Command) {
+			defer wg.Done()
+
+			err := sub.RunE(sub, args)
+			if emptyErr := (EmptyListError{}); errors.As(err, &emptyErr) {
+				results[i].empty = &emptyErr
+				return
+			} else if err != nil {
+				results[i].err = err
+				return
+			}
+
+			if structured {
+				results[i].err = json.Unmarshal(outputs[i].Bytes(), &results[i].data)
+			}
+		}(i, sub)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// allEmpty returns the empty list error of the first context when no context
+// listed anything, like the list command does for a single context.
+func allEmpty(results []contextResult) error {
+	for _, result := range results {
+		if result.empty == nil {
+			return nil
+		}
+	}
+	return *results[0].empty
+}
+
+// copyFlags sets the flags given to cmd on sub, which is a new instance of
+// the same command.
+func copyFlags(cmd, sub *cobra.Command) error {
+	var err error
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		target := sub.Flags().Lookup(f.Name)
+		if err != nil || target == nil || f.Name == OutputFlag {
+			return
+		}
+
+		if slice, ok := f.Value.(pflag.SliceValue); ok {
+			if targetSlice, ok := target.Value.(pflag.SliceValue); ok {
+				err = targetSlice.Replace(slice.GetSlice())
+				target.Changed = true
+				return
+			}
+		}
+		err = sub.Flags().Set(f.Name, f.Value.String())
+	})
+	return err
+}
+
+// printContextResults adds the context as the cluster field of each printed
+// entry, so the entries of all contexts can be printed as one list.
+func printContextResults(cmd *cobra.Command, printer *DataPrinter, results []contextResult) error {
+	entries := []interface{}{}
+	for _, result := range results {
+		if result.err != nil {
+			continue
+		}
+
+		items, ok := result.data.([]interface{})
+		if !ok {
+			items = []interface{}{result.data}
+		}
+
+		for _, item := range items {
+			entry := map[string]interface{}{"cluster": result.context}
+			if fields, ok := item.(map[string]interface{}); ok {
+				for k, v := range fields {
+					entry[k] = v
+				}
+			} else {
+				entry["value"] = item
+			}
+			entries = append(entries, entry)
+		}
+	}
+
+	return printer.Print(cmd.OutOrStdout(), entries)
+}
+
+// writeContextResults merges the tables of list commands, or the summary
+// fields of status commands with one row per context.
+func writeContextResults(cmd *cobra.Command, results []contextResult) error {
+	var (
+		headers []string
+		keys    []string
+		seen    = map[string]bool{}
+	)
+	for _, result := range results {
+		if result.err != nil {
+			continue
+		}
+
+		if headers == nil {
+			headers = result.collector.headers
+		}
+		for _, key := range result.collector.keys {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	if len(keys) > 0 {
+		return writeStatusRows(cmd, keys, results)
+	} else if headers != nil {
+		return writeTableRows(cmd, headers, results)
+	}
+	return nil
+}
+
+func writeTableRows(cmd *cobra.Command, headers []string, results []contextResult) error {
+	writer, err := NewTableWriter(cmd.OutOrStdout(), append([]string{"CLUSTER"}, headers...)...)
+	if err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		if result.err != nil {
+			continue
+		}
+
+		for _, row := range result.collector.rows {
+			if len(row) != len(headers) {
+				return errors.Errorf("context %q returned different columns", result.context)
+			}
+
+			if err := writer.AddRow(append([]string{result.context}, row...)...); err != nil {
+				return err
+			}
+		}
+	}
+
+	return writer.Write()
+}
+
+func writeStatusRows(cmd *cobra.Command, keys []string, results []contextResult) error {
+	writer, err := NewTableWriter(cmd.OutOrStdout(), append([]string{"CLUSTER"}, keys...)...)
+	if err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		if result.err != nil {
+			continue
+		}
+
+		values := map[string]string{}
+		for i, key := range result.collector.keys {
+			values[key] = result.collector.values[i]
+		}
+
+		row := []string{result.context}
+		for _, key := range keys {
+			value, ok := values[key]
+			if !ok {
+				value = "--"
+			}
+			row = append(row, value)
+		}
+
+		if err := writer.AddRow(row...); err != nil {
+			return err
+		}
+	}
+
+	return writer.Write()
+}
@@ -0,0 +1,150 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	kpackfakes "github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
+	"github.com/sclevine/spec"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/commands"
+	"github.com/buildpacks-community/kpack-cli/pkg/commands/clusterstack"
+	"github.com/buildpacks-community/kpack-cli/pkg/k8s"
+	"github.com/buildpacks-community/kpack-cli/pkg/testhelpers"
+)
+
+func TestMultiContextCommand(t *testing.T) {
+	spec.Run(t, "TestMultiContextCommand", testMultiContextCommand)
+}
+
+func testMultiContextCommand(t *testing.T, when spec.G, it spec.S) {
+	makeStack := func(name, id string, ready corev1.ConditionStatus) *v1alpha2.ClusterStack {
+		return &v1alpha2.ClusterStack{
+			ObjectMeta: v1.ObjectMeta{Name: name},
+			Status: v1alpha2.ClusterStackStatus{
+				Status: corev1alpha1.Status{
+					Conditions: []corev1alpha1.Condition{{Type: corev1alpha1.ConditionReady, Status: ready}},
+				},
+				ResolvedClusterStack: v1alpha2.ResolvedClusterStack{Id: id},
+			},
+		}
+	}
+
+	provider := testhelpers.FakeContextClientSetProvider{
+		DefaultProvider: testhelpers.GetFakeKpackClusterProvider(kpackfakes.NewSimpleClientset(
+			makeStack("default-stack", "default-id", corev1.ConditionTrue),
+		)),
+		Providers: map[string]k8s.ClientSetProvider{
+			"prod": testhelpers.GetFakeKpackClusterProvider(kpackfakes.NewSimpleClientset(
+				makeStack("my-stack", "prod-id", corev1.ConditionTrue),
+			)),
+			"staging": testhelpers.GetFakeKpackClusterProvider(kpackfakes.NewSimpleClientset(
+				makeStack("my-stack", "staging-id", corev1.ConditionFalse),
+				makeStack("other-stack", "other-id", corev1.ConditionTrue),
+			)),
+			"empty": testhelpers.GetFakeKpackClusterProvider(kpackfakes.NewSimpleClientset()),
+		},
+	}
+
+	run := func(newCommand func(k8s.ClientSetProvider) *cobra.Command, args ...string) (string, string, error) {
+		cmd := commands.NewMultiContextCommand(provider, newCommand)
+		out, errOut := &bytes.Buffer{}, &bytes.Buffer{}
+		cmd.SetOut(out)
+		cmd.SetErr(errOut)
+		cmd.SetArgs(args)
+		cmd.SilenceErrors = true
+		err := cmd.Execute()
+		return out.String(), errOut.String(), err
+	}
+
+	it("uses the default provider without --contexts or --all-contexts", func() {
+		out, _, err := run(clusterstack.NewListCommand)
+		require.NoError(t, err)
+		require.Equal(t, `NAME             READY    ID
+default-stack    True     default-id
+
+`, out)
+	})
+
+	it("merges the tables of each context with --all-contexts", func() {
+		out, _, err := run(clusterstack.NewListCommand, "--all-contexts")
+		require.NoError(t, err)
+		require.Equal(t, `CLUSTER    NAME           READY    ID
+prod       my-stack       True     prod-id
+staging    my-stack       False    staging-id
+staging    other-stack    True     other-id
+
+`, out)
+	})
+
+	it("merges the status of each context into one row per context with --contexts", func() {
+		out, _, err := run(clusterstack.NewStatusCommand, "my-stack", "--contexts", "staging,prod")
+		require.NoError(t, err)
+		require.Equal(t, `CLUSTER    STATUS          ID            RUN IMAGE    BUILD IMAGE
+staging    Not Ready -     staging-id    --           --
+prod       Ready           prod-id       --           --
+
+`, out)
+	})
+
+	it("adds the cluster to each entry with --output", func() {
+		out, _, err := run(clusterstack.NewListCommand, "--contexts", "prod", "--output", "json")
+		require.NoError(t, err)
+		require.Equal(t, `[
+    {
+        "cluster": "prod",
+        "id": "prod-id",
+        "name": "my-stack",
+        "ready": "True"
+    }
+]
+`, out)
+	})
+
+	it("prints the results of the other contexts when a context fails", func() {
+		out, errOut, err := run(clusterstack.NewStatusCommand, "other-stack", "--contexts", "prod,staging,missing")
+		require.EqualError(t, err, "2 of 3 contexts failed")
+		require.Equal(t, `CLUSTER    STATUS    ID          RUN IMAGE    BUILD IMAGE
+staging    Ready     other-id    --           --
+
+`, out)
+		require.Equal(t, `Error in context "prod": clusterstacks.kpack.io "other-stack" not found
+Error in context "missing": Kubernetes context "missing" not found
+`, errOut)
+	})
+
+	it("counts contexts without entries as empty instead of failed", func() {
+		out, errOut, err := run(clusterstack.NewListCommand, "--contexts", "empty,prod")
+		require.NoError(t, err)
+		require.Equal(t, `CLUSTER    NAME        READY    ID
+prod       my-stack    True     prod-id
+
+`, out)
+		require.Empty(t, errOut)
+	})
+
+	it("prints an empty list with --output when no context has entries", func() {
+		out, _, err := run(clusterstack.NewListCommand, "--contexts", "empty", "--output", "json")
+		require.NoError(t, err)
+		require.Equal(t, "[]\n", out)
+	})
+
+	it("errors like the list command when no context has entries", func() {
+		_, _, err := run(clusterstack.NewListCommand, "--contexts", "empty")
+		require.EqualError(t, err, "no clusterstacks found")
+	})
+
+	it("errors when --contexts and --all-contexts are used together", func() {
+		_, _, err := run(clusterstack.NewListCommand, "--contexts", "prod", "--all-contexts")
+		require.EqualError(t, err, "--contexts and --all-contexts cannot be used together")
+	})
+}
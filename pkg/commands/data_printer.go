@@ -0,0 +1,217 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	jsonPathPrefix      = "jsonpath="
+	customColumnsPrefix = "custom-columns="
+
+	listOutputUsage = `print the listed fields in the specified format; supported formats are: json, yaml, jsonpath=<template>, custom-columns=<header>:<jsonpath>[,<header>:<jsonpath>].
+  The output is a list with one entry per row.`
+	statusOutputUsage = `print the displayed status in the specified format; supported formats are: json, yaml, jsonpath=<template>, custom-columns=<header>:<jsonpath>[,<header>:<jsonpath>].
+  The output is a single object with the fields of the status.`
+)
+
+func SetListOutputFlag(cmd *cobra.Command) {
+	cmd.Flags().StringP(OutputFlag, "o", "", listOutputUsage)
+}
+
+func SetStatusOutputFlag(cmd *cobra.Command) {
+	cmd.Flags().StringP(OutputFlag, "o", "", statusOutputUsage)
+}
+
+// BuildpackInfo is the printed id, version and homepage of a buildpack.
+type BuildpackInfo struct {
+	Id       string `json:"id"`
+	Version  string `json:"version"`
+	Homepage string `json:"homepage,omitempty"`
+}
+
+// DataPrinter prints the fields displayed by list and status commands in a
+// machine-readable format, so scripts do not need to parse tables.
+type DataPrinter struct {
+	format   string
+	template string
+}
+
+// NewDataPrinter returns nil when the output flag is not set and the command
+// should print its table.
+func NewDataPrinter(cmd *cobra.Command) (*DataPrinter, error) {
+	output, err := GetStringFlag(OutputFlag, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case output == "":
+		return nil, nil
+	case output == "json" || output == "yaml":
+		return &DataPrinter{format: output}, nil
+	case strings.HasPrefix(output, jsonPathPrefix):
+		return &DataPrinter{format: "jsonpath", template: strings.TrimPrefix(output, jsonPathPrefix)}, nil
+	case strings.HasPrefix(output, customColumnsPrefix):
+		return &DataPrinter{format: "custom-columns", template: strings.TrimPrefix(output, customColumnsPrefix)}, nil
+	default:
+		return nil, errors.Errorf("unsupported output format: %q, supported formats are json, yaml, jsonpath=<template>, custom-columns=<spec>", output)
+	}
+}
+
+// EmptyListError is returned by list commands when there is nothing to list,
+// so that an empty list can be told apart from a failed one.
+type EmptyListError struct {
+	message string
+}
+
+func (e EmptyListError) Error() string {
+	return e.message
+}
+
+// EmptyList prints an empty list when an output format is set, otherwise it
+// returns an EmptyListError with the formatted message.
+func EmptyList(cmd *cobra.Command, format string, args ...interface{}) error {
+	printer, err := NewDataPrinter(cmd)
+	if err != nil {
+		return err
+	} else if printer != nil {
+		return printer.Print(cmd.OutOrStdout(), []interface{}{})
+	}
+
+	return EmptyListError{message: fmt.Sprintf(format, args...)}
+}
+
+func (p *DataPrinter) Print(out io.Writer, data interface{}) error {
+	switch p.format {
+	case "json":
+		buf, err := json.MarshalIndent(data, "", "    ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(out, string(buf))
+		return err
+	case "yaml":
+		buf, err := yaml.Marshal(data)
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(buf)
+		return err
+	case "jsonpath":
+		return printJSONPath(out, p.template, data)
+	default:
+		return printCustomColumns(out, p.template, data)
+	}
+}
+
+func printJSONPath(out io.Writer, template string, data interface{}) error {
+	parser, err := newJSONPath(template)
+	if err != nil {
+		return err
+	}
+
+	generic, err := toGeneric(data)
+	if err != nil {
+		return err
+	}
+
+	return parser.Execute(out, generic)
+}
+
+func printCustomColumns(out io.Writer, spec string, data interface{}) error {
+	var (
+		headers []string
+		parsers []*jsonpath.JSONPath
+	)
+	for _, column := range strings.Split(spec, ",") {
+		parts := strings.SplitN(column, ":", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return errors.Errorf("custom-columns format should be <header>:<jsonpath>, found %q", column)
+		}
+
+		parser, err := newJSONPath(parts[1])
+		if err != nil {
+			return err
+		}
+		headers = append(headers, parts[0])
+		parsers = append(parsers, parser)
+	}
+
+	generic, err := toGeneric(data)
+	if err != nil {
+		return err
+	}
+
+	items, ok := generic.([]interface{})
+	if !ok {
+		items = []interface{}{generic}
+	}
+
+	writer, err := NewTableWriter(out, headers...)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		var row []string
+		for _, parser := range parsers {
+			buf := &bytes.Buffer{}
+			if err := parser.Execute(buf, item); err != nil {
+				return err
+			}
+
+			value := buf.String()
+			if value == "" {
+				value = "<none>"
+			}
+			row = append(row, value)
+		}
+
+		if err := writer.AddRow(row...); err != nil {
+			return err
+		}
+	}
+
+	return writer.Write()
+}
+
+// newJSONPath accepts templates with or without the surrounding braces, like kubectl.
+func newJSONPath(template string) (*jsonpath.JSONPath, error) {
+	if !strings.Contains(template, "{") {
+		template = fmt.Sprintf("{%s}", template)
+	}
+
+	parser := jsonpath.New("output").AllowMissingKeys(true)
+	if err := parser.Parse(template); err != nil {
+		return nil, errors.Wrapf(err, "invalid jsonpath %q", template)
+	}
+	return parser, nil
+}
+
+// toGeneric converts data to maps and slices so jsonpath uses the json field names.
+func toGeneric(data interface{}) (interface{}, error) {
+	if v := reflect.ValueOf(data); v.Kind() == reflect.Slice && v.IsNil() {
+		return []interface{}{}, nil
+	}
+
+	buf, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var generic interface{}
+	return generic, json.Unmarshal(buf, &generic)
+}
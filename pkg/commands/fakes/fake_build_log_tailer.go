@@ -0,0 +1,38 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package fakes
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+)
+
+type FakeBuildLogTailer struct {
+	mu     sync.Mutex
+	builds []string
+
+	// BeforeTail is called with the build number before its logs are written
+	BeforeTail func(build string)
+}
+
+func (f *FakeBuildLogTailer) Tail(ctx context.Context, writer io.Writer, image, build, namespace string, timestamp bool) error {
+	if f.BeforeTail != nil {
+		f.BeforeTail(build)
+	}
+
+	f.mu.Lock()
+	f.builds = append(f.builds, build)
+	f.mu.Unlock()
+
+	_, err := fmt.Fprintf(writer, "logs of %s/%s build %s\n", namespace, image, build)
+	return err
+}
+
+func (f *FakeBuildLogTailer) TailedBuilds() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string{}, f.builds...)
+}
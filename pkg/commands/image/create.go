@@ -24,6 +24,7 @@ func NewCreateCommand(clientSetProvider k8s.ClientSetProvider, rup registry.Util
 		subPath   string
 		factory   image.Factory
 		tlsCfg    registry.TLSConfig
+		revision  revisionOptions
 	)
 
 	cmd := &cobra.Command{
@@ -40,10 +41,17 @@ The flags for this command determine how the build will retrieve source code:
   "--blob" to use source code hosted in a blob store
   "--local-path" to use source code from the local machine
 
+"--resolve" checks that the git revision exists before the image resource is created,
+using the git secrets of the service account. "--pin" also replaces the revision with the resolved commit sha.
+
 Local source code will be pushed to the same registry provided for the image resource tag.
 --local-path-destination-image can be used to specify the repository of the source code image.
 If not specified, the source code image will be pushed to the <image-tag-repo>-source repo.
 Therefore, you must have credentials to access the registry on your machine.
+
+Local source code files matching the patterns in a .kpignore file at the root of the local path are not uploaded.
+Without a .kpignore file, the .git directory and files matching .gitignore files are not uploaded.
+Additional patterns may be provided with the "--exclude" flag. Use "--dry-run" to list the files that would be uploaded.
 --registry-ca-cert-path and --registry-verify-certs are only used for local source type.
 
 Environment variables may be provided by using the "--env" flag.
@@ -56,6 +64,7 @@ For example, "--service-binding my-secret-1 --service-binding Secret:v1:my-secre
 
 Env vars can be used for registry auth as described in https://github.com/buildpacks-community/kpack-cli/blob/main/docs/auth.md"`,
 		Example: `kp image create my-image --tag my-registry.com/my-repo --git https://my-repo.com/my-app.git --git-revision my-branch
+kp image create my-image --tag my-registry.com/my-repo --git https://my-repo.com/my-app.git --git-revision v1.0.0 --pin
 kp image create my-image --tag my-registry.com/my-repo --blob https://my-blob-host.com/my-blob
 kp image create my-image --tag my-registry.com/my-repo --local-path /path/to/local/source/code
 kp image create my-image --tag my-registry.com/my-repo --local-path /path/to/local/source/code --builder my-builder -n my-namespace
@@ -81,7 +90,7 @@ kp image create my-image --tag my-registry.com/my-repo --blob https://my-blob-ho
 			factory.Printer = ch
 
 			ctx := cmd.Context()
-			img, err := create(ctx, name, tag, &factory, ch, cs)
+			img, err := create(ctx, name, tag, &factory, revision, ch, cs)
 			if err != nil {
 				return err
 			}
@@ -100,8 +109,10 @@ kp image create my-image --tag my-registry.com/my-repo --blob https://my-blob-ho
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "kubernetes namespace")
 	cmd.Flags().StringVar(&factory.GitRepo, "git", "", "git repository url")
 	cmd.Flags().StringVar(&factory.GitRevision, "git-revision", "", "git revision such as commit, tag, or branch (default \"main\")")
+	setRevisionFlags(cmd, &revision)
 	cmd.Flags().StringVar(&factory.Blob, "blob", "", "source code blob url")
 	cmd.Flags().StringVar(&factory.LocalPath, "local-path", "", "path to local source code")
+	cmd.Flags().StringArrayVar(&factory.Excludes, "exclude", []string{}, "gitignore style pattern of local source files to leave out of the upload")
 	cmd.Flags().StringVar(&factory.LocalPathDestinationImage, "local-path-destination-image", "", "registry location of where the local source code will be uploaded to (default \"<image-tag-repo>-source\")")
 	cmd.Flags().StringVar(&subPath, "sub-path", "", "build code at the sub path located within the source code directory")
 	cmd.Flags().StringVarP(&factory.Builder, "builder", "b", "", "builder name")
@@ -119,7 +130,7 @@ kp image create my-image --tag my-registry.com/my-repo --blob https://my-blob-ho
 	return cmd
 }
 
-func create(ctx context.Context, name, tag string, factory *image.Factory, ch *commands.CommandHelper, cs k8s.ClientSet) (*v1alpha2.Image, error) {
+func create(ctx context.Context, name, tag string, factory *image.Factory, revision revisionOptions, ch *commands.CommandHelper, cs k8s.ClientSet) (*v1alpha2.Image, error) {
 	if err := ch.PrintStatus("Creating Image Resource..."); err != nil {
 		return nil, err
 	}
@@ -129,6 +140,10 @@ func create(ctx context.Context, name, tag string, factory *image.Factory, ch *c
 		return nil, err
 	}
 
+	if err := revision.resolveRevision(ctx, img, ch, cs); err != nil {
+		return nil, err
+	}
+
 	if err := k8s.SetLastAppliedCfg(img); err != nil {
 		return nil, err
 	}
@@ -8,9 +8,13 @@ import (
 	"os"
 	"strconv"
 	"testing"
+	"time"
 
 	registryfakes "github.com/buildpacks-community/kpack-cli/pkg/registry/fakes"
 
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
 	"github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
@@ -22,6 +26,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	k8sfakes "k8s.io/client-go/kubernetes/fake"
 
 	cmdFakes "github.com/buildpacks-community/kpack-cli/pkg/commands/fakes"
 	imgcmds "github.com/buildpacks-community/kpack-cli/pkg/commands/image"
@@ -1045,6 +1050,163 @@ Image Resource "some-image" created
 				assert.Len(t, fakeImageWaiter.Calls, 0)
 			})
 		})
+
+		when("the git revision is resolved", func() {
+			var (
+				repoPath  string
+				commitSha string
+			)
+
+			it.Before(func() {
+				repoPath, commitSha = initGitRepo(t, "some-branch")
+			})
+
+			k8sCmdFunc := func(k8sClientSet *k8sfakes.Clientset, kpackClientSet *fake.Clientset) *cobra.Command {
+				clientSetProvider := testhelpers.GetFakeProvider(k8sClientSet, kpackClientSet, defaultNamespace)
+				return imageCommand(clientSetProvider, registryUtilProvider, func(set k8s.ClientSet) imgcmds.ImageWaiter {
+					return fakeImageWaiter
+				})
+			}
+
+			makeImage := func(revision string) *v1alpha2.Image {
+				img := &v1alpha2.Image{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       "Image",
+						APIVersion: "kpack.io/v1alpha2",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "some-image",
+						Namespace:   defaultNamespace,
+						Annotations: map[string]string{},
+					},
+					Spec: v1alpha2.ImageSpec{
+						Tag: "some-registry.io/some-repo",
+						Builder: corev1.ObjectReference{
+							Kind: v1alpha2.ClusterBuilderKind,
+							Name: "default",
+						},
+						ServiceAccountName: "default",
+						Source: corev1alpha1.SourceConfig{
+							Git: &corev1alpha1.Git{
+								URL:      repoPath,
+								Revision: revision,
+							},
+						},
+						Build: &v1alpha2.ImageBuild{},
+					},
+				}
+				require.NoError(t, setLastAppliedAnnotation(img))
+				return img
+			}
+
+			it("creates the image with the revision when it exists", func() {
+				testhelpers.CommandTest{
+					Args: []string{
+						"some-image",
+						"--tag", "some-registry.io/some-repo",
+						"--git", repoPath,
+						"--git-revision", "some-branch",
+						"--resolve",
+					},
+					ExpectedOutput: `Creating Image Resource...
+Resolving git revision 'some-branch'...
+Resolved git revision 'some-branch' to '` + commitSha + `'
+Image Resource "some-image" created
+`,
+					ExpectCreates: []runtime.Object{
+						makeImage("some-branch"),
+					},
+				}.TestK8sAndKpack(t, k8sCmdFunc)
+			})
+
+			it("pins the image to the resolved commit sha", func() {
+				testhelpers.CommandTest{
+					Args: []string{
+						"some-image",
+						"--tag", "some-registry.io/some-repo",
+						"--git", repoPath,
+						"--git-revision", "some-branch",
+						"--pin",
+					},
+					ExpectedOutput: `Creating Image Resource...
+Resolving git revision 'some-branch'...
+Resolved git revision 'some-branch' to '` + commitSha + `'
+Image Resource "some-image" created
+`,
+					ExpectCreates: []runtime.Object{
+						makeImage(commitSha),
+					},
+				}.TestK8sAndKpack(t, k8sCmdFunc)
+			})
+
+			it("fails when the revision does not exist", func() {
+				testhelpers.CommandTest{
+					Args: []string{
+						"some-image",
+						"--tag", "some-registry.io/some-repo",
+						"--git", repoPath,
+						"--git-revision", "missing-branch",
+						"--pin",
+					},
+					ExpectErr: true,
+					ExpectedOutput: `Creating Image Resource...
+Resolving git revision 'missing-branch'...
+`,
+					ExpectedErrorOutput: "Error: git revision 'missing-branch' not found in repository '" + repoPath + "', use a branch, tag or full commit sha\n",
+				}.TestK8sAndKpack(t, k8sCmdFunc)
+			})
+
+			it("reports commit shas that cannot be checked", func() {
+				testhelpers.CommandTest{
+					Args: []string{
+						"some-image",
+						"--tag", "some-registry.io/some-repo",
+						"--git", repoPath,
+						"--git-revision", "0000000",
+						"--resolve",
+					},
+					ExpectedOutput: `Creating Image Resource...
+Resolving git revision '0000000'...
+Could not check git revision '0000000', it is not the commit of a branch or tag
+Image Resource "some-image" created
+`,
+					ExpectCreates: []runtime.Object{
+						makeImage("0000000"),
+					},
+				}.TestK8sAndKpack(t, k8sCmdFunc)
+			})
+
+			it("fails to pin an abbreviated commit sha that cannot be checked", func() {
+				testhelpers.CommandTest{
+					Args: []string{
+						"some-image",
+						"--tag", "some-registry.io/some-repo",
+						"--git", repoPath,
+						"--git-revision", "0000000",
+						"--pin",
+					},
+					ExpectErr: true,
+					ExpectedOutput: `Creating Image Resource...
+Resolving git revision '0000000'...
+`,
+					ExpectedErrorOutput: "Error: git revision '0000000' could not be resolved to a full commit sha, use a full commit sha with --pin\n",
+				}.TestK8sAndKpack(t, k8sCmdFunc)
+			})
+
+			it("fails for a source that is not git", func() {
+				testhelpers.CommandTest{
+					Args: []string{
+						"some-image",
+						"--tag", "some-registry.io/some-repo",
+						"--blob", "some-blob",
+						"--resolve",
+					},
+					ExpectErr:           true,
+					ExpectedOutput:      "Creating Image Resource...\n",
+					ExpectedErrorOutput: "Error: --resolve and --pin can only be used with a git source\n",
+				}.TestK8sAndKpack(t, k8sCmdFunc)
+			})
+		})
 	}
 }
 
@@ -1055,3 +1217,23 @@ func getTestFile(testfile string) (string, error) {
 	}
 	return string(b), nil
 }
+
+// initGitRepo creates a repository with one empty commit on the branch and
+// returns its path and the commit sha.
+func initGitRepo(t *testing.T, branch string) (string, string) {
+	repoPath := t.TempDir()
+	repo, err := gogit.PlainInit(repoPath, false)
+	require.NoError(t, err)
+
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+
+	commit, err := worktree.Commit("some-commit", &gogit.CommitOptions{
+		AllowEmptyCommits: true,
+		Author:            &object.Signature{Name: "some-author", Email: "some@example.com", When: time.Now()},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName(branch), commit)))
+	return repoPath, commit.String()
+}
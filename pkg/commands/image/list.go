@@ -8,7 +8,6 @@ import (
 
 	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
-	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -62,7 +61,7 @@ kp image list --filter ready=true --filter latest-reason=commit,trigger`,
 			})
 
 			if len(imageList.Items) == 0 {
-				return errors.New("no image resources found")
+				return commands.EmptyList(cmd, "no image resources found")
 			} else {
 				return displayImagesTable(cmd, imageList)
 			}
@@ -72,26 +71,46 @@ kp image list --filter ready=true --filter latest-reason=commit,trigger`,
 	}
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "kubernetes namespace")
 	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "Return objects found in all namespaces")
-	cmd.Flags().StringArrayVar(&filters, "filter", nil,
-		`Each new filter argument requires an additional filter flag.
-Multiple values can be provided using comma separation.
-Supported filters and values:
-  builder=string
-  clusterbuilder=string
-  latest-reason=commit,trigger,config,stack,buildpack
-  ready=true,false,unknown`)
+	cmd.Flags().StringArrayVar(&filters, "filter", nil, filterUsage)
+	commands.SetListOutputFlag(cmd)
 
 	return cmd
 }
 
+type imageRow struct {
+	Name         string `json:"name"`
+	Ready        string `json:"ready"`
+	LatestReason string `json:"latestReason"`
+	LatestImage  string `json:"latestImage"`
+	Namespace    string `json:"namespace"`
+}
+
 func displayImagesTable(cmd *cobra.Command, imageList *v1alpha2.ImageList) error {
-	writer, err := commands.NewTableWriter(cmd.OutOrStdout(), "NAME", "READY", "LATEST REASON", "LATEST IMAGE", "NAMESPACE")
+	rows := make([]imageRow, 0, len(imageList.Items))
+	for _, img := range imageList.Items {
+		rows = append(rows, imageRow{
+			Name:         img.Name,
+			Ready:        getReadyText(img),
+			LatestReason: img.Status.LatestBuildReason,
+			LatestImage:  img.Status.LatestImage,
+			Namespace:    img.Namespace,
+		})
+	}
+
+	printer, err := commands.NewDataPrinter(cmd)
 	if err != nil {
 		return err
+	} else if printer != nil {
+		return printer.Print(cmd.OutOrStdout(), rows)
 	}
 
-	for _, img := range imageList.Items {
-		err := writer.AddRow(img.Name, getReadyText(img), img.Status.LatestBuildReason, img.Status.LatestImage, img.Namespace)
+	writer, err := commands.NewCommandTableWriter(cmd, "NAME", "READY", "LATEST REASON", "LATEST IMAGE", "NAMESPACE")
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		err := writer.AddRow(row.Name, row.Ready, row.LatestReason, row.LatestImage, row.Namespace)
 		if err != nil {
 			return err
 		}
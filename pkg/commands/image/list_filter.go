@@ -13,6 +13,14 @@ import (
 	corev1 "k8s.io/api/core/v1"
 )
 
+const filterUsage = `Each new filter argument requires an additional filter flag.
+Multiple values can be provided using comma separation.
+Supported filters and values:
+  builder=string
+  clusterbuilder=string
+  latest-reason=commit,trigger,config,stack,buildpack
+  ready=true,false,unknown`
+
 type filter struct {
 	filterFunc func(image v1alpha2.Image, values []string) bool
 	values     []string
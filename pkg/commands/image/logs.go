@@ -0,0 +1,203 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package image
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/pivotal/kpack/pkg/client/informers/externalversions"
+	v1alpha2listers "github.com/pivotal/kpack/pkg/client/listers/build/v1alpha2"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/commands"
+	"github.com/buildpacks-community/kpack-cli/pkg/k8s"
+)
+
+type BuildLogTailer interface {
+	Tail(ctx context.Context, writer io.Writer, image, build, namespace string, timestamp bool) error
+}
+
+func NewLogsCommand(clientSetProvider k8s.ClientSetProvider, newLogTailer func(k8s.ClientSet) BuildLogTailer) *cobra.Command {
+	var (
+		namespace string
+		follow    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "logs <name>",
+		Short: "Tails logs for the builds of an image resource",
+		Long: `Tails the logs of the latest build of an image resource in the provided namespace.
+
+Use the flag --follow to stay attached to the image resource. When a build completes, the logs of the next build
+are tailed as soon as it is created, whatever caused it, such as a stack rebase, a buildpack update or a trigger.
+The command then runs until it is interrupted.
+
+The logs of each build start with a header showing the build number and the reason for the build.
+
+The namespace defaults to the kubernetes current-context namespace.
+
+Use the flag --timestamps to include the timestamps for the logs`,
+		Example:      "kp image logs my-image\nkp image logs my-image --follow -n my-namespace",
+		Args:         commands.ExactArgsWithUsage(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cs, err := clientSetProvider.GetClientSet(namespace)
+			if err != nil {
+				return err
+			}
+
+			ch, err := commands.NewCommandHelper(cmd)
+			if err != nil {
+				return err
+			}
+
+			selector := v1alpha2.ImageLabel + "=" + args[0]
+			factory := externalversions.NewSharedInformerFactoryWithOptions(cs.KpackClient, 0,
+				externalversions.WithNamespace(cs.Namespace),
+				externalversions.WithTweakListOptions(func(options *metav1.ListOptions) {
+					options.LabelSelector = selector
+				}))
+
+			follower := newBuildFollower(factory, newLogTailer(cs), args[0], cs.Namespace, ch.ShowTimestamp())
+			return follower.Run(cmd.Context(), cmd.OutOrStdout(), follow)
+		},
+	}
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "kubernetes namespace")
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "keep tailing the logs of each new build until interrupted")
+	cmd.Flags().BoolP("timestamps", "t", false, "show log timestamps")
+	return cmd
+}
+
+// buildFollower tails the logs of the builds of one image in order, waiting
+// for the next build to be created once a build completes.
+type buildFollower struct {
+	factory    externalversions.SharedInformerFactory
+	builds     v1alpha2listers.BuildNamespaceLister
+	informer   cache.SharedIndexInformer
+	tailer     BuildLogTailer
+	image      string
+	namespace  string
+	timestamps bool
+	changed    chan struct{}
+}
+
+func newBuildFollower(factory externalversions.SharedInformerFactory, tailer BuildLogTailer, image, namespace string, timestamps bool) *buildFollower {
+	buildInformer := factory.Kpack().V1alpha2().Builds()
+
+	return &buildFollower{
+		factory:    factory,
+		builds:     buildInformer.Lister().Builds(namespace),
+		informer:   buildInformer.Informer(),
+		tailer:     tailer,
+		image:      image,
+		namespace:  namespace,
+		timestamps: timestamps,
+		changed:    make(chan struct{}, 1),
+	}
+}
+
+func (f *buildFollower) Run(ctx context.Context, out io.Writer, follow bool) error {
+	_, err := f.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(interface{}) { f.notify() },
+		UpdateFunc: func(interface{}, interface{}) { f.notify() },
+	})
+	if err != nil {
+		return err
+	}
+
+	// the informers are stopped when the logs are tailed without following the image
+	informerCtx, cancel := context.WithCancel(ctx)
+	f.factory.Start(informerCtx.Done())
+	defer func() {
+		cancel()
+		f.factory.Shutdown()
+	}()
+
+	if !cache.WaitForCacheSync(ctx.Done(), f.informer.HasSynced) {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return errors.New("failed to sync builds")
+	}
+
+	lastNumber := -1
+	for {
+		bld, err := f.nextBuild(lastNumber)
+		if err != nil {
+			return err
+		}
+
+		if bld == nil {
+			if !follow {
+				return errors.New("no builds found")
+			}
+
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-f.changed:
+				continue
+			}
+		}
+
+		if err := f.tail(ctx, out, bld); err != nil {
+			return err
+		}
+
+		if !follow || ctx.Err() != nil {
+			return nil
+		}
+		lastNumber = buildNumber(bld)
+	}
+}
+
+func (f *buildFollower) notify() {
+	select {
+	case f.changed <- struct{}{}:
+	default:
+	}
+}
+
+// nextBuild returns the latest build when no build was tailed yet, otherwise
+// the first build numbered after lastNumber, so builds that were created while
+// another build was tailed are tailed in order.
+func (f *buildFollower) nextBuild(lastNumber int) (*v1alpha2.Build, error) {
+	builds, err := f.builds.List(labels.SelectorFromSet(labels.Set{v1alpha2.ImageLabel: f.image}))
+	if err != nil {
+		return nil, err
+	}
+
+	var next *v1alpha2.Build
+	for _, bld := range builds {
+		number := buildNumber(bld)
+		if number <= lastNumber {
+			continue
+		}
+
+		if next == nil || (lastNumber < 0 && number > buildNumber(next)) || (lastNumber >= 0 && number < buildNumber(next)) {
+			next = bld
+		}
+	}
+	return next, nil
+}
+
+func (f *buildFollower) tail(ctx context.Context, out io.Writer, bld *v1alpha2.Build) error {
+	header := fmt.Sprintf("===> BUILD #%s", bld.Labels[v1alpha2.BuildNumberLabel])
+	if reason := bld.Annotations[v1alpha2.BuildReasonAnnotation]; reason != "" {
+		header += fmt.Sprintf(" (%s)", reason)
+	}
+
+	if _, err := fmt.Fprintln(out, header); err != nil {
+		return err
+	}
+
+	return f.tailer.Tail(ctx, out, f.image, bld.Labels[v1alpha2.BuildNumberLabel], f.namespace, f.timestamps)
+}
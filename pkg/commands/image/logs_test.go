@@ -0,0 +1,180 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package image_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
+	"github.com/sclevine/spec"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	cmdFakes "github.com/buildpacks-community/kpack-cli/pkg/commands/fakes"
+	"github.com/buildpacks-community/kpack-cli/pkg/commands/image"
+	"github.com/buildpacks-community/kpack-cli/pkg/k8s"
+	"github.com/buildpacks-community/kpack-cli/pkg/testhelpers"
+)
+
+func TestImageLogsCommand(t *testing.T) {
+	spec.Run(t, "TestImageLogsCommand", testImageLogsCommand)
+}
+
+func testImageLogsCommand(t *testing.T, when spec.G, it spec.S) {
+	const defaultNamespace = "some-default-namespace"
+
+	makeBuild := func(imageName, number, reason string) *v1alpha2.Build {
+		return &v1alpha2.Build{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      imageName + "-build-" + number,
+				Namespace: defaultNamespace,
+				Labels: map[string]string{
+					v1alpha2.ImageLabel:       imageName,
+					v1alpha2.BuildNumberLabel: number,
+				},
+				Annotations: map[string]string{v1alpha2.BuildReasonAnnotation: reason},
+			},
+		}
+	}
+
+	objects := []runtime.Object{
+		makeBuild("some-image", "1", "CONFIG"),
+		makeBuild("some-image", "2", "STACK"),
+		makeBuild("other-image", "3", "TRIGGER"),
+	}
+
+	var tailer *cmdFakes.FakeBuildLogTailer
+
+	it.Before(func() {
+		tailer = &cmdFakes.FakeBuildLogTailer{}
+	})
+
+	newLogsCommand := func(clientSet *fake.Clientset) *cobra.Command {
+		return image.NewLogsCommand(testhelpers.GetFakeKpackProvider(clientSet, defaultNamespace), func(k8s.ClientSet) image.BuildLogTailer {
+			return tailer
+		})
+	}
+
+	it("tails the logs of the latest build", func() {
+		testhelpers.CommandTest{
+			Objects: objects,
+			Args:    []string{"some-image"},
+			ExpectedOutput: `===> BUILD #2 (STACK)
+logs of some-default-namespace/some-image build 2
+`,
+		}.TestKpack(t, newLogsCommand)
+	})
+
+	it("returns an error when the image has no builds", func() {
+		testhelpers.CommandTest{
+			Objects:             objects,
+			Args:                []string{"missing-image"},
+			ExpectErr:           true,
+			ExpectedErrorOutput: "Error: no builds found\n",
+		}.TestKpack(t, newLogsCommand)
+	})
+
+	when("following the image", func() {
+		it("tails each new build as it is created until interrupted", func() {
+			client := fake.NewSimpleClientset(objects...)
+			cmd := newLogsCommand(client)
+			out := &syncBuffer{}
+			cmd.SetOut(out)
+			cmd.SetErr(out)
+			cmd.SetArgs([]string{"some-image", "--follow"})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			errs := make(chan error, 1)
+			go func() { errs <- cmd.ExecuteContext(ctx) }()
+
+			require.Eventually(t, func() bool {
+				return strings.Contains(out.String(), "logs of some-default-namespace/some-image build 2")
+			}, 5*time.Second, 10*time.Millisecond)
+
+			require.Eventually(t, func() bool {
+				// updates can be missed by the fake clientset until its watch is established, so keep sending them
+				next := makeBuild("some-image", "3", "BUILDPACK")
+				next.Annotations["attempt"] = time.Now().String()
+				_, err := client.KpackV1alpha2().Builds(defaultNamespace).Create(context.Background(), next, metav1.CreateOptions{})
+				if k8serrors.IsAlreadyExists(err) {
+					_, err = client.KpackV1alpha2().Builds(defaultNamespace).Update(context.Background(), next, metav1.UpdateOptions{})
+				}
+				if err != nil {
+					return false
+				}
+
+				return strings.Contains(out.String(), "logs of some-default-namespace/some-image build 3")
+			}, 5*time.Second, 50*time.Millisecond)
+
+			cancel()
+			require.NoError(t, <-errs)
+
+			require.Equal(t, `===> BUILD #2 (STACK)
+logs of some-default-namespace/some-image build 2
+===> BUILD #3 (BUILDPACK)
+logs of some-default-namespace/some-image build 3
+`, out.String())
+			require.Equal(t, []string{"2", "3"}, tailer.TailedBuilds())
+		})
+
+		it("tails the builds created while another build was tailed in order", func() {
+			client := fake.NewSimpleClientset(objects...)
+
+			createOrUpdate := func(bld *v1alpha2.Build) error {
+				bld.Annotations["attempt"] = time.Now().String()
+				_, err := client.KpackV1alpha2().Builds(defaultNamespace).Create(context.Background(), bld, metav1.CreateOptions{})
+				if k8serrors.IsAlreadyExists(err) {
+					_, err = client.KpackV1alpha2().Builds(defaultNamespace).Update(context.Background(), bld, metav1.UpdateOptions{})
+				}
+				return err
+			}
+
+			tailer.BeforeTail = func(build string) {
+				if build != "2" {
+					return
+				}
+				require.NoError(t, createOrUpdate(makeBuild("some-image", "3", "STACK")))
+				require.NoError(t, createOrUpdate(makeBuild("some-image", "4", "TRIGGER")))
+				// give the informer time to see both builds before the next one is picked
+				time.Sleep(200 * time.Millisecond)
+			}
+
+			cmd := newLogsCommand(client)
+			out := &syncBuffer{}
+			cmd.SetOut(out)
+			cmd.SetErr(out)
+			cmd.SetArgs([]string{"some-image", "--follow"})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			errs := make(chan error, 1)
+			go func() { errs <- cmd.ExecuteContext(ctx) }()
+
+			require.Eventually(t, func() bool {
+				// updates can be missed by the fake clientset until its watch is established, so keep sending them
+				if len(tailer.TailedBuilds()) > 0 {
+					_ = createOrUpdate(makeBuild("some-image", "3", "STACK"))
+					_ = createOrUpdate(makeBuild("some-image", "4", "TRIGGER"))
+				}
+				return strings.Contains(out.String(), "logs of some-default-namespace/some-image build 4")
+			}, 5*time.Second, 50*time.Millisecond)
+
+			cancel()
+			require.NoError(t, <-errs)
+
+			require.Equal(t, []string{"2", "3", "4"}, tailer.TailedBuilds())
+			require.Contains(t, out.String(), `===> BUILD #3 (STACK)
+logs of some-default-namespace/some-image build 3
+===> BUILD #4 (TRIGGER)
+logs of some-default-namespace/some-image build 4
+`)
+		})
+	})
+}
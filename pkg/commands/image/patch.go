@@ -25,6 +25,7 @@ func NewPatchCommand(clientSetProvider k8s.ClientSetProvider, rup registry.UtilP
 		subPath   string
 		factory   image.Factory
 		tlsCfg    registry.TLSConfig
+		revision  revisionOptions
 	)
 
 	cmd := &cobra.Command{
@@ -41,9 +42,16 @@ The flags for this command determine how the build will retrieve source code:
   "--blob" to use source code hosted in a blob store
   "--local-path" to use source code from the local machine
 
+"--resolve" checks that the git revision exists before the image resource is patched,
+using the git secrets of the service account. "--pin" also replaces the revision with the resolved commit sha.
+
 Local source code will be pushed to the same registry as the existing image resource tag.
 Therefore, you must have credentials to access the registry on your machine.
 
+Local source code files matching the patterns in a .kpignore file at the root of the local path are not uploaded.
+Without a .kpignore file, the .git directory and files matching .gitignore files are not uploaded.
+Additional patterns may be provided with the "--exclude" flag. Use "--dry-run" to list the files that would be uploaded.
+
 All tags found under Image.spec.additionalTags will be added to your built OCI image.
 To append to the list of tags that will be added to a built image, use the "additional-tag" flag.
 To remove a tag from the list of tags that will be added to a built image, use the "delete-additional-tag".
@@ -62,6 +70,7 @@ The --cache-size flag can only be used to increase the size of the existing cach
 Env vars can be used for registry auth as described in https://github.com/buildpacks-community/kpack-cli/blob/main/docs/auth.md
 `,
 		Example: `kp image patch my-image --git-revision my-other-branch
+kp image patch my-image --git-revision v1.0.1 --pin
 kp image patch my-image --blob https://my-blob-host.com/my-blob
 kp image patch my-image --local-path /path/to/local/source/code
 kp image patch my-image --local-path /path/to/local/source/code --builder my-builder
@@ -94,7 +103,7 @@ kp image patch my-image --tag my-registry.com/my-repo --blob https://my-blob-hos
 				factory.SubPath = &subPath
 			}
 
-			wasPatched, img, err := patch(ctx, img, &factory, ch, cs)
+			wasPatched, img, err := patch(ctx, img, &factory, revision, ch, cs)
 			if err != nil {
 				return err
 			}
@@ -115,8 +124,10 @@ kp image patch my-image --tag my-registry.com/my-repo --blob https://my-blob-hos
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "kubernetes namespace")
 	cmd.Flags().StringVar(&factory.GitRepo, "git", "", "git repository url")
 	cmd.Flags().StringVar(&factory.GitRevision, "git-revision", "", "git revision such as commit, tag, or branch (default \"main\")")
+	setRevisionFlags(cmd, &revision)
 	cmd.Flags().StringVar(&factory.Blob, "blob", "", "source code blob url")
 	cmd.Flags().StringVar(&factory.LocalPath, "local-path", "", "path to local source code")
+	cmd.Flags().StringArrayVar(&factory.Excludes, "exclude", []string{}, "gitignore style pattern of local source files to leave out of the upload")
 	cmd.Flags().StringVar(&subPath, "sub-path", "", "build code at the sub path located within the source code directory")
 	cmd.Flags().StringVar(&factory.Builder, "builder", "", "builder name")
 	cmd.Flags().StringVar(&factory.ClusterBuilder, "cluster-builder", "", "cluster builder name")
@@ -134,7 +145,7 @@ kp image patch my-image --tag my-registry.com/my-repo --blob https://my-blob-hos
 	return cmd
 }
 
-func patch(ctx context.Context, img *v1alpha2.Image, factory *image.Factory, ch *commands.CommandHelper, cs k8s.ClientSet) (bool, *v1alpha2.Image, error) {
+func patch(ctx context.Context, img *v1alpha2.Image, factory *image.Factory, revision revisionOptions, ch *commands.CommandHelper, cs k8s.ClientSet) (bool, *v1alpha2.Image, error) {
 	if err := ch.PrintStatus("Patching Image Resource..."); err != nil {
 		return false, nil, err
 	}
@@ -144,6 +155,10 @@ func patch(ctx context.Context, img *v1alpha2.Image, factory *image.Factory, ch
 		return false, nil, err
 	}
 
+	if err := revision.resolveRevision(ctx, updatedImage, ch, cs); err != nil {
+		return false, nil, err
+	}
+
 	p, err := k8s.CreatePatch(img, updatedImage)
 	if err != nil {
 		return false, nil, err
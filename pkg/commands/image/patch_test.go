@@ -15,6 +15,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	k8sfakes "k8s.io/client-go/kubernetes/fake"
 
 	cmdFakes "github.com/buildpacks-community/kpack-cli/pkg/commands/fakes"
 	imgcmds "github.com/buildpacks-community/kpack-cli/pkg/commands/image"
@@ -857,5 +858,56 @@ status: {}
 				})
 			})
 		})
+
+		when("the git revision is resolved", func() {
+			k8sCmdFunc := func(k8sClientSet *k8sfakes.Clientset, kpackClientSet *fake.Clientset) *cobra.Command {
+				clientSetProvider := testhelpers.GetFakeProvider(k8sClientSet, kpackClientSet, defaultNamespace)
+				return imageCommand(clientSetProvider, registryUtilProvider, func(set k8s.ClientSet) imgcmds.ImageWaiter {
+					return fakeImageWaiter
+				})
+			}
+
+			it("pins the image to the resolved commit sha", func() {
+				repoPath, commitSha := initGitRepo(t, "some-branch")
+
+				testhelpers.CommandTest{
+					Objects: []runtime.Object{
+						existingImage,
+					},
+					Args: []string{
+						"some-image",
+						"--git", repoPath,
+						"--git-revision", "some-branch",
+						"--pin",
+					},
+					ExpectedOutput: `Patching Image Resource...
+Resolving git revision 'some-branch'...
+Resolved git revision 'some-branch' to '` + commitSha + `'
+Image Resource "some-image" patched
+`,
+					ExpectPatches: []string{
+						`{"spec":{"source":{"git":{"revision":"` + commitSha + `","url":"` + repoPath + `"}}}}`,
+					},
+				}.TestK8sAndKpack(t, k8sCmdFunc)
+			})
+
+			it("fails when the git repository cannot be listed", func() {
+				testhelpers.CommandTest{
+					Objects: []runtime.Object{
+						existingImage,
+					},
+					Args: []string{
+						"some-image",
+						"--git", "/some/missing/repo",
+						"--resolve",
+					},
+					ExpectErr: true,
+					ExpectedOutput: `Patching Image Resource...
+Resolving git revision 'main'...
+`,
+					ExpectedErrorOutput: "Error: failed to list revisions of git repository '/some/missing/repo': repository not found\n",
+				}.TestK8sAndKpack(t, k8sCmdFunc)
+			})
+		})
 	}
 }
@@ -0,0 +1,74 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package image
+
+import (
+	"context"
+
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/commands"
+	"github.com/buildpacks-community/kpack-cli/pkg/git"
+	"github.com/buildpacks-community/kpack-cli/pkg/k8s"
+)
+
+const fullCommitShaLength = 40
+
+// revisionOptions control whether the git revision of an image is checked
+// against the repository before the image is saved.
+type revisionOptions struct {
+	resolve bool
+	pin     bool
+}
+
+func setRevisionFlags(cmd *cobra.Command, opts *revisionOptions) {
+	cmd.Flags().BoolVar(&opts.resolve, "resolve", false, "resolve the git revision to a commit sha before saving and fail if the branch or tag does not exist")
+	cmd.Flags().BoolVar(&opts.pin, "pin", false, "replace the git revision with the resolved commit sha for reproducible builds (implies --resolve)")
+}
+
+// resolveRevision uses the git secrets of the image service account, like the
+// kpack controller, so a revision that cannot be built is reported right away.
+func (o revisionOptions) resolveRevision(ctx context.Context, img *v1alpha2.Image, ch *commands.CommandHelper, cs k8s.ClientSet) error {
+	if !o.resolve && !o.pin {
+		return nil
+	}
+
+	gitSource := img.Spec.Source.Git
+	if gitSource == nil {
+		return errors.New("--resolve and --pin can only be used with a git source")
+	}
+
+	if err := ch.PrintStatus("Resolving git revision '%s'...", gitSource.Revision); err != nil {
+		return err
+	}
+
+	serviceAccount := img.Spec.ServiceAccountName
+	if serviceAccount == "" {
+		serviceAccount = "default"
+	}
+
+	sha, found, err := git.NewResolver(cs.K8sClient).Resolve(ctx, img.Namespace, serviceAccount, gitSource.URL, gitSource.Revision)
+	if err != nil {
+		return err
+	}
+
+	// commits that are not the head of a branch or tag cannot be listed
+	if !found {
+		if o.pin && len(sha) != fullCommitShaLength {
+			return errors.Errorf("git revision '%s' could not be resolved to a full commit sha, use a full commit sha with --pin", gitSource.Revision)
+		}
+		return ch.PrintStatus("Could not check git revision '%s', it is not the commit of a branch or tag", gitSource.Revision)
+	}
+
+	if err := ch.PrintStatus("Resolved git revision '%s' to '%s'", gitSource.Revision, sha); err != nil {
+		return err
+	}
+
+	if o.pin {
+		gitSource.Revision = sha
+	}
+	return nil
+}
@@ -23,6 +23,7 @@ func NewSaveCommand(clientSetProvider k8s.ClientSetProvider, rup registry.UtilPr
 		subPath   string
 		factory   image.Factory
 		tlsCfg    registry.TLSConfig
+		revision  revisionOptions
 	)
 
 	cmd := &cobra.Command{
@@ -42,11 +43,18 @@ The flags for this command determine how the build will retrieve source code:
   "--blob" to use source code hosted in a blob store
   "--local-path" to use source code from the local machine
 
+"--resolve" checks that the git revision exists before the image resource is saved,
+using the git secrets of the service account. "--pin" also replaces the revision with the resolved commit sha.
+
 Local source code will be pushed to the same registry provided for the image resource tag.
 --local-path-destination-image can be used to specify the repository of the source code image.
 If not specified, the source code image will be pushed to the <image-tag-repo>-source repo.
 Therefore, you must have credentials to access the registry on your machine.
 
+Local source code files matching the patterns in a .kpignore file at the root of the local path are not uploaded.
+Without a .kpignore file, the .git directory and files matching .gitignore files are not uploaded.
+Additional patterns may be provided with the "--exclude" flag. Use "--dry-run" to list the files that would be uploaded.
+
 Environment variables may be provided by using the "--env" flag or deleted by using the "--delete-env" flag.
 For each environment variable, supply the "--env" flag followed by the key value pair.
 For example, "--env key1=value1 --env key2=value2 --delete-env key3".
@@ -91,7 +99,7 @@ kp image save my-image --tag my-registry.com/my-repo --blob https://my-blob-host
 				}
 
 				factory.SubPath = &subPath
-				img, err = create(ctx, name, tag, &factory, ch, cs)
+				img, err = create(ctx, name, tag, &factory, revision, ch, cs)
 			} else if err != nil {
 				return err
 			} else {
@@ -100,7 +108,7 @@ kp image save my-image --tag my-registry.com/my-repo --blob https://my-blob-host
 				}
 
 				var patched bool
-				patched, img, err = patch(ctx, img, &factory, ch, cs)
+				patched, img, err = patch(ctx, img, &factory, revision, ch, cs)
 				if !patched {
 					shouldWait = false
 				}
@@ -125,8 +133,10 @@ kp image save my-image --tag my-registry.com/my-repo --blob https://my-blob-host
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "kubernetes namespace")
 	cmd.Flags().StringVar(&factory.GitRepo, "git", "", "git repository url")
 	cmd.Flags().StringVar(&factory.GitRevision, "git-revision", "", "git revision such as commit, tag, or branch (default \"main\")")
+	setRevisionFlags(cmd, &revision)
 	cmd.Flags().StringVar(&factory.Blob, "blob", "", "source code blob url")
 	cmd.Flags().StringVar(&factory.LocalPath, "local-path", "", "path to local source code")
+	cmd.Flags().StringArrayVar(&factory.Excludes, "exclude", []string{}, "gitignore style pattern of local source files to leave out of the upload")
 	cmd.Flags().StringVar(&factory.LocalPathDestinationImage, "local-path-destination-image", "", "registry location of where the local source code will be uploaded to (default \"<image-tag-repo>-source\")")
 	cmd.Flags().StringVar(&subPath, "sub-path", "", "build code at the sub path located within the source code directory")
 	cmd.Flags().StringVar(&factory.CacheSize, "cache-size", "", "cache size as a kubernetes quantity (default \"2G\")")
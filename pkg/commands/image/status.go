@@ -53,17 +53,26 @@ The namespace defaults to the kubernetes current-context namespace.`,
 			}
 
 			sort.Slice(buildList.Items, build.Sort(buildList.Items))
+
+			printer, err := commands.NewDataPrinter(cmd)
+			if err != nil {
+				return err
+			} else if printer != nil {
+				return printer.Print(cmd.OutOrStdout(), newImageStatusOutput(image, buildList.Items))
+			}
+
 			return displayImageStatus(cmd, image, buildList.Items)
 		},
 	}
 
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "kubernetes namespace")
+	commands.SetStatusOutputFlag(cmd)
 
 	return cmd
 }
 
 func displayImageStatus(cmd *cobra.Command, image *v1alpha2.Image, builds []v1alpha2.Build) error {
-	statusWriter := commands.NewStatusWriter(cmd.OutOrStdout())
+	statusWriter := commands.NewCommandStatusWriter(cmd)
 	imgDetails := getImageDetails(image)
 	failedBuild := getLastFailedBuild(builds)
 	successfulBuild := getLastSuccessfulBuild(builds)
@@ -104,7 +113,7 @@ func displayImageStatus(cmd *cobra.Command, image *v1alpha2.Image, builds []v1al
 	}
 
 	if successfulBuild != nil {
-		tableWriter, err := commands.NewTableWriter(cmd.OutOrStdout(), "Buildpack Id", "Buildpack Version", "Homepage")
+		tableWriter, err := commands.NewCommandTableWriter(cmd, "Buildpack Id", "Buildpack Version", "Homepage")
 		if err != nil {
 			return err
 		}
@@ -132,6 +141,75 @@ func displayImageStatus(cmd *cobra.Command, image *v1alpha2.Image, builds []v1al
 	return statusWriter.Write()
 }
 
+type imageStatusOutput struct {
+	Status              string            `json:"status"`
+	Message             string            `json:"message,omitempty"`
+	LatestImage         string            `json:"latestImage"`
+	Source              sourceOutput      `json:"source"`
+	BuilderRef          builderRefOutput  `json:"builderRef"`
+	LastSuccessfulBuild *imageBuildOutput `json:"lastSuccessfulBuild,omitempty"`
+	LastFailedBuild     *imageBuildOutput `json:"lastFailedBuild,omitempty"`
+}
+
+type sourceOutput struct {
+	Type     string `json:"type"`
+	Url      string `json:"url,omitempty"`
+	Revision string `json:"revision,omitempty"`
+}
+
+type builderRefOutput struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+}
+
+type imageBuildOutput struct {
+	Id          string                   `json:"id"`
+	BuildReason string                   `json:"buildReason"`
+	GitRevision string                   `json:"gitRevision,omitempty"`
+	Buildpacks  []commands.BuildpackInfo `json:"buildpacks,omitempty"`
+}
+
+func newImageStatusOutput(image *v1alpha2.Image, builds []v1alpha2.Build) imageStatusOutput {
+	imgDetails := getImageDetails(image)
+	output := imageStatusOutput{
+		Status:      imgDetails.status,
+		Message:     imgDetails.message,
+		LatestImage: imgDetails.latestImage,
+		Source:      sourceOutput{Type: "Local Source"},
+		BuilderRef:  builderRefOutput{Name: image.Spec.Builder.Name, Kind: image.Spec.Builder.Kind},
+	}
+
+	if image.Spec.Source.Git != nil {
+		output.Source = sourceOutput{Type: "GitUrl", Url: image.Spec.Source.Git.URL, Revision: image.Spec.Source.Git.Revision}
+	} else if image.Spec.Source.Blob != nil {
+		output.Source = sourceOutput{Type: "Blob", Url: image.Spec.Source.Blob.URL}
+	}
+
+	if successfulBuild := getLastSuccessfulBuild(builds); successfulBuild != nil {
+		output.LastSuccessfulBuild = newImageBuildOutput(successfulBuild)
+		for _, metadata := range successfulBuild.Status.BuildMetadata {
+			output.LastSuccessfulBuild.Buildpacks = append(output.LastSuccessfulBuild.Buildpacks, commands.BuildpackInfo{Id: metadata.Id, Version: metadata.Version, Homepage: metadata.Homepage})
+		}
+	}
+
+	if failedBuild := getLastFailedBuild(builds); failedBuild != nil {
+		output.LastFailedBuild = newImageBuildOutput(failedBuild)
+	}
+
+	return output
+}
+
+func newImageBuildOutput(build *v1alpha2.Build) *imageBuildOutput {
+	output := &imageBuildOutput{
+		Id:          getId(build),
+		BuildReason: getReason(build),
+	}
+	if build.Spec.Source.Git != nil {
+		output.GitRevision = build.Spec.Source.Git.Revision
+	}
+	return output
+}
+
 func buildStatus(build *v1alpha2.Build) []string {
 	items := []string{
 		"Id", getId(build),
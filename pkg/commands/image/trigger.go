@@ -4,81 +4,196 @@
 package image
 
 import (
+	"context"
 	"fmt"
 	"sort"
-	"strconv"
 	"time"
 
 	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/pivotal/kpack/pkg/client/clientset/versioned"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/buildpacks-community/kpack-cli/pkg/build"
 	"github.com/buildpacks-community/kpack-cli/pkg/commands"
 	"github.com/buildpacks-community/kpack-cli/pkg/k8s"
 )
 
-const BuildNeededAnnotation = "image.kpack.io/additionalBuildNeeded"
+const BuildNeededAnnotation = build.BuildNeededAnnotation
 
 func NewTriggerCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 	var (
-		namespace string
+		namespace     string
+		allNamespaces bool
+		filters       []string
+		selector      string
+		rateLimit     int
 	)
 
 	cmd := &cobra.Command{
-		Use:   "trigger <name>",
-		Short: "Trigger an image resource build",
+		Use:   "trigger [<name>]",
+		Short: "Trigger image resource builds",
 		Long: `Trigger a build using current inputs for a specific image resource in the provided namespace.
 
+Builds can also be triggered for every image resource matching the --filter and --selector flags, for instance
+to rebuild all images using a builder after a CVE. Use --all-namespaces to select image resources across namespaces,
+--rate-limit to spread the builds over time and --dry-run to preview the image resources that would be triggered.
+
 The namespace defaults to the kubernetes current-context namespace.`,
-		Example: "kp image trigger my-image",
-		Args:    commands.ExactArgsWithUsage(1),
+		Example: `kp image trigger my-image
+kp image trigger --filter clusterbuilder=default -A
+kp image trigger --selector team=payments --rate-limit 10 --dry-run`,
+		Args: commands.OptionalArgsWithUsage(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			bulk := allNamespaces || len(filters) > 0 || selector != ""
+			if len(args) == 1 && bulk {
+				return errors.New("image name cannot be used with --all-namespaces, --filter or --selector")
+			} else if len(args) == 0 && len(filters) == 0 && selector == "" {
+				return errors.New("an image name, --filter or --selector must be provided")
+			} else if rateLimit < 0 {
+				return errors.New("rate-limit cannot be negative")
+			}
+
 			cs, err := clientSetProvider.GetClientSet(namespace)
 			if err != nil {
 				return err
 			}
 
-			ctx := cmd.Context()
-
-			buildList, err := cs.KpackClient.KpackV1alpha2().Builds(cs.Namespace).List(ctx, metav1.ListOptions{
-				LabelSelector: v1alpha2.ImageLabel + "=" + args[0],
-			})
+			ch, err := commands.NewCommandHelper(cmd)
 			if err != nil {
 				return err
 			}
 
-			if len(buildList.Items) == 0 {
-				return errors.New("no builds found")
-			} else {
-				sort.Slice(buildList.Items, build.Sort(buildList.Items))
-
-				original := buildList.Items[len(buildList.Items)-1].DeepCopy()
-				patched := original.DeepCopy()
-				patched.Annotations[BuildNeededAnnotation] = time.Now().String()
+			ctx := cmd.Context()
 
-				patch, err := k8s.CreatePatch(original, patched)
-				if err != nil {
-					return err
+			if len(args) == 1 {
+				if ch.IsDryRun() {
+					bld, err := build.Latest(ctx, cs.KpackClient, cs.Namespace, args[0])
+					if err != nil {
+						return err
+					}
+					return ch.PrintResult("Triggered build for Image Resource %q with Build Number %d", args[0], build.NextBuildNumber(bld))
 				}
 
-				_, err = cs.KpackClient.KpackV1alpha2().Builds(cs.Namespace).Patch(ctx, original.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+				buildNumber, err := build.Trigger(ctx, cs.KpackClient, cs.Namespace, args[0])
 				if err != nil {
 					return err
 				}
 
-				previousBuildNumber, _ := strconv.Atoi(patched.Labels[v1alpha2.BuildNumberLabel])
+				_, err = fmt.Fprintf(cmd.OutOrStderr(), "Triggered build for Image Resource %q with Build Number %d\n", args[0], buildNumber)
+				return err
+			}
+
+			imagesNamespace := cs.Namespace
+			if allNamespaces {
+				imagesNamespace = ""
+			}
+
+			imageList, err := cs.KpackClient.KpackV1alpha2().Images(imagesNamespace).List(ctx, metav1.ListOptions{
+				LabelSelector: selector,
+			})
+			if err != nil {
+				return err
+			}
 
-				_, err = fmt.Fprintf(cmd.OutOrStderr(), "Triggered build for Image Resource %q with Build Number %d\n", args[0], previousBuildNumber+1)
+			imageList, err = filterImageList(imageList, filters)
+			if err != nil {
 				return err
 			}
+
+			if len(imageList.Items) == 0 {
+				return errors.New("no image resources found")
+			}
+
+			sort.SliceStable(imageList.Items, func(i, j int) bool {
+				if imageList.Items[i].Namespace != imageList.Items[j].Namespace {
+					return imageList.Items[i].Namespace < imageList.Items[j].Namespace
+				}
+				return imageList.Items[i].Name < imageList.Items[j].Name
+			})
+
+			t := &bulkTrigger{
+				client:   cs.KpackClient,
+				ch:       ch,
+				interval: rateLimitInterval(rateLimit),
+			}
+			return t.triggerAll(ctx, imageList.Items)
 		},
 		SilenceUsage: true,
 	}
 
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "kubernetes namespace")
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "trigger image resources found in all namespaces")
+	cmd.Flags().StringArrayVar(&filters, "filter", nil, filterUsage)
+	cmd.Flags().StringVarP(&selector, "selector", "l", "", "label selector of the image resources to trigger (format: key1=value1,key2!=value2)")
+	cmd.Flags().IntVar(&rateLimit, "rate-limit", 0, "maximum number of builds to trigger per minute, 0 for no limit")
+	cmd.Flags().Bool(commands.DryRunFlag, false, "print the image resources that would be triggered without triggering builds")
 
 	return cmd
 }
+
+func rateLimitInterval(buildsPerMinute int) time.Duration {
+	if buildsPerMinute == 0 {
+		return 0
+	}
+	return time.Minute / time.Duration(buildsPerMinute)
+}
+
+type bulkTrigger struct {
+	client    versioned.Interface
+	ch        *commands.CommandHelper
+	interval  time.Duration
+	triggered int
+}
+
+func (t *bulkTrigger) triggerAll(ctx context.Context, images []v1alpha2.Image) error {
+	var skipped int
+	for _, img := range images {
+		buildNumber, err := t.trigger(ctx, img)
+		if err == build.ErrNoBuilds {
+			skipped++
+			if err := t.ch.Printlnf("Skipping Image Resource %q in namespace %q: %s", img.Name, img.Namespace, err); err != nil {
+				return err
+			}
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		if err := t.ch.PrintResult("Triggered build for Image Resource %q in namespace %q with Build Number %d", img.Name, img.Namespace, buildNumber); err != nil {
+			return err
+		}
+	}
+
+	return t.ch.PrintResult("\nTriggered %d of %d image resources, skipped %d without builds", len(images)-skipped, len(images), skipped)
+}
+
+// trigger triggers a build for the image, waiting for the rate limit interval
+// since the previous triggered build first.
+func (t *bulkTrigger) trigger(ctx context.Context, img v1alpha2.Image) (int, error) {
+	if t.ch.IsDryRun() {
+		bld, err := build.Latest(ctx, t.client, img.Namespace, img.Name)
+		if err != nil {
+			return 0, err
+		}
+
+		return build.NextBuildNumber(bld), nil
+	}
+
+	if t.triggered > 0 && t.interval > 0 {
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-time.After(t.interval):
+		}
+	}
+
+	buildNumber, err := build.Trigger(ctx, t.client, img.Namespace, img.Name)
+	if err != nil {
+		return 0, err
+	}
+
+	t.triggered++
+	return buildNumber, nil
+}
@@ -8,9 +8,14 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	"github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
 	"github.com/sclevine/spec"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/buildpacks-community/kpack-cli/pkg/commands/image"
 	"github.com/buildpacks-community/kpack-cli/pkg/testhelpers"
@@ -118,6 +123,102 @@ func testImageTrigger(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 	})
+
+	when("image resources are selected with filters", func() {
+		makeImage := func(name, namespace, builderKind string, labels map[string]string) *v1alpha2.Image {
+			return &v1alpha2.Image{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+				Spec: v1alpha2.ImageSpec{
+					Builder: corev1.ObjectReference{Kind: builderKind, Name: "default"},
+				},
+			}
+		}
+
+		var objects []runtime.Object
+
+		it.Before(func() {
+			objects = append(testhelpers.BuildsToRuntimeObjs(testBuilds), testhelpers.BuildsToRuntimeObjs(testNamespacedBuilds)...)
+			objects = append(objects,
+				makeImage("some-image", defaultNamespace, v1alpha2.ClusterBuilderKind, map[string]string{"team": "some-team"}),
+				makeImage("some-image", namespace, v1alpha2.ClusterBuilderKind, nil),
+				makeImage("other-image", defaultNamespace, v1alpha2.ClusterBuilderKind, nil),
+				makeImage("builder-image", namespace, v1alpha2.BuilderKind, nil),
+			)
+		})
+
+		it("triggers the latest build of every matching image resource", func() {
+			clientSet := fake.NewSimpleClientset(objects...)
+			cmd := image.NewTriggerCommand(testhelpers.GetFakeKpackProvider(clientSet, defaultNamespace))
+
+			out := &bytes.Buffer{}
+			cmd.SetOut(out)
+			cmd.SetArgs([]string{"--filter", "clusterbuilder=default", "-A", "--rate-limit", "6000"})
+
+			require.NoError(t, cmd.Execute())
+			require.Equal(t, `Skipping Image Resource "other-image" in namespace "some-default-namespace": no builds found
+Triggered build for Image Resource "some-image" in namespace "some-default-namespace" with Build Number 4
+Triggered build for Image Resource "some-image" in namespace "some-namespace" with Build Number 4
+
+Triggered 2 of 3 image resources, skipped 1 without builds
+`, out.String())
+
+			actions, err := testhelpers.ActionRecorderList{clientSet}.ActionsByVerb()
+			require.NoError(t, err)
+			require.Len(t, actions.Patches, 2)
+			require.Equal(t, defaultNamespace, actions.Patches[0].GetNamespace())
+			require.Equal(t, namespace, actions.Patches[1].GetNamespace())
+		})
+
+		it("previews the builds that would be triggered with dry run", func() {
+			clientSet := fake.NewSimpleClientset(objects...)
+			cmd := image.NewTriggerCommand(testhelpers.GetFakeKpackProvider(clientSet, defaultNamespace))
+
+			out := &bytes.Buffer{}
+			cmd.SetOut(out)
+			cmd.SetArgs([]string{"--selector", "team=some-team", "--dry-run"})
+
+			require.NoError(t, cmd.Execute())
+			require.Equal(t, `Triggered build for Image Resource "some-image" in namespace "some-default-namespace" with Build Number 4 (dry run)
+
+Triggered 1 of 1 image resources, skipped 0 without builds (dry run)
+`, out.String())
+
+			actions, err := testhelpers.ActionRecorderList{clientSet}.ActionsByVerb()
+			require.NoError(t, err)
+			require.Empty(t, actions.Patches)
+		})
+
+		it("fails when no image resources match", func() {
+			testhelpers.CommandTest{
+				Objects:             objects,
+				Args:                []string{"--selector", "team=other-team"},
+				ExpectErr:           true,
+				ExpectedErrorOutput: "Error: no image resources found\n",
+			}.TestKpack(t, func(clientSet *fake.Clientset) *cobra.Command {
+				return image.NewTriggerCommand(testhelpers.GetFakeKpackProvider(clientSet, defaultNamespace))
+			})
+		})
+
+		it("fails when an image name is combined with filters", func() {
+			testhelpers.CommandTest{
+				Args:                []string{"some-image", "--filter", "clusterbuilder=default"},
+				ExpectErr:           true,
+				ExpectedErrorOutput: "Error: image name cannot be used with --all-namespaces, --filter or --selector\n",
+			}.TestKpack(t, func(clientSet *fake.Clientset) *cobra.Command {
+				return image.NewTriggerCommand(testhelpers.GetFakeKpackProvider(clientSet, defaultNamespace))
+			})
+		})
+
+		it("fails without an image name or filters", func() {
+			testhelpers.CommandTest{
+				Args:                []string{"-A"},
+				ExpectErr:           true,
+				ExpectedErrorOutput: "Error: an image name, --filter or --selector must be provided\n",
+			}.TestKpack(t, func(clientSet *fake.Clientset) *cobra.Command {
+				return image.NewTriggerCommand(testhelpers.GetFakeKpackProvider(clientSet, defaultNamespace))
+			})
+		})
+	})
 }
 
 type buildNeededPatch struct {
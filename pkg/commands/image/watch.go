@@ -0,0 +1,303 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package image
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/client/informers/externalversions"
+	v1alpha2listers "github.com/pivotal/kpack/pkg/client/listers/build/v1alpha2"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/commands"
+	"github.com/buildpacks-community/kpack-cli/pkg/k8s"
+)
+
+const clearScreen = "\033[H\033[2J"
+
+func NewWatchCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
+	var (
+		namespace     string
+		allNamespaces bool
+		filters       []string
+		interval      time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Watch image resources and their latest builds",
+		Long: `Displays a table of image resources in the provided namespace that refreshes as the images and their builds change.
+
+The table shows the readiness of each image resource and the number, phase and reason of its latest build.
+The command runs until it is interrupted.
+
+The namespace defaults to the kubernetes current-context namespace.`,
+		Example: `kp image watch
+kp image watch -A
+kp image watch -n my-namespace --filter clusterbuilder=default
+kp image watch -A --filter ready=false,unknown`,
+		Args:         commands.ExactArgsWithUsage(0),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cs, err := clientSetProvider.GetClientSet(namespace)
+			if err != nil {
+				return err
+			}
+
+			parsedFilters, err := parseFilters(filters)
+			if err != nil {
+				return err
+			}
+
+			if interval <= 0 {
+				return errors.New("interval must be greater than zero")
+			}
+
+			watchNamespace := cs.Namespace
+			if allNamespaces {
+				watchNamespace = metav1.NamespaceAll
+			}
+
+			factory := externalversions.NewSharedInformerFactoryWithOptions(cs.KpackClient, 0, externalversions.WithNamespace(watchNamespace))
+			dashboard := newImageDashboard(factory, parsedFilters)
+			return dashboard.Run(cmd.Context(), cmd.OutOrStdout(), interval)
+		},
+	}
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "kubernetes namespace")
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "Return objects found in all namespaces")
+	cmd.Flags().StringArrayVar(&filters, "filter", nil, filterUsage)
+	cmd.Flags().DurationVar(&interval, "interval", time.Second, "how often the table is refreshed when images or builds change")
+
+	return cmd
+}
+
+// imageDashboard keeps informer caches of images and builds and redraws a
+// table of them whenever either changes.
+type imageDashboard struct {
+	factory   externalversions.SharedInformerFactory
+	images    v1alpha2listers.ImageLister
+	builds    v1alpha2listers.BuildLister
+	informers []cache.SharedIndexInformer
+	filters   []filter
+	changed   chan struct{}
+}
+
+func newImageDashboard(factory externalversions.SharedInformerFactory, filters []filter) *imageDashboard {
+	imageInformer := factory.Kpack().V1alpha2().Images()
+	buildInformer := factory.Kpack().V1alpha2().Builds()
+
+	return &imageDashboard{
+		factory:   factory,
+		images:    imageInformer.Lister(),
+		builds:    buildInformer.Lister(),
+		informers: []cache.SharedIndexInformer{imageInformer.Informer(), buildInformer.Informer()},
+		filters:   filters,
+		changed:   make(chan struct{}, 1),
+	}
+}
+
+func (d *imageDashboard) Run(ctx context.Context, out io.Writer, interval time.Duration) error {
+	for _, informer := range d.informers {
+		_, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    func(interface{}) { d.notify() },
+			UpdateFunc: func(interface{}, interface{}) { d.notify() },
+			DeleteFunc: func(interface{}) { d.notify() },
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	d.factory.Start(ctx.Done())
+	defer d.factory.Shutdown()
+
+	for informerType, synced := range d.factory.WaitForCacheSync(ctx.Done()) {
+		if !synced {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return errors.Errorf("failed to sync %s", informerType)
+		}
+	}
+
+	if err := d.Render(out); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	dirty := false
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-d.changed:
+			dirty = true
+		case <-ticker.C:
+			if !dirty {
+				continue
+			}
+			dirty = false
+			if err := d.Render(out); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (d *imageDashboard) notify() {
+	select {
+	case d.changed <- struct{}{}:
+	default:
+	}
+}
+
+type watchRow struct {
+	name        string
+	ready       string
+	latestBuild string
+	phase       string
+	reason      string
+	namespace   string
+}
+
+// Render clears the terminal and writes a summary line and a table of the
+// images that match the filters.
+func (d *imageDashboard) Render(out io.Writer) error {
+	rows, err := d.rows()
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprint(out, clearScreen); err != nil {
+		return err
+	}
+
+	counts := map[string]int{}
+	building := 0
+	for _, row := range rows {
+		counts[row.ready]++
+		if strings.HasPrefix(row.phase, "BUILDING") {
+			building++
+		}
+	}
+
+	_, err = fmt.Fprintf(out, "Images: %d    Ready: %d    Not Ready: %d    Unknown: %d    Building: %d\n\n",
+		len(rows), counts["True"], counts["False"], counts["Unknown"], building)
+	if err != nil {
+		return err
+	}
+
+	if len(rows) == 0 {
+		_, err := fmt.Fprintln(out, "no image resources found")
+		return err
+	}
+
+	writer, err := commands.NewTableWriter(out, "NAME", "READY", "LATEST BUILD", "PHASE", "REASON", "NAMESPACE")
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		err := writer.AddRow(row.name, row.ready, row.latestBuild, row.phase, row.reason, row.namespace)
+		if err != nil {
+			return err
+		}
+	}
+
+	return writer.Write()
+}
+
+func (d *imageDashboard) rows() ([]watchRow, error) {
+	images, err := d.images.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	builds, err := d.builds.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	latestBuilds := map[string]*v1alpha2.Build{}
+	for _, bld := range builds {
+		key := bld.Namespace + "/" + bld.Labels[v1alpha2.ImageLabel]
+		if latest, ok := latestBuilds[key]; !ok || buildNumber(bld) > buildNumber(latest) {
+			latestBuilds[key] = bld
+		}
+	}
+
+	sort.Slice(images, func(i, j int) bool {
+		if images[i].Namespace != images[j].Namespace {
+			return images[i].Namespace < images[j].Namespace
+		}
+		return images[i].Name < images[j].Name
+	})
+
+	var rows []watchRow
+	for _, img := range images {
+		if !matchesAll(*img, d.filters) {
+			continue
+		}
+
+		row := watchRow{
+			name:      img.Name,
+			ready:     getReadyText(*img),
+			reason:    img.Status.LatestBuildReason,
+			namespace: img.Namespace,
+		}
+
+		if bld, ok := latestBuilds[img.Namespace+"/"+img.Name]; ok {
+			row.latestBuild = bld.Labels[v1alpha2.BuildNumberLabel]
+			row.phase = getBuildPhase(bld)
+			if reason := bld.Annotations[v1alpha2.BuildReasonAnnotation]; reason != "" {
+				row.reason = reason
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func buildNumber(bld *v1alpha2.Build) int {
+	number, err := strconv.Atoi(bld.Labels[v1alpha2.BuildNumberLabel])
+	if err != nil {
+		return 0
+	}
+	return number
+}
+
+// getBuildPhase includes the number of completed steps for running builds so a
+// rebase or rebuild can be followed without tailing logs.
+func getBuildPhase(bld *v1alpha2.Build) string {
+	cond := bld.Status.GetCondition(corev1alpha1.ConditionSucceeded)
+	switch {
+	case cond.IsTrue():
+		return "SUCCESS"
+	case cond.IsFalse():
+		return "FAILURE"
+	case cond.IsUnknown():
+		if len(bld.Status.StepStates) == 0 {
+			return "BUILDING"
+		}
+		return fmt.Sprintf("BUILDING (%d/%d)", len(bld.Status.StepsCompleted), len(bld.Status.StepStates))
+	default:
+		return "UNKNOWN"
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package image_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
+	"github.com/sclevine/spec"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/commands/image"
+	"github.com/buildpacks-community/kpack-cli/pkg/testhelpers"
+)
+
+func TestImageWatchCommand(t *testing.T) {
+	spec.Run(t, "TestImageWatchCommand", testImageWatchCommand)
+}
+
+func testImageWatchCommand(t *testing.T, when spec.G, it spec.S) {
+	const defaultNamespace = "some-default-namespace"
+
+	readyImage := &v1alpha2.Image{
+		ObjectMeta: metav1.ObjectMeta{Name: "image-a", Namespace: defaultNamespace},
+		Status: v1alpha2.ImageStatus{
+			LatestBuildReason: "CONFIG",
+			Status: corev1alpha1.Status{
+				Conditions: []corev1alpha1.Condition{{Type: corev1alpha1.ConditionReady, Status: corev1.ConditionTrue}},
+			},
+		},
+	}
+	rebasingImage := &v1alpha2.Image{
+		ObjectMeta: metav1.ObjectMeta{Name: "image-b", Namespace: defaultNamespace},
+		Spec: v1alpha2.ImageSpec{
+			Builder: corev1.ObjectReference{Name: "some-builder", Kind: v1alpha2.ClusterBuilderKind},
+		},
+		Status: v1alpha2.ImageStatus{
+			Status: corev1alpha1.Status{
+				Conditions: []corev1alpha1.Condition{{Type: corev1alpha1.ConditionReady, Status: corev1.ConditionUnknown}},
+			},
+		},
+	}
+	otherNamespaceImage := &v1alpha2.Image{
+		ObjectMeta: metav1.ObjectMeta{Name: "image-c", Namespace: "other-namespace"},
+	}
+
+	makeBuild := func(img *v1alpha2.Image, number string, reason string, status corev1.ConditionStatus) *v1alpha2.Build {
+		return &v1alpha2.Build{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      img.Name + "-build-" + number,
+				Namespace: img.Namespace,
+				Labels: map[string]string{
+					v1alpha2.ImageLabel:       img.Name,
+					v1alpha2.BuildNumberLabel: number,
+				},
+				Annotations: map[string]string{v1alpha2.BuildReasonAnnotation: reason},
+			},
+			Status: v1alpha2.BuildStatus{
+				Status: corev1alpha1.Status{
+					Conditions: []corev1alpha1.Condition{{Type: corev1alpha1.ConditionSucceeded, Status: status}},
+				},
+			},
+		}
+	}
+
+	rebaseBuild := makeBuild(rebasingImage, "2", "STACK", corev1.ConditionUnknown)
+	rebaseBuild.Status.StepStates = make([]corev1.ContainerState, 3)
+	rebaseBuild.Status.StepsCompleted = []string{"prepare"}
+
+	objects := []runtime.Object{
+		readyImage,
+		rebasingImage,
+		otherNamespaceImage,
+		makeBuild(readyImage, "1", "CONFIG", corev1.ConditionTrue),
+		makeBuild(rebasingImage, "1", "CONFIG", corev1.ConditionTrue),
+		rebaseBuild,
+	}
+
+	runWatch := func(client *fake.Clientset, args ...string) (*syncBuffer, func()) {
+		cmd := image.NewWatchCommand(testhelpers.GetFakeKpackProvider(client, defaultNamespace))
+		out := &syncBuffer{}
+		cmd.SetOut(out)
+		cmd.SetErr(out)
+		cmd.SetArgs(append(args, "--interval", "10ms"))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		errs := make(chan error, 1)
+		go func() { errs <- cmd.ExecuteContext(ctx) }()
+
+		return out, func() {
+			cancel()
+			require.NoError(t, <-errs)
+		}
+	}
+
+	latestTable := func(out *syncBuffer) string {
+		frames := strings.Split(out.String(), "\033[H\033[2J")
+		return frames[len(frames)-1]
+	}
+
+	it("displays the images in the namespace with their latest build", func() {
+		client := fake.NewSimpleClientset(objects...)
+		out, stop := runWatch(client)
+		defer stop()
+
+		expected := `Images: 2    Ready: 1    Not Ready: 0    Unknown: 1    Building: 1
+
+NAME       READY      LATEST BUILD    PHASE             REASON    NAMESPACE
+image-a    True       1               SUCCESS           CONFIG    some-default-namespace
+image-b    Unknown    2               BUILDING (1/3)    STACK     some-default-namespace
+
+`
+		require.Eventually(t, func() bool { return latestTable(out) == expected }, 5*time.Second, 10*time.Millisecond)
+	})
+
+	it("refreshes the table when a build changes", func() {
+		client := fake.NewSimpleClientset(objects...)
+		out, stop := runWatch(client)
+		defer stop()
+
+		require.Eventually(t, func() bool { return strings.Contains(out.String(), "BUILDING (1/3)") }, 5*time.Second, 10*time.Millisecond)
+
+		require.Eventually(t, func() bool {
+			// updates can be missed by the fake clientset until its watch is established, so keep sending them
+			finished := rebaseBuild.DeepCopy()
+			finished.Status.Conditions[0].Status = corev1.ConditionTrue
+			finished.Annotations["attempt"] = time.Now().String()
+			if _, err := client.KpackV1alpha2().Builds(defaultNamespace).Update(context.Background(), finished, metav1.UpdateOptions{}); err != nil {
+				return false
+			}
+
+			return strings.Contains(latestTable(out), "image-b    Unknown    2               SUCCESS    STACK")
+		}, 5*time.Second, 50*time.Millisecond)
+	})
+
+	it("displays images in all namespaces that match the filters", func() {
+		client := fake.NewSimpleClientset(objects...)
+		out, stop := runWatch(client, "-A", "--filter", "ready=unknown")
+		defer stop()
+
+		expected := `Images: 2    Ready: 0    Not Ready: 0    Unknown: 2    Building: 1
+
+NAME       READY      LATEST BUILD    PHASE             REASON    NAMESPACE
+image-c    Unknown                                                other-namespace
+image-b    Unknown    2               BUILDING (1/3)    STACK     some-default-namespace
+
+`
+		require.Eventually(t, func() bool { return latestTable(out) == expected }, 5*time.Second, 10*time.Millisecond)
+	})
+
+	it("fails for an invalid filter", func() {
+		testhelpers.CommandTest{
+			Args:                []string{"--filter", "color=blue"},
+			ExpectErr:           true,
+			ExpectedErrorOutput: "Error: invalid filter argument \"color=blue\"\n",
+		}.TestKpack(t, func(clientSet *fake.Clientset) *cobra.Command {
+			return image.NewWatchCommand(testhelpers.GetFakeKpackProvider(clientSet, defaultNamespace))
+		})
+	})
+}
+
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
@@ -0,0 +1,69 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package _import
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/commands"
+	"github.com/buildpacks-community/kpack-cli/pkg/dockercreds"
+	importpkg "github.com/buildpacks-community/kpack-cli/pkg/import"
+	"github.com/buildpacks-community/kpack-cli/pkg/registry"
+)
+
+func NewExportCommand(rup registry.UtilProvider) *cobra.Command {
+	var (
+		filename  string
+		bundle    string
+		tlsConfig registry.TLSConfig
+		platforms []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export -f <filename> --bundle <bundle>",
+		Short: "Export dependencies to an offline bundle",
+		Long: `Export the dependency descriptor and every image it references to a single bundle file.
+
+The bundle can be moved to an environment without access to the source registries and imported with "kp import --from-bundle".
+Images are stored in the OCI image layout format. Multi-platform images are exported with all platforms unless --platform is set.
+Cosign signatures stored next to the images are included so the bundle can be imported with --verify-key.
+Signatures of multi-platform images exported with --platform no longer match and cannot be verified.
+
+Env vars can be used for registry auth as described in https://github.com/buildpacks-community/kpack-cli/blob/main/docs/auth.md`,
+		Example: `kp import export -f dependencies.yaml --bundle dependencies.tar
+cat dependencies.yaml | kp import export -f - --bundle dependencies.tar`,
+		Args:         commands.ExactArgsWithUsage(0),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ch, err := commands.NewCommandHelper(cmd)
+			if err != nil {
+				return err
+			}
+
+			fetcher, err := registry.NewPlatformFilteringFetcher(rup.Fetcher(tlsConfig), platforms)
+			if err != nil {
+				return err
+			}
+
+			rawDescriptor, err := readDescriptor(cmd, filename)
+			if err != nil {
+				return err
+			}
+
+			writer := importpkg.NewBundleWriter(ch, fetcher)
+			if err := writer.WriteBundle(dockercreds.DefaultKeychain, rawDescriptor, bundle); err != nil {
+				return err
+			}
+
+			return ch.PrintResult("Exported bundle '%s'", bundle)
+		},
+	}
+	cmd.Flags().StringVarP(&filename, "filename", "f", "", "dependency descriptor filename")
+	cmd.Flags().StringVar(&bundle, "bundle", "", "path of the bundle file to write")
+	commands.SetTLSFlags(cmd, &tlsConfig)
+	commands.SetPlatformFlags(cmd, &platforms)
+	_ = cmd.MarkFlagRequired("filename")
+	_ = cmd.MarkFlagRequired("bundle")
+	return cmd
+}
@@ -0,0 +1,149 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package _import_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	kpackfakes "github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
+	"github.com/sclevine/spec"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+
+	importcmds "github.com/buildpacks-community/kpack-cli/pkg/commands/import"
+	importpkg "github.com/buildpacks-community/kpack-cli/pkg/import"
+	"github.com/buildpacks-community/kpack-cli/pkg/registry"
+	registryfakes "github.com/buildpacks-community/kpack-cli/pkg/registry/fakes"
+	"github.com/buildpacks-community/kpack-cli/pkg/testhelpers"
+)
+
+func TestExportCommand(t *testing.T) {
+	spec.Run(t, "TestExportCommand", testExportCommand)
+}
+
+func testExportCommand(t *testing.T, when spec.G, it spec.S) {
+	const descriptor = `apiVersion: kp.kpack.io/v1
+kind: DependencyDescriptor
+clusterStores:
+- name: some-store
+  sources:
+  - image: some-registry.io/repo/buildpack
+clusterStacks:
+- name: some-stack
+  buildImage:
+    image: some-registry.io/repo/build
+  runImage:
+    image: some-registry.io/repo/run
+`
+
+	var (
+		fetcher        *registryfakes.Fetcher
+		descriptorPath string
+		bundlePath     string
+	)
+
+	it.Before(func() {
+		fetcher = &registryfakes.Fetcher{}
+		for _, ref := range []string{
+			"some-registry.io/repo/buildpack",
+			"some-registry.io/repo/build",
+			"some-registry.io/repo/run",
+		} {
+			img, err := random.Image(10, 1)
+			require.NoError(t, err)
+			fetcher.AddImage(ref, img)
+		}
+
+		dir := t.TempDir()
+		descriptorPath = filepath.Join(dir, "deps.yaml")
+		bundlePath = filepath.Join(dir, "bundle.tar")
+		require.NoError(t, os.WriteFile(descriptorPath, []byte(descriptor), 0644))
+	})
+
+	cmdFunc := func(*kpackfakes.Clientset) *cobra.Command {
+		return importcmds.NewExportCommand(registryfakes.UtilProvider{FakeFetcher: fetcher})
+	}
+
+	it("exports the descriptor and its images to a bundle", func() {
+		testhelpers.CommandTest{
+			Args: []string{"-f", descriptorPath, "--bundle", bundlePath},
+			ExpectedOutput: `Exporting 'some-registry.io/repo/buildpack'...
+Exporting 'some-registry.io/repo/build'...
+Exporting 'some-registry.io/repo/run'...
+Exported bundle '` + bundlePath + `'
+`,
+		}.TestKpack(t, cmdFunc)
+
+		bundle, err := importpkg.ReadBundle(bundlePath)
+		require.NoError(t, err)
+		defer bundle.Close()
+
+		require.Equal(t, descriptor, bundle.RawDescriptor)
+
+		_, err = bundle.Fetcher.Fetch(authn.DefaultKeychain, "some-registry.io/repo/run")
+		require.NoError(t, err)
+	})
+
+	it("fails when an image cannot be fetched", func() {
+		fetcher = &registryfakes.Fetcher{}
+
+		testhelpers.CommandTest{
+			Args:      []string{"-f", descriptorPath, "--bundle", bundlePath},
+			ExpectErr: true,
+			ExpectedOutput: `Exporting 'some-registry.io/repo/buildpack'...
+`,
+			ExpectedErrorOutput: "Error: image not found: \"some-registry.io/repo/buildpack\"\n",
+		}.TestKpack(t, cmdFunc)
+	})
+
+	it("fails when a signature cannot be fetched", func() {
+		cmdFunc := func(*kpackfakes.Clientset) *cobra.Command {
+			return importcmds.NewExportCommand(registryfakes.UtilProvider{FakeFetcher: signatureErrorFetcher{fetcher}})
+		}
+
+		testhelpers.CommandTest{
+			Args:      []string{"-f", descriptorPath, "--bundle", bundlePath},
+			ExpectErr: true,
+			ExpectedOutput: `Exporting 'some-registry.io/repo/buildpack'...
+`,
+			ExpectedErrorOutput: "Error: fetching signature 'some-registry.io/repo/buildpack:sha256-" + digestHex(t, fetcher, "some-registry.io/repo/buildpack") + ".sig': unauthorized\n",
+		}.TestKpack(t, cmdFunc)
+	})
+
+	it("requires a bundle path", func() {
+		testhelpers.CommandTest{
+			Args:                []string{"-f", descriptorPath},
+			ExpectErr:           true,
+			ExpectedErrorOutput: "Error: required flag(s) \"bundle\" not set\n",
+		}.TestKpack(t, cmdFunc)
+	})
+}
+
+// signatureErrorFetcher fails to fetch cosign signatures as with invalid
+// credentials.
+type signatureErrorFetcher struct {
+	registry.Fetcher
+}
+
+func (f signatureErrorFetcher) Fetch(keychain authn.Keychain, src string) (v1.Image, error) {
+	if strings.HasSuffix(src, ".sig") {
+		return nil, errors.New("unauthorized")
+	}
+	return f.Fetcher.Fetch(keychain, src)
+}
+
+func digestHex(t *testing.T, fetcher registry.Fetcher, ref string) string {
+	img, err := fetcher.Fetch(authn.DefaultKeychain, ref)
+	require.NoError(t, err)
+	digest, err := img.Digest()
+	require.NoError(t, err)
+	return digest.Hex
+}
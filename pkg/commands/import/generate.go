@@ -0,0 +1,75 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package _import
+
+import (
+	"os"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/commands"
+	importpkg "github.com/buildpacks-community/kpack-cli/pkg/import"
+	"github.com/buildpacks-community/kpack-cli/pkg/k8s"
+)
+
+func NewGenerateCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
+	var (
+		filename        string
+		useSourceImages bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "generate [-f <filename>]",
+		Short: "Generate a dependency descriptor from the cluster",
+		Long: `Generate a dependency descriptor of the clusterlifecycles, clusterbuildpacks, clusterstores, clusterstacks, and clusterbuilders in the cluster.
+
+The descriptor can be used with "kp import" to recreate or update the resources, including resources that were not created by an import.
+The "default" clusterstack and clusterbuilder and the "default-lifecycle" clusterlifecycle are written as the default of the resource they are a copy of.
+
+Images are referenced as they are in the cluster. Use --source-images to reference the images the resources were imported from instead,
+for resources where a previous import recorded them.
+
+The descriptor is written to stdout unless --filename is set.`,
+		Example: `kp import generate
+kp import generate -f dependencies.yaml --source-images`,
+		Args:         commands.ExactArgsWithUsage(0),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cs, err := clientSetProvider.GetClientSet("")
+			if err != nil {
+				return err
+			}
+
+			ch, err := commands.NewCommandHelper(cmd)
+			if err != nil {
+				return err
+			}
+
+			descriptor, err := importpkg.NewGenerator(cs.KpackClient).Generate(cmd.Context(), useSourceImages)
+			if err != nil {
+				return err
+			}
+
+			data, err := yaml.Marshal(descriptor)
+			if err != nil {
+				return err
+			}
+
+			if filename == "" {
+				_, err = cmd.OutOrStdout().Write(data)
+				return err
+			}
+
+			if err := os.WriteFile(filename, data, 0644); err != nil {
+				return err
+			}
+
+			return ch.PrintResult("Generated dependency descriptor '%s'", filename)
+		},
+	}
+	cmd.Flags().StringVarP(&filename, "filename", "f", "", "dependency descriptor filename to write, defaults to stdout")
+	cmd.Flags().BoolVar(&useSourceImages, "source-images", false, "reference the images resources were imported from where they are recorded")
+	return cmd
+}
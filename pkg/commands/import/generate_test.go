@@ -0,0 +1,82 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package _import_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	kpackfakes "github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
+	"github.com/sclevine/spec"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	importcmds "github.com/buildpacks-community/kpack-cli/pkg/commands/import"
+	"github.com/buildpacks-community/kpack-cli/pkg/testhelpers"
+)
+
+func TestGenerateCommand(t *testing.T) {
+	spec.Run(t, "TestGenerateCommand", testGenerateCommand)
+}
+
+func testGenerateCommand(t *testing.T, when spec.G, it spec.S) {
+	cmdFunc := func(clientSet *kpackfakes.Clientset) *cobra.Command {
+		return importcmds.NewGenerateCommand(testhelpers.GetFakeKpackClusterProvider(clientSet))
+	}
+
+	buildpack := &v1alpha2.ClusterBuildpack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "some-buildpack",
+			Annotations: map[string]string{"kpack.io/import-source": `{"name":"some-buildpack","image":"source.io/buildpack:1"}`},
+		},
+		Spec: v1alpha2.ClusterBuildpackSpec{
+			ImageSource: corev1alpha1.ImageSource{Image: "relocated.io/buildpack:1"},
+		},
+	}
+
+	it("writes the descriptor to stdout", func() {
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{buildpack},
+			ExpectedOutput: `apiVersion: kp.kpack.io/v1
+clusterBuildpacks:
+- image: relocated.io/buildpack:1
+  name: some-buildpack
+kind: DependencyDescriptor
+`,
+		}.TestKpack(t, cmdFunc)
+	})
+
+	it("references recorded source images with --source-images", func() {
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{buildpack},
+			Args:    []string{"--source-images"},
+			ExpectedOutput: `apiVersion: kp.kpack.io/v1
+clusterBuildpacks:
+- image: source.io/buildpack:1
+  name: some-buildpack
+kind: DependencyDescriptor
+`,
+		}.TestKpack(t, cmdFunc)
+	})
+
+	it("writes the descriptor to a file with --filename", func() {
+		filename := filepath.Join(t.TempDir(), "deps.yaml")
+
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{buildpack},
+			Args:    []string{"-f", filename},
+			ExpectedOutput: `Generated dependency descriptor '` + filename + `'
+`,
+		}.TestKpack(t, cmdFunc)
+
+		data, err := os.ReadFile(filename)
+		require.NoError(t, err)
+		require.Contains(t, string(data), "image: relocated.io/buildpack:1")
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"io"
 	"os"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/dynamic"
@@ -14,6 +15,7 @@ import (
 	"github.com/buildpacks-community/kpack-cli/pkg/commands"
 	"github.com/buildpacks-community/kpack-cli/pkg/config"
 	"github.com/buildpacks-community/kpack-cli/pkg/dockercreds"
+	"github.com/buildpacks-community/kpack-cli/pkg/impact"
 	importpkg "github.com/buildpacks-community/kpack-cli/pkg/import"
 	"github.com/buildpacks-community/kpack-cli/pkg/k8s"
 	"github.com/buildpacks-community/kpack-cli/pkg/registry"
@@ -33,9 +35,15 @@ func NewImportCommand(
 
 	var (
 		filename    string
+		bundle      string
 		showChanges bool
+		showImpact  bool
+		prune       bool
+		parallelism int
 		force       bool
 		tlsConfig   registry.TLSConfig
+		platforms   []string
+		verifyCfg   registry.VerificationConfig
 	)
 
 	const (
@@ -49,17 +57,48 @@ func NewImportCommand(
 	}
 
 	cmd := &cobra.Command{
-		Use:   "import -f <filename>",
+		Use:   "import -f <filename> | --from-bundle <bundle>",
 		Short: "Import dependencies for stores, stacks, and cluster builders",
 		Long: `This operation will create or update clusterstores, clusterstacks, and clusterbuilders defined in the dependency descriptor.
 
 kp import will always attempt to upload the stack, store, and builder images, even if the resources have not changed.
 This can be used as a way to repair resources when registry images have been unexpectedly removed.
 
+Use --impact with --show-changes to also list the images that would rebase or rebuild because of changed clusterstacks,
+clusterstores, clusterbuildpacks, or buildpacks, with their count per namespace.
+
+Use --prune to delete clusterlifecycles, clusterbuildpacks, buildpacks, clusterstores, clusterstacks, clusterbuilders, and builders created by a previous import that are no longer in the dependency descriptor.
+Resources still referenced by a builder or image are never pruned.
+
+Use --from-bundle to import a bundle created with "kp import export" without access to the source registries.
+The descriptor stored in the bundle is used unless --filename is also provided.
+
+The descriptor entry of each imported resource is recorded on it so that "kp import generate --source-images" can reference the source images.
+
+Use --verify-key to only import images with a cosign signature made with the matching private key.
+Images that fail verification stop the import; with --verify-policy skip, the resources using them are left out.
+The public key and policy can also be set in the verification section of a v2 descriptor, the flags take precedence.
+Builders that depend on a skipped resource are skipped as well. Verification cannot be combined with --platform.
+
 Env vars can be used for registry auth as described in https://github.com/buildpacks-community/kpack-cli/blob/main/docs/auth.md`,
 		Example: `kp import -f dependencies.yaml
-cat dependencies.yaml | kp import -f -`,
+cat dependencies.yaml | kp import -f -
+kp import -f dependencies.yaml --prune --show-changes
+kp import -f dependencies.yaml --show-changes --impact
+kp import --from-bundle dependencies.tar`,
 		SilenceUsage: true,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if filename == "" && bundle == "" {
+				return errors.New(`required flag(s) "filename" or "from-bundle" not set`)
+			}
+			if parallelism < 1 {
+				return errors.New("parallelism must be at least 1")
+			}
+			if showImpact && !showChanges {
+				return errors.New("--impact requires --show-changes")
+			}
+			return nil
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cs, err := clientSetProvider.GetClientSet("")
 			if err != nil {
@@ -75,7 +114,35 @@ cat dependencies.yaml | kp import -f -`,
 
 			kpConfig := config.NewKpConfigProvider(cs.K8sClient).GetKpConfig(ctx)
 
-			imgFetcher := rup.Fetcher(tlsConfig)
+			var rawDescriptor string
+			srcFetcher := rup.Fetcher(tlsConfig)
+			if bundle != "" {
+				b, err := importpkg.ReadBundle(bundle)
+				if err != nil {
+					return err
+				}
+				defer b.Close()
+
+				srcFetcher = b.Fetcher
+				rawDescriptor = b.RawDescriptor
+			}
+
+			if filename != "" {
+				rawDescriptor, err = readDescriptor(cmd, filename)
+				if err != nil {
+					return err
+				}
+			}
+
+			descriptor, err := importpkg.ReadDescriptor(rawDescriptor)
+			if err != nil {
+				return err
+			}
+
+			imgFetcher, err := registry.NewSourceFetcher(srcFetcher, platforms, importpkg.GetVerificationConfig(descriptor, verifyCfg))
+			if err != nil {
+				return err
+			}
 			imgRelocator := rup.Relocator(ch.Writer(), tlsConfig, ch.CanChangeState())
 
 			importer := importpkg.NewImporter(
@@ -86,22 +153,25 @@ cat dependencies.yaml | kp import -f -`,
 				imgRelocator,
 				newWaiter(cs.DynamicClient),
 				timestampProvider,
+				parallelism,
 			)
 
-			rawDescriptor, err := readDescriptor(cmd, filename)
-			if err != nil {
-				return err
-			}
+			keychain := dockercreds.DefaultKeychain
 
-			descriptor, err := importer.ReadDescriptor(rawDescriptor)
-			if err != nil {
-				return err
-			}
+			var pruneCandidates []importpkg.PruneCandidate
+			if prune {
+				pruneCandidates, err = importer.FindPruneCandidates(ctx, descriptor)
+				if err != nil {
+					return err
+				}
 
-			keychain := dockercreds.DefaultKeychain
+				if err = importer.ValidatePrune(ctx, descriptor, pruneCandidates); err != nil {
+					return err
+				}
+			}
 
 			if showChanges {
-				hasChanges, summary, err := importpkg.SummarizeChange(ctx, keychain, descriptor, kpConfig, importpkg.NewDefaultRelocatedImageProvider(imgFetcher), differ, cs)
+				hasChanges, summary, err := importpkg.SummarizeChange(ctx, keychain, descriptor, kpConfig, importpkg.NewDefaultRelocatedImageProvider(imgFetcher), differ, cs, pruneCandidates)
 				if err != nil {
 					return err
 				}
@@ -111,6 +181,22 @@ cat dependencies.yaml | kp import -f -`,
 					return err
 				}
 
+				if showImpact {
+					changes, err := importpkg.ImpactChanges(ctx, keychain, descriptor, kpConfig, importpkg.NewDefaultRelocatedImageProvider(imgFetcher), cs.KpackClient)
+					if err != nil {
+						return err
+					}
+
+					images, err := impact.Analyze(ctx, cs.KpackClient, changes)
+					if err != nil {
+						return err
+					}
+
+					if err = impact.Write(ch.Writer(), images); err != nil {
+						return err
+					}
+				}
+
 				if !force {
 					confirmed, err := confirmationProvider.Confirm(confirmMsgMap[hasChanges])
 					if err != nil {
@@ -121,6 +207,24 @@ cat dependencies.yaml | kp import -f -`,
 						return ch.Printlnf("Skipping import")
 					}
 				}
+			} else if len(pruneCandidates) > 0 && !force && !ch.IsDryRun() {
+				if err = ch.Printlnf("Resources to prune\n"); err != nil {
+					return err
+				}
+				for _, candidate := range pruneCandidates {
+					if err = ch.Printlnf("%s '%s'", candidate.Kind, candidate.FullName()); err != nil {
+						return err
+					}
+				}
+
+				confirmed, err := confirmationProvider.Confirm(confirmMessage)
+				if err != nil {
+					return err
+				}
+
+				if !confirmed {
+					return ch.Printlnf("Skipping import")
+				}
 			}
 
 			var objs []runtime.Object
@@ -134,6 +238,10 @@ cat dependencies.yaml | kp import -f -`,
 				if err != nil {
 					return err
 				}
+
+				if err = importer.PruneDryRun(pruneCandidates); err != nil {
+					return err
+				}
 			} else {
 				objs, err = importer.ImportDescriptor(
 					ctx,
@@ -144,6 +252,10 @@ cat dependencies.yaml | kp import -f -`,
 				if err != nil {
 					return err
 				}
+
+				if err = importer.Prune(ctx, pruneCandidates); err != nil {
+					return err
+				}
 			}
 
 			if err := ch.PrintObjs(objs); err != nil {
@@ -154,11 +266,16 @@ cat dependencies.yaml | kp import -f -`,
 		},
 	}
 	cmd.Flags().StringVarP(&filename, "filename", "f", "", "dependency descriptor filename")
+	cmd.Flags().StringVar(&bundle, "from-bundle", "", "bundle created with \"kp import export\" to import from")
 	cmd.Flags().BoolVar(&showChanges, "show-changes", false, "show a summary of resource changes before importing")
-	cmd.Flags().BoolVar(&force, "force", false, "import without confirmation when showing changes")
+	cmd.Flags().BoolVar(&showImpact, "impact", false, "with --show-changes, also list the images that would rebase or rebuild")
+	cmd.Flags().BoolVar(&prune, "prune", false, "delete resources created by a previous import that are no longer in the dependency descriptor")
+	cmd.Flags().BoolVar(&force, "force", false, "import without confirmation when showing changes or pruning")
+	cmd.Flags().IntVar(&parallelism, "parallelism", 4, "number of clusterstores, clusterstacks, clusterbuildpacks, and clusterlifecycles to upload at the same time")
 	commands.SetImgUploadDryRunOutputFlags(cmd)
 	commands.SetTLSFlags(cmd, &tlsConfig)
-	_ = cmd.MarkFlagRequired("filename")
+	commands.SetPlatformFlags(cmd, &platforms)
+	commands.SetVerificationFlags(cmd, &verifyCfg)
 	return cmd
 }
 
@@ -4,7 +4,10 @@
 package _import_test
 
 import (
+	"fmt"
 	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/buildpacks-community/kpack-cli/pkg/commands"
@@ -12,6 +15,7 @@ import (
 	importcmds "github.com/buildpacks-community/kpack-cli/pkg/commands/import"
 	registryfakes "github.com/buildpacks-community/kpack-cli/pkg/registry/fakes"
 	"github.com/buildpacks-community/kpack-cli/pkg/testhelpers"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
 	kpackfakes "github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
@@ -33,6 +37,7 @@ func testImportCommand(t *testing.T, when spec.G, it spec.S) {
 	const (
 		lifecycleImageKey  = "image"
 		importTimestampKey = "kpack.io/import-timestamp"
+		importSourceKey    = "kpack.io/import-source"
 	)
 
 	fakeFetcher := &registryfakes.Fetcher{}
@@ -141,6 +146,7 @@ func testImportCommand(t *testing.T, when spec.G, it spec.S) {
 			Name: "my-buildpack",
 			Annotations: map[string]string{
 				importTimestampKey: timestampProvider.timestamp,
+				importSourceKey:    `{"name":"my-buildpack","image":"some-registry.io/repo/standalone-buildpack"}`,
 				"kubectl.kubernetes.io/last-applied-configuration": `{"kind":"ClusterBuildpack","apiVersion":"kpack.io/v1alpha2","metadata":{"name":"my-buildpack","creationTimestamp":null},"spec":{"image":"default-registry.io/default-repo@sha256:standalone-buildpack-digest","serviceAccountRef":{"namespace":"some-namespace","name":"some-serviceaccount"}},"status":{}}`,
 			},
 		},
@@ -165,6 +171,7 @@ func testImportCommand(t *testing.T, when spec.G, it spec.S) {
 			Annotations: map[string]string{
 				"kubectl.kubernetes.io/last-applied-configuration": `{"kind":"ClusterStore","apiVersion":"kpack.io/v1alpha2","metadata":{"name":"store-name","creationTimestamp":null},"spec":{"sources":[{"image":"default-registry.io/default-repo@sha256:buildpack-image-digest"}],"serviceAccountRef":{"namespace":"some-namespace","name":"some-serviceaccount"}},"status":{}}`,
 				importTimestampKey: timestampProvider.timestamp,
+				importSourceKey:    `{"name":"store-name","sources":[{"image":"some-registry.io/repo/buildpack-image"}],"relocated":{"default-registry.io/default-repo@sha256:buildpack-image-digest":"some-registry.io/repo/buildpack-image"}}`,
 			},
 		},
 		Spec: v1alpha2.ClusterStoreSpec{
@@ -187,6 +194,7 @@ func testImportCommand(t *testing.T, when spec.G, it spec.S) {
 			Name: "stack-name",
 			Annotations: map[string]string{
 				importTimestampKey: timestampProvider.timestamp,
+				importSourceKey:    `{"name":"stack-name","buildImage":{"image":"some-registry.io/repo/build-image"},"runImage":{"image":"some-registry.io/repo/run-image"}}`,
 			},
 		},
 		Spec: v1alpha2.ClusterStackSpec{
@@ -206,6 +214,7 @@ func testImportCommand(t *testing.T, when spec.G, it spec.S) {
 
 	defaultStack := stack.DeepCopy()
 	defaultStack.Name = "default"
+	defaultStack.Annotations[importSourceKey] = `{"name":"default","buildImage":{"image":"some-registry.io/repo/build-image"},"runImage":{"image":"some-registry.io/repo/run-image"}}`
 
 	builder := &v1alpha2.ClusterBuilder{
 		TypeMeta: metav1.TypeMeta{
@@ -277,6 +286,22 @@ func testImportCommand(t *testing.T, when spec.G, it spec.S) {
 		fakeConfirmationProvider = commandsfakes.NewFakeConfirmationProvider(true, nil)
 	})
 
+	it("errors when neither a filename nor a bundle is provided", func() {
+		testhelpers.CommandTest{
+			Args:                []string{},
+			ExpectErr:           true,
+			ExpectedErrorOutput: "Error: required flag(s) \"filename\" or \"from-bundle\" not set\n",
+		}.TestK8sAndKpack(t, cmdFunc)
+	})
+
+	it("errors when --impact is used without --show-changes", func() {
+		testhelpers.CommandTest{
+			Args:                []string{"-f", "./testdata/deps.yaml", "--impact"},
+			ExpectErr:           true,
+			ExpectedErrorOutput: "Error: --impact requires --show-changes\n",
+		}.TestK8sAndKpack(t, cmdFunc)
+	})
+
 	when("there are no stores, stacks, or cbs", func() {
 		it("creates stores, stacks, and cbs defined in the dependency descriptor", func() {
 			builder.Annotations["kubectl.kubernetes.io/last-applied-configuration"] = `{"kind":"ClusterBuilder","apiVersion":"kpack.io/v1alpha2","metadata":{"name":"clusterbuilder-name","creationTimestamp":null},"spec":{"tag":"default-registry.io/default-repo:clusterbuilder-clusterbuilder-name","stack":{"kind":"ClusterStack","name":"stack-name"},"lifecycle":{},"store":{"kind":"ClusterStore","name":"store-name"},"order":[{"group":[{"id":"buildpack-id"}]}],"serviceAccountRef":{"namespace":"some-namespace","name":"some-serviceaccount"}},"status":{"stack":{},"lifecycle":{"image":{},"api":{},"apis":{"buildpack":{"deprecated":null,"supported":null},"platform":{"deprecated":null,"supported":null}}}}}`
@@ -321,7 +346,7 @@ Imported resources
 					defaultBuilder,
 				},
 				ExpectPatches: []string{
-					`{"metadata":{"annotations":{"kpack.io/import-timestamp":"2006-01-02T15:04:05Z","kubectl.kubernetes.io/last-applied-configuration":"{\"kind\":\"ClusterLifecycle\",\"apiVersion\":\"kpack.io/v1alpha2\",\"metadata\":{\"name\":\"default\",\"creationTimestamp\":null},\"spec\":{\"image\":\"default-registry.io/default-repo@sha256:lifecycle-image-digest\",\"serviceAccountRef\":{\"namespace\":\"some-namespace\",\"name\":\"some-serviceaccount\"}},\"status\":{\"image\":{},\"api\":{},\"apis\":{\"buildpack\":{\"deprecated\":null,\"supported\":null},\"platform\":{\"deprecated\":null,\"supported\":null}}}}"}},"spec":{"image":"default-registry.io/default-repo@sha256:lifecycle-image-digest","serviceAccountRef":{"name":"some-serviceaccount","namespace":"some-namespace"}}}`,
+					`{"metadata":{"annotations":{"kpack.io/import-source":"{\"name\":\"default\",\"image\":\"some-registry.io/repo/lifecycle-image\"}","kpack.io/import-timestamp":"2006-01-02T15:04:05Z","kubectl.kubernetes.io/last-applied-configuration":"{\"kind\":\"ClusterLifecycle\",\"apiVersion\":\"kpack.io/v1alpha2\",\"metadata\":{\"name\":\"default\",\"creationTimestamp\":null},\"spec\":{\"image\":\"default-registry.io/default-repo@sha256:lifecycle-image-digest\",\"serviceAccountRef\":{\"namespace\":\"some-namespace\",\"name\":\"some-serviceaccount\"}},\"status\":{\"image\":{},\"api\":{},\"apis\":{\"buildpack\":{\"deprecated\":null,\"supported\":null},\"platform\":{\"deprecated\":null,\"supported\":null}}}}"}},"spec":{"image":"default-registry.io/default-repo@sha256:lifecycle-image-digest","serviceAccountRef":{"name":"some-serviceaccount","namespace":"some-namespace"}}}`,
 				},
 			}.TestK8sAndKpack(t, cmdFunc)
 			require.Len(t, fakeWaiter.WaitCalls, 7)
@@ -376,7 +401,7 @@ Imported resources
 					defaultBuilder,
 				},
 				ExpectPatches: []string{
-					`{"metadata":{"annotations":{"kpack.io/import-timestamp":"2006-01-02T15:04:05Z","kubectl.kubernetes.io/last-applied-configuration":"{\"kind\":\"ClusterLifecycle\",\"apiVersion\":\"kpack.io/v1alpha2\",\"metadata\":{\"name\":\"default\",\"creationTimestamp\":null},\"spec\":{\"image\":\"default-registry.io/default-repo@sha256:lifecycle-image-digest\",\"serviceAccountRef\":{\"namespace\":\"some-namespace\",\"name\":\"some-serviceaccount\"}},\"status\":{\"image\":{},\"api\":{},\"apis\":{\"buildpack\":{\"deprecated\":null,\"supported\":null},\"platform\":{\"deprecated\":null,\"supported\":null}}}}"}},"spec":{"image":"default-registry.io/default-repo@sha256:lifecycle-image-digest","serviceAccountRef":{"name":"some-serviceaccount","namespace":"some-namespace"}}}`,
+					`{"metadata":{"annotations":{"kpack.io/import-source":"{\"name\":\"default\",\"image\":\"some-registry.io/repo/lifecycle-image\"}","kpack.io/import-timestamp":"2006-01-02T15:04:05Z","kubectl.kubernetes.io/last-applied-configuration":"{\"kind\":\"ClusterLifecycle\",\"apiVersion\":\"kpack.io/v1alpha2\",\"metadata\":{\"name\":\"default\",\"creationTimestamp\":null},\"spec\":{\"image\":\"default-registry.io/default-repo@sha256:lifecycle-image-digest\",\"serviceAccountRef\":{\"namespace\":\"some-namespace\",\"name\":\"some-serviceaccount\"}},\"status\":{\"image\":{},\"api\":{},\"apis\":{\"buildpack\":{\"deprecated\":null,\"supported\":null},\"platform\":{\"deprecated\":null,\"supported\":null}}}}"}},"spec":{"image":"default-registry.io/default-repo@sha256:lifecycle-image-digest","serviceAccountRef":{"name":"some-serviceaccount","namespace":"some-namespace"}}}`,
 				},
 			}.TestK8sAndKpack(t, cmdFunc)
 			require.Len(t, fakeWaiter.WaitCalls, 7)
@@ -492,7 +517,7 @@ Imported resources
 						defaultBuilder,
 					},
 					ExpectPatches: []string{
-						`{"metadata":{"annotations":{"kpack.io/import-timestamp":"2006-01-02T15:04:05Z","kubectl.kubernetes.io/last-applied-configuration":"{\"kind\":\"ClusterLifecycle\",\"apiVersion\":\"kpack.io/v1alpha2\",\"metadata\":{\"name\":\"default\",\"creationTimestamp\":null},\"spec\":{\"image\":\"default-registry.io/default-repo@sha256:lifecycle-image-digest\",\"serviceAccountRef\":{\"namespace\":\"some-namespace\",\"name\":\"some-serviceaccount\"}},\"status\":{\"image\":{},\"api\":{},\"apis\":{\"buildpack\":{\"deprecated\":null,\"supported\":null},\"platform\":{\"deprecated\":null,\"supported\":null}}}}"}},"spec":{"image":"default-registry.io/default-repo@sha256:lifecycle-image-digest","serviceAccountRef":{"name":"some-serviceaccount","namespace":"some-namespace"}}}`,
+						`{"metadata":{"annotations":{"kpack.io/import-source":"{\"name\":\"default\",\"image\":\"some-registry.io/repo/lifecycle-image\"}","kpack.io/import-timestamp":"2006-01-02T15:04:05Z","kubectl.kubernetes.io/last-applied-configuration":"{\"kind\":\"ClusterLifecycle\",\"apiVersion\":\"kpack.io/v1alpha2\",\"metadata\":{\"name\":\"default\",\"creationTimestamp\":null},\"spec\":{\"image\":\"default-registry.io/default-repo@sha256:lifecycle-image-digest\",\"serviceAccountRef\":{\"namespace\":\"some-namespace\",\"name\":\"some-serviceaccount\"}},\"status\":{\"image\":{},\"api\":{},\"apis\":{\"buildpack\":{\"deprecated\":null,\"supported\":null},\"platform\":{\"deprecated\":null,\"supported\":null}}}}"}},"spec":{"image":"default-registry.io/default-repo@sha256:lifecycle-image-digest","serviceAccountRef":{"name":"some-serviceaccount","namespace":"some-namespace"}}}`,
 					},
 				}.TestK8sAndKpack(t, cmdFunc)
 				require.NoError(t, fakeConfirmationProvider.WasRequestedWithMsg("Confirm with y:"))
@@ -568,7 +593,7 @@ Imported resources
 						defaultBuilder,
 					},
 					ExpectPatches: []string{
-						`{"metadata":{"annotations":{"kpack.io/import-timestamp":"2006-01-02T15:04:05Z","kubectl.kubernetes.io/last-applied-configuration":"{\"kind\":\"ClusterLifecycle\",\"apiVersion\":\"kpack.io/v1alpha2\",\"metadata\":{\"name\":\"default\",\"creationTimestamp\":null},\"spec\":{\"image\":\"default-registry.io/default-repo@sha256:lifecycle-image-digest\",\"serviceAccountRef\":{\"namespace\":\"some-namespace\",\"name\":\"some-serviceaccount\"}},\"status\":{\"image\":{},\"api\":{},\"apis\":{\"buildpack\":{\"deprecated\":null,\"supported\":null},\"platform\":{\"deprecated\":null,\"supported\":null}}}}"}},"spec":{"image":"default-registry.io/default-repo@sha256:lifecycle-image-digest","serviceAccountRef":{"name":"some-serviceaccount","namespace":"some-namespace"}}}`,
+						`{"metadata":{"annotations":{"kpack.io/import-source":"{\"name\":\"default\",\"image\":\"some-registry.io/repo/lifecycle-image\"}","kpack.io/import-timestamp":"2006-01-02T15:04:05Z","kubectl.kubernetes.io/last-applied-configuration":"{\"kind\":\"ClusterLifecycle\",\"apiVersion\":\"kpack.io/v1alpha2\",\"metadata\":{\"name\":\"default\",\"creationTimestamp\":null},\"spec\":{\"image\":\"default-registry.io/default-repo@sha256:lifecycle-image-digest\",\"serviceAccountRef\":{\"namespace\":\"some-namespace\",\"name\":\"some-serviceaccount\"}},\"status\":{\"image\":{},\"api\":{},\"apis\":{\"buildpack\":{\"deprecated\":null,\"supported\":null},\"platform\":{\"deprecated\":null,\"supported\":null}}}}"}},"spec":{"image":"default-registry.io/default-repo@sha256:lifecycle-image-digest","serviceAccountRef":{"name":"some-serviceaccount","namespace":"some-namespace"}}}`,
 					},
 				}.TestK8sAndKpack(t, cmdFunc)
 				require.Equal(t, false, fakeConfirmationProvider.WasRequested())
@@ -651,11 +676,11 @@ Imported resources
 						expectedClusterBuildpack,
 					},
 					ExpectPatches: []string{
-						`{"metadata":{"annotations":{"kpack.io/import-timestamp":"new-timestamp","kubectl.kubernetes.io/last-applied-configuration":"{\"kind\":\"ClusterLifecycle\",\"apiVersion\":\"kpack.io/v1alpha2\",\"metadata\":{\"name\":\"default\",\"creationTimestamp\":null},\"spec\":{\"image\":\"default-registry.io/default-repo@sha256:lifecycle-image-digest\",\"serviceAccountRef\":{\"namespace\":\"some-namespace\",\"name\":\"some-serviceaccount\"}},\"status\":{\"image\":{},\"api\":{},\"apis\":{\"buildpack\":{\"deprecated\":null,\"supported\":null},\"platform\":{\"deprecated\":null,\"supported\":null}}}}"}},"spec":{"image":"default-registry.io/default-repo@sha256:lifecycle-image-digest","serviceAccountRef":{"name":"some-serviceaccount","namespace":"some-namespace"}}}`,
 						`{"metadata":{"annotations":{"kpack.io/import-timestamp":"new-timestamp"}}}`,
 						`{"metadata":{"annotations":{"kpack.io/import-timestamp":"new-timestamp"}},"spec":{"buildImage":{"image":"default-registry.io/default-repo@sha256:build-image-digest"},"runImage":{"image":"default-registry.io/default-repo@sha256:build-image-digest"}}}`,
 						`{"metadata":{"annotations":{"kpack.io/import-timestamp":"new-timestamp","kubectl.kubernetes.io/last-applied-configuration":"{\"kind\":\"ClusterBuilder\",\"apiVersion\":\"kpack.io/v1alpha2\",\"metadata\":{\"name\":\"clusterbuilder-name\",\"creationTimestamp\":null},\"spec\":{\"tag\":\"default-registry.io/default-repo:clusterbuilder-clusterbuilder-name\",\"stack\":{\"kind\":\"ClusterStack\",\"name\":\"stack-name\"},\"lifecycle\":{},\"store\":{\"kind\":\"ClusterStore\",\"name\":\"store-name\"},\"order\":[{\"group\":[{\"id\":\"buildpack-id\"}]}],\"serviceAccountRef\":{\"namespace\":\"some-namespace\",\"name\":\"some-serviceaccount\"}},\"status\":{\"stack\":{},\"lifecycle\":{\"image\":{},\"api\":{},\"apis\":{\"buildpack\":{\"deprecated\":null,\"supported\":null},\"platform\":{\"deprecated\":null,\"supported\":null}}}}}"}}}`,
 						`{"metadata":{"annotations":{"kpack.io/import-timestamp":"new-timestamp","kubectl.kubernetes.io/last-applied-configuration":"{\"kind\":\"ClusterBuilder\",\"apiVersion\":\"kpack.io/v1alpha2\",\"metadata\":{\"name\":\"default\",\"creationTimestamp\":null},\"spec\":{\"tag\":\"default-registry.io/default-repo:clusterbuilder-default\",\"stack\":{\"kind\":\"ClusterStack\",\"name\":\"stack-name\"},\"lifecycle\":{},\"store\":{\"kind\":\"ClusterStore\",\"name\":\"store-name\"},\"order\":[{\"group\":[{\"id\":\"buildpack-id\"}]}],\"serviceAccountRef\":{\"namespace\":\"some-namespace\",\"name\":\"some-serviceaccount\"}},\"status\":{\"stack\":{},\"lifecycle\":{\"image\":{},\"api\":{},\"apis\":{\"buildpack\":{\"deprecated\":null,\"supported\":null},\"platform\":{\"deprecated\":null,\"supported\":null}}}}}"}}}`,
+						`{"metadata":{"annotations":{"kpack.io/import-source":"{\"name\":\"default\",\"image\":\"some-registry.io/repo/lifecycle-image\"}","kpack.io/import-timestamp":"new-timestamp","kubectl.kubernetes.io/last-applied-configuration":"{\"kind\":\"ClusterLifecycle\",\"apiVersion\":\"kpack.io/v1alpha2\",\"metadata\":{\"name\":\"default\",\"creationTimestamp\":null},\"spec\":{\"image\":\"default-registry.io/default-repo@sha256:lifecycle-image-digest\",\"serviceAccountRef\":{\"namespace\":\"some-namespace\",\"name\":\"some-serviceaccount\"}},\"status\":{\"image\":{},\"api\":{},\"apis\":{\"buildpack\":{\"deprecated\":null,\"supported\":null},\"platform\":{\"deprecated\":null,\"supported\":null}}}}"}},"spec":{"image":"default-registry.io/default-repo@sha256:lifecycle-image-digest","serviceAccountRef":{"name":"some-serviceaccount","namespace":"some-namespace"}}}`,
 					},
 				}.TestK8sAndKpack(t, cmdFunc)
 				require.Len(t, fakeWaiter.WaitCalls, 7)
@@ -713,10 +738,12 @@ Imported resources
 						expectedClusterBuildpack,
 					},
 					ExpectPatches: []string{
-						`{"metadata":{"annotations":{"kpack.io/import-timestamp":"new-timestamp","kubectl.kubernetes.io/last-applied-configuration":"{\"kind\":\"ClusterLifecycle\",\"apiVersion\":\"kpack.io/v1alpha2\",\"metadata\":{\"name\":\"default\",\"creationTimestamp\":null},\"spec\":{\"image\":\"default-registry.io/default-repo@sha256:lifecycle-image-digest\",\"serviceAccountRef\":{\"namespace\":\"some-namespace\",\"name\":\"some-serviceaccount\"}},\"status\":{\"image\":{},\"api\":{},\"apis\":{\"buildpack\":{\"deprecated\":null,\"supported\":null},\"platform\":{\"deprecated\":null,\"supported\":null}}}}"}},"spec":{"image":"default-registry.io/default-repo@sha256:lifecycle-image-digest","serviceAccountRef":{"name":"some-serviceaccount","namespace":"some-namespace"}}}`,
-						`{"metadata":{"annotations":{"kpack.io/import-timestamp":"new-timestamp"}}}`,
 						`{"metadata":{"annotations":{"kpack.io/import-timestamp":"new-timestamp","kubectl.kubernetes.io/last-applied-configuration":"{\"kind\":\"ClusterBuilder\",\"apiVersion\":\"kpack.io/v1alpha2\",\"metadata\":{\"name\":\"clusterbuilder-name\",\"creationTimestamp\":null},\"spec\":{\"tag\":\"default-registry.io/default-repo:clusterbuilder-clusterbuilder-name\",\"stack\":{\"kind\":\"ClusterStack\",\"name\":\"stack-name\"},\"lifecycle\":{},\"store\":{\"kind\":\"ClusterStore\",\"name\":\"store-name\"},\"order\":[{\"group\":[{\"id\":\"buildpack-id\"}]}],\"serviceAccountRef\":{\"namespace\":\"some-namespace\",\"name\":\"some-serviceaccount\"}},\"status\":{\"stack\":{},\"lifecycle\":{\"image\":{},\"api\":{},\"apis\":{\"buildpack\":{\"deprecated\":null,\"supported\":null},\"platform\":{\"deprecated\":null,\"supported\":null}}}}}"}}}`,
 						`{"metadata":{"annotations":{"kpack.io/import-timestamp":"new-timestamp","kubectl.kubernetes.io/last-applied-configuration":"{\"kind\":\"ClusterBuilder\",\"apiVersion\":\"kpack.io/v1alpha2\",\"metadata\":{\"name\":\"default\",\"creationTimestamp\":null},\"spec\":{\"tag\":\"default-registry.io/default-repo:clusterbuilder-default\",\"stack\":{\"kind\":\"ClusterStack\",\"name\":\"stack-name\"},\"lifecycle\":{},\"store\":{\"kind\":\"ClusterStore\",\"name\":\"store-name\"},\"order\":[{\"group\":[{\"id\":\"buildpack-id\"}]}],\"serviceAccountRef\":{\"namespace\":\"some-namespace\",\"name\":\"some-serviceaccount\"}},\"status\":{\"stack\":{},\"lifecycle\":{\"image\":{},\"api\":{},\"apis\":{\"buildpack\":{\"deprecated\":null,\"supported\":null},\"platform\":{\"deprecated\":null,\"supported\":null}}}}}"}}}`,
+						`{"metadata":{"annotations":{"kpack.io/import-source":"{\"name\":\"default\",\"image\":\"some-registry.io/repo/lifecycle-image\"}","kpack.io/import-timestamp":"new-timestamp","kubectl.kubernetes.io/last-applied-configuration":"{\"kind\":\"ClusterLifecycle\",\"apiVersion\":\"kpack.io/v1alpha2\",\"metadata\":{\"name\":\"default\",\"creationTimestamp\":null},\"spec\":{\"image\":\"default-registry.io/default-repo@sha256:lifecycle-image-digest\",\"serviceAccountRef\":{\"namespace\":\"some-namespace\",\"name\":\"some-serviceaccount\"}},\"status\":{\"image\":{},\"api\":{},\"apis\":{\"buildpack\":{\"deprecated\":null,\"supported\":null},\"platform\":{\"deprecated\":null,\"supported\":null}}}}"}},"spec":{"image":"default-registry.io/default-repo@sha256:lifecycle-image-digest","serviceAccountRef":{"name":"some-serviceaccount","namespace":"some-namespace"}}}`,
+						`{"metadata":{"annotations":{"kpack.io/import-source":"{\"name\":\"store-name\",\"sources\":[{\"image\":\"some-registry.io/repo/buildpack-image\"}],\"relocated\":{\"default-registry.io/default-repo@sha256:buildpack-image-digest\":\"some-registry.io/repo/buildpack-image\"}}","kpack.io/import-timestamp":"new-timestamp"}}}`,
+						`{"metadata":{"annotations":{"kpack.io/import-source":"{\"name\":\"stack-name\",\"buildImage\":{\"image\":\"some-registry.io/repo/build-image\"},\"runImage\":{\"image\":\"some-registry.io/repo/run-image\"}}","kpack.io/import-timestamp":"new-timestamp"}}}`,
+						`{"metadata":{"annotations":{"kpack.io/import-source":"{\"name\":\"default\",\"buildImage\":{\"image\":\"some-registry.io/repo/build-image\"},\"runImage\":{\"image\":\"some-registry.io/repo/run-image\"}}","kpack.io/import-timestamp":"new-timestamp"}}}`,
 					},
 				}.TestK8sAndKpack(t, cmdFunc)
 			})
@@ -813,14 +840,380 @@ Imported resources
 `,
 					ExpectPatches: []string{
 						`{"metadata":{"annotations":{"kpack.io/import-timestamp":"new-timestamp","kubectl.kubernetes.io/last-applied-configuration":"{\"kind\":\"ClusterBuilder\",\"apiVersion\":\"kpack.io/v1alpha2\",\"metadata\":{\"name\":\"default\",\"creationTimestamp\":null},\"spec\":{\"tag\":\"default-registry.io/default-repo:clusterbuilder-default\",\"stack\":{\"kind\":\"ClusterStack\",\"name\":\"stack-name\"},\"lifecycle\":{},\"store\":{\"kind\":\"ClusterStore\",\"name\":\"store-name\"},\"order\":[{\"group\":[{\"id\":\"another-buildpack-id\"}]}],\"serviceAccountRef\":{\"namespace\":\"some-namespace\",\"name\":\"some-serviceaccount\"}},\"status\":{\"stack\":{},\"lifecycle\":{\"image\":{},\"api\":{},\"apis\":{\"buildpack\":{\"deprecated\":null,\"supported\":null},\"platform\":{\"deprecated\":null,\"supported\":null}}}}}"}},"spec":{"order":[{"group":[{"id":"another-buildpack-id"}]}]}}`,
-						`{"metadata":{"annotations":{"kpack.io/import-timestamp":"new-timestamp","kubectl.kubernetes.io/last-applied-configuration":"{\"kind\":\"ClusterLifecycle\",\"apiVersion\":\"kpack.io/v1alpha2\",\"metadata\":{\"name\":\"default\",\"creationTimestamp\":null},\"spec\":{\"image\":\"default-registry.io/default-repo@sha256:another-lifecycle-image-digest\",\"serviceAccountRef\":{\"namespace\":\"some-namespace\",\"name\":\"some-serviceaccount\"}},\"status\":{\"image\":{},\"api\":{},\"apis\":{\"buildpack\":{\"deprecated\":null,\"supported\":null},\"platform\":{\"deprecated\":null,\"supported\":null}}}}"}},"spec":{"image":"default-registry.io/default-repo@sha256:another-lifecycle-image-digest","serviceAccountRef":{"name":"some-serviceaccount","namespace":"some-namespace"}}}`,
-						`{"metadata":{"annotations":{"kpack.io/import-timestamp":"new-timestamp"}},"spec":{"sources":[{"image":"default-registry.io/default-repo@sha256:buildpack-image-digest"},{"image":"default-registry.io/default-repo@sha256:another-buildpack-image-digest"}]}}`,
-						`{"metadata":{"annotations":{"kpack.io/import-timestamp":"new-timestamp"}},"spec":{"buildImage":{"image":"default-registry.io/default-repo@sha256:another-build-image-digest"},"id":"another-stack-id","runImage":{"image":"default-registry.io/default-repo@sha256:another-run-image-digest"}}}`,
 						`{"metadata":{"annotations":{"kpack.io/import-timestamp":"new-timestamp","kubectl.kubernetes.io/last-applied-configuration":"{\"kind\":\"ClusterBuilder\",\"apiVersion\":\"kpack.io/v1alpha2\",\"metadata\":{\"name\":\"clusterbuilder-name\",\"creationTimestamp\":null},\"spec\":{\"tag\":\"default-registry.io/default-repo:clusterbuilder-clusterbuilder-name\",\"stack\":{\"kind\":\"ClusterStack\",\"name\":\"stack-name\"},\"lifecycle\":{},\"store\":{\"kind\":\"ClusterStore\",\"name\":\"store-name\"},\"order\":[{\"group\":[{\"id\":\"another-buildpack-id\"}]}],\"serviceAccountRef\":{\"namespace\":\"some-namespace\",\"name\":\"some-serviceaccount\"}},\"status\":{\"stack\":{},\"lifecycle\":{\"image\":{},\"api\":{},\"apis\":{\"buildpack\":{\"deprecated\":null,\"supported\":null},\"platform\":{\"deprecated\":null,\"supported\":null}}}}}"}},"spec":{"order":[{"group":[{"id":"another-buildpack-id"}]}]}}`,
+						`{"metadata":{"annotations":{"kpack.io/import-source":"{\"name\":\"default\",\"image\":\"some-registry.io/repo/another-lifecycle-image\"}","kpack.io/import-timestamp":"new-timestamp","kubectl.kubernetes.io/last-applied-configuration":"{\"kind\":\"ClusterLifecycle\",\"apiVersion\":\"kpack.io/v1alpha2\",\"metadata\":{\"name\":\"default\",\"creationTimestamp\":null},\"spec\":{\"image\":\"default-registry.io/default-repo@sha256:another-lifecycle-image-digest\",\"serviceAccountRef\":{\"namespace\":\"some-namespace\",\"name\":\"some-serviceaccount\"}},\"status\":{\"image\":{},\"api\":{},\"apis\":{\"buildpack\":{\"deprecated\":null,\"supported\":null},\"platform\":{\"deprecated\":null,\"supported\":null}}}}"}},"spec":{"image":"default-registry.io/default-repo@sha256:another-lifecycle-image-digest","serviceAccountRef":{"name":"some-serviceaccount","namespace":"some-namespace"}}}`,
+						`{"metadata":{"annotations":{"kpack.io/import-source":"{\"name\":\"store-name\",\"sources\":[{\"image\":\"some-registry.io/repo/buildpack-image\"},{\"image\":\"some-registry.io/repo/another-buildpack-image\"}],\"relocated\":{\"default-registry.io/default-repo@sha256:another-buildpack-image-digest\":\"some-registry.io/repo/another-buildpack-image\",\"default-registry.io/default-repo@sha256:buildpack-image-digest\":\"some-registry.io/repo/buildpack-image\"}}","kpack.io/import-timestamp":"new-timestamp"}},"spec":{"sources":[{"image":"default-registry.io/default-repo@sha256:buildpack-image-digest"},{"image":"default-registry.io/default-repo@sha256:another-buildpack-image-digest"}]}}`,
+						`{"metadata":{"annotations":{"kpack.io/import-source":"{\"name\":\"stack-name\",\"buildImage\":{\"image\":\"some-registry.io/repo/another-build-image\"},\"runImage\":{\"image\":\"some-registry.io/repo/another-run-image\"}}","kpack.io/import-timestamp":"new-timestamp"}},"spec":{"buildImage":{"image":"default-registry.io/default-repo@sha256:another-build-image-digest"},"id":"another-stack-id","runImage":{"image":"default-registry.io/default-repo@sha256:another-run-image-digest"}}}`,
+						`{"metadata":{"annotations":{"kpack.io/import-source":"{\"name\":\"default\",\"buildImage\":{\"image\":\"some-registry.io/repo/another-build-image\"},\"runImage\":{\"image\":\"some-registry.io/repo/another-run-image\"}}","kpack.io/import-timestamp":"new-timestamp"}},"spec":{"buildImage":{"image":"default-registry.io/default-repo@sha256:another-build-image-digest"},"id":"another-stack-id","runImage":{"image":"default-registry.io/default-repo@sha256:another-run-image-digest"}}}`,
 					},
 				}.TestK8sAndKpack(t, cmdFunc)
 			})
 		})
+
+		it("shows the images that would rebase or rebuild with --impact", func() {
+			fakeConfirmationProvider = commandsfakes.NewFakeConfirmationProvider(false, nil)
+
+			image := &v1alpha2.Image{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "some-image",
+					Namespace: "some-namespace",
+				},
+				Spec: v1alpha2.ImageSpec{
+					Builder: corev1.ObjectReference{Kind: v1alpha2.ClusterBuilderKind, Name: "clusterbuilder-name"},
+				},
+			}
+
+			testhelpers.CommandTest{
+				Objects: []runtime.Object{
+					kpConfig,
+					lifecycleImageConfig,
+					store,
+					stack,
+					defaultStack,
+					builder,
+					defaultBuilder,
+					image,
+				},
+				Args: []string{
+					"-f", "./testdata/updated-deps.yaml",
+					"--show-changes",
+					"--impact",
+				},
+				ExpectedOutput: `Changes
+
+ClusterLifecycles
+
+some-diff
+
+ClusterBuildpacks
+
+No Changes
+
+ClusterStores
+
+some-diff
+
+ClusterStacks
+
+some-diff
+
+some-diff
+
+ClusterBuilders
+
+some-diff
+
+some-diff
+
+
+Impact
+
+NAMESPACE         IMAGE         BUILDER                               ACTION
+some-namespace    some-image    ClusterBuilder/clusterbuilder-name    rebuild
+
+NAMESPACE         REBASE    REBUILD
+some-namespace    0         1
+
+Skipping import
+`,
+			}.TestK8sAndKpack(t, cmdFunc)
+			require.NoError(t, fakeConfirmationProvider.WasRequestedWithMsg("Confirm with y:"))
+		})
+	})
+
+	it("shows changes to namespaced resources in a v2 descriptor", func() {
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{kpConfig},
+			Args: []string{
+				"-f", "./testdata/v2-deps.yaml",
+				"--show-changes",
+				"--dry-run",
+			},
+			ExpectedOutput: `Changes
+
+ClusterLifecycles
+
+No Changes
+
+ClusterBuildpacks
+
+No Changes
+
+ClusterStores
+
+No Changes
+
+ClusterStacks
+
+some-diff
+
+ClusterBuilders
+
+No Changes
+
+Buildpacks
+
+some-diff
+
+Builders
+
+some-diff
+
+
+Importing Buildpack 'my-buildpack' in namespace 'some-namespace'... (dry run)
+Uploading to 'default-registry.io/default-repo'... (dry run)
+	Skipping 'default-registry.io/default-repo@sha256:standalone-buildpack-digest'
+Importing ClusterStack 'stack-name'... (dry run)
+Uploading to 'default-registry.io/default-repo'... (dry run)
+	Skipping 'default-registry.io/default-repo@sha256:build-image-digest'
+	Skipping 'default-registry.io/default-repo@sha256:build-image-digest'
+Importing Builder 'builder-name' in namespace 'some-namespace'... (dry run)
+Imported resources (dry run)
+`,
+		}.TestK8sAndKpack(t, cmdFunc)
+		require.NoError(t, fakeConfirmationProvider.WasRequestedWithMsg("Confirm with y:"))
+	})
+
+	when("the prune flag is used", func() {
+		oldStack := &v1alpha2.ClusterStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "old-stack",
+				Annotations: map[string]string{importTimestampKey: "2006-01-02T15:04:05Z"},
+			},
+		}
+
+		it("shows and prunes imported resources that are no longer in the descriptor", func() {
+			testhelpers.CommandTest{
+				Objects: []runtime.Object{kpConfig, oldStack},
+				Args: []string{
+					"-f", "./testdata/v2-deps.yaml",
+					"--prune",
+					"--show-changes",
+					"--dry-run",
+				},
+				ExpectedOutput: `Changes
+
+ClusterLifecycles
+
+No Changes
+
+ClusterBuildpacks
+
+No Changes
+
+ClusterStores
+
+No Changes
+
+ClusterStacks
+
+some-diff
+
+ClusterBuilders
+
+No Changes
+
+Buildpacks
+
+some-diff
+
+Builders
+
+some-diff
+
+Pruned Resources
+
+some-diff
+
+
+Importing Buildpack 'my-buildpack' in namespace 'some-namespace'... (dry run)
+Uploading to 'default-registry.io/default-repo'... (dry run)
+	Skipping 'default-registry.io/default-repo@sha256:standalone-buildpack-digest'
+Importing ClusterStack 'stack-name'... (dry run)
+Uploading to 'default-registry.io/default-repo'... (dry run)
+	Skipping 'default-registry.io/default-repo@sha256:build-image-digest'
+	Skipping 'default-registry.io/default-repo@sha256:build-image-digest'
+Importing Builder 'builder-name' in namespace 'some-namespace'... (dry run)
+Pruning ClusterStack 'old-stack'... (dry run)
+Imported resources (dry run)
+`,
+			}.TestK8sAndKpack(t, cmdFunc)
+			require.NoError(t, fakeConfirmationProvider.WasRequestedWithMsg("Confirm with y:"))
+		})
+
+		it("does not ask for confirmation to prune with --dry-run", func() {
+			testhelpers.CommandTest{
+				Objects: []runtime.Object{kpConfig, oldStack},
+				Args: []string{
+					"-f", "./testdata/v2-deps.yaml",
+					"--prune",
+					"--dry-run",
+				},
+				ExpectedOutput: `Importing Buildpack 'my-buildpack' in namespace 'some-namespace'... (dry run)
+Uploading to 'default-registry.io/default-repo'... (dry run)
+	Skipping 'default-registry.io/default-repo@sha256:standalone-buildpack-digest'
+Importing ClusterStack 'stack-name'... (dry run)
+Uploading to 'default-registry.io/default-repo'... (dry run)
+	Skipping 'default-registry.io/default-repo@sha256:build-image-digest'
+	Skipping 'default-registry.io/default-repo@sha256:build-image-digest'
+Importing Builder 'builder-name' in namespace 'some-namespace'... (dry run)
+Pruning ClusterStack 'old-stack'... (dry run)
+Imported resources (dry run)
+`,
+			}.TestK8sAndKpack(t, cmdFunc)
+			require.False(t, fakeConfirmationProvider.WasRequested())
+		})
+
+		it("refuses to prune resources that are still referenced", func() {
+			manualBuilder := &v1alpha2.ClusterBuilder{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "manual-builder",
+				},
+				Spec: v1alpha2.ClusterBuilderSpec{
+					BuilderSpec: v1alpha2.BuilderSpec{
+						Stack: corev1.ObjectReference{Name: "old-stack", Kind: v1alpha2.ClusterStackKind},
+					},
+				},
+			}
+
+			testhelpers.CommandTest{
+				Objects: []runtime.Object{kpConfig, oldStack, manualBuilder},
+				Args: []string{
+					"-f", "./testdata/v2-deps.yaml",
+					"--prune",
+				},
+				ExpectErr:           true,
+				ExpectedErrorOutput: "Error: cannot prune ClusterStack 'old-stack': it is referenced by ClusterBuilder 'manual-builder'\n",
+			}.TestK8sAndKpack(t, cmdFunc)
+		})
+	})
+
+	when("the descriptor configures signature verification", func() {
+		var descriptorPath string
+
+		it.Before(func() {
+			key, publicKey := testhelpers.MakeCosignKey(t)
+			fakeFetcher.AddImage(
+				"some-registry.io/repo/standalone-buildpack:sha256-standalone-buildpack-digest.sig",
+				testhelpers.MakeCosignSignature(t, key, v1.Hash{Algorithm: "sha256", Hex: "standalone-buildpack-digest"}),
+			)
+
+			descriptorPath = filepath.Join(t.TempDir(), "deps.yaml")
+			require.NoError(t, os.WriteFile(descriptorPath, []byte(fmt.Sprintf(`apiVersion: kp.kpack.io/v2
+kind: DependencyDescriptor
+verification:
+  publicKey: %s
+  policy: skip
+clusterBuildpacks:
+- name: my-buildpack
+  image: some-registry.io/repo/standalone-buildpack
+- name: unsigned-buildpack
+  image: some-registry.io/repo/buildpack-image
+clusterBuilders:
+- name: unsigned-builder
+  clusterStack: some-stack
+  order:
+  - group:
+    - name: unsigned-buildpack
+      kind: ClusterBuildpack
+`, publicKey)), 0600))
+		})
+
+		it("skips resources with images that are not signed with the skip policy", func() {
+			testhelpers.CommandTest{
+				Objects: []runtime.Object{kpConfig},
+				Args:    []string{"-f", descriptorPath},
+				ExpectedOutput: `Importing ClusterBuildpack 'my-buildpack'...
+Uploading to 'default-registry.io/default-repo'...
+	Uploading 'default-registry.io/default-repo@sha256:standalone-buildpack-digest'
+Importing ClusterBuildpack 'unsigned-buildpack'...
+Skipping: invalid buildpack image: image 'some-registry.io/repo/buildpack-image' failed signature verification: no signature found at 'some-registry.io/repo/buildpack-image:sha256-buildpack-image-digest.sig'
+Importing ClusterBuilder 'unsigned-builder'...
+Skipping ClusterBuilder 'unsigned-builder': depends on skipped ClusterBuildpack 'unsigned-buildpack'
+Imported resources
+`,
+				ExpectCreates: []runtime.Object{clusterBuildpack},
+			}.TestK8sAndKpack(t, cmdFunc)
+		})
+
+		it("shows the resources that are skipped in the changes", func() {
+			testhelpers.CommandTest{
+				Objects: []runtime.Object{kpConfig},
+				Args:    []string{"-f", descriptorPath, "--show-changes", "--dry-run"},
+				ExpectedOutput: `Changes
+
+ClusterLifecycles
+
+No Changes
+
+ClusterBuildpacks
+
+some-diff
+
+Skipping ClusterBuildpack 'unsigned-buildpack': image 'some-registry.io/repo/buildpack-image' failed signature verification: no signature found at 'some-registry.io/repo/buildpack-image:sha256-buildpack-image-digest.sig'
+
+ClusterStores
+
+No Changes
+
+ClusterStacks
+
+No Changes
+
+ClusterBuilders
+
+some-diff
+
+
+Importing ClusterBuildpack 'my-buildpack'... (dry run)
+Uploading to 'default-registry.io/default-repo'... (dry run)
+	Skipping 'default-registry.io/default-repo@sha256:standalone-buildpack-digest'
+Importing ClusterBuildpack 'unsigned-buildpack'... (dry run)
+Skipping: invalid buildpack image: image 'some-registry.io/repo/buildpack-image' failed signature verification: no signature found at 'some-registry.io/repo/buildpack-image:sha256-buildpack-image-digest.sig'
+Importing ClusterBuilder 'unsigned-builder'... (dry run)
+Skipping ClusterBuilder 'unsigned-builder': depends on skipped ClusterBuildpack 'unsigned-buildpack'
+Imported resources (dry run)
+`,
+			}.TestK8sAndKpack(t, cmdFunc)
+		})
+
+		it("cannot filter platforms when verifying signatures", func() {
+			testhelpers.CommandTest{
+				Objects:             []runtime.Object{kpConfig},
+				Args:                []string{"-f", descriptorPath, "--platform", "linux/amd64"},
+				ExpectErr:           true,
+				ExpectedErrorOutput: "Error: platforms cannot be filtered when verifying signatures, the filtered images would not match the signed digests\n",
+			}.TestK8sAndKpack(t, cmdFunc)
+		})
+
+		it("requires a v2 descriptor", func() {
+			require.NoError(t, os.WriteFile(descriptorPath, []byte(`apiVersion: kp.kpack.io/v1
+kind: DependencyDescriptor
+verification:
+  publicKey: some-key.pub
+`), 0600))
+
+			testhelpers.CommandTest{
+				Objects:             []runtime.Object{kpConfig},
+				Args:                []string{"-f", descriptorPath},
+				ExpectErr:           true,
+				ExpectedErrorOutput: "Error: verification requires apiVersion kp.kpack.io/v2\n",
+			}.TestK8sAndKpack(t, cmdFunc)
+		})
+
+		it("fails on images that are not signed when the policy flag enforces verification", func() {
+			testhelpers.CommandTest{
+				Objects:   []runtime.Object{kpConfig},
+				Args:      []string{"-f", descriptorPath, "--verify-policy", "enforce"},
+				ExpectErr: true,
+				ExpectedOutput: `Importing ClusterBuildpack 'my-buildpack'...
+Uploading to 'default-registry.io/default-repo'...
+	Uploading 'default-registry.io/default-repo@sha256:standalone-buildpack-digest'
+Importing ClusterBuildpack 'unsigned-buildpack'...
+`,
+				ExpectedErrorOutput: "Error: invalid buildpack image: image 'some-registry.io/repo/buildpack-image' failed signature verification: no signature found at 'some-registry.io/repo/buildpack-image:sha256-buildpack-image-digest.sig'\n",
+			}.TestK8sAndKpack(t, cmdFunc)
+		})
 	})
 
 	it("errors when the descriptor apiVersion is unexpected", func() {
@@ -829,7 +1222,7 @@ Imported resources
 			Args: []string{
 				"-f", "./testdata/invalid-deps.yaml",
 			},
-			ExpectedErrorOutput: "Error: did not find expected apiVersion, must be one of: [kp.kpack.io/v1alpha1 kp.kpack.io/v1alpha3 kp.kpack.io/v1]\n",
+			ExpectedErrorOutput: "Error: did not find expected apiVersion, must be one of: [kp.kpack.io/v1alpha1 kp.kpack.io/v1alpha3 kp.kpack.io/v1 kp.kpack.io/v2]\n",
 			ExpectErr:           true,
 		}.TestK8sAndKpack(t, cmdFunc)
 	})
@@ -863,6 +1256,7 @@ Importing ClusterBuilder 'default'...
 kind: ClusterLifecycle
 metadata:
   annotations:
+    kpack.io/import-source: '{"name":"default","image":"some-registry.io/repo/lifecycle-image"}'
     kpack.io/import-timestamp: "2006-01-02T15:04:05Z"
     kubectl.kubernetes.io/last-applied-configuration: '{"kind":"ClusterLifecycle","apiVersion":"kpack.io/v1alpha2","metadata":{"name":"default","creationTimestamp":null},"spec":{"image":"default-registry.io/default-repo@sha256:lifecycle-image-digest","serviceAccountRef":{"namespace":"some-namespace","name":"some-serviceaccount"}},"status":{"image":{},"api":{},"apis":{"buildpack":{"deprecated":null,"supported":null},"platform":{"deprecated":null,"supported":null}}}}'
   creationTimestamp: null
@@ -887,6 +1281,7 @@ apiVersion: kpack.io/v1alpha2
 kind: ClusterBuildpack
 metadata:
   annotations:
+    kpack.io/import-source: '{"name":"my-buildpack","image":"some-registry.io/repo/standalone-buildpack"}'
     kpack.io/import-timestamp: "2006-01-02T15:04:05Z"
     kubectl.kubernetes.io/last-applied-configuration: '{"kind":"ClusterBuildpack","apiVersion":"kpack.io/v1alpha2","metadata":{"name":"my-buildpack","creationTimestamp":null},"spec":{"image":"default-registry.io/default-repo@sha256:standalone-buildpack-digest","serviceAccountRef":{"namespace":"some-namespace","name":"some-serviceaccount"}},"status":{}}'
   creationTimestamp: null
@@ -902,6 +1297,7 @@ apiVersion: kpack.io/v1alpha2
 kind: ClusterStore
 metadata:
   annotations:
+    kpack.io/import-source: '{"name":"store-name","sources":[{"image":"some-registry.io/repo/buildpack-image"}],"relocated":{"default-registry.io/default-repo@sha256:buildpack-image-digest":"some-registry.io/repo/buildpack-image"}}'
     kpack.io/import-timestamp: "2006-01-02T15:04:05Z"
     kubectl.kubernetes.io/last-applied-configuration: '{"kind":"ClusterStore","apiVersion":"kpack.io/v1alpha2","metadata":{"name":"store-name","creationTimestamp":null},"spec":{"sources":[{"image":"default-registry.io/default-repo@sha256:buildpack-image-digest"}],"serviceAccountRef":{"namespace":"some-namespace","name":"some-serviceaccount"}},"status":{}}'
   creationTimestamp: null
@@ -918,6 +1314,7 @@ apiVersion: kpack.io/v1alpha2
 kind: ClusterStack
 metadata:
   annotations:
+    kpack.io/import-source: '{"name":"stack-name","buildImage":{"image":"some-registry.io/repo/build-image"},"runImage":{"image":"some-registry.io/repo/run-image"}}'
     kpack.io/import-timestamp: "2006-01-02T15:04:05Z"
   creationTimestamp: null
   name: stack-name
@@ -938,6 +1335,7 @@ apiVersion: kpack.io/v1alpha2
 kind: ClusterStack
 metadata:
   annotations:
+    kpack.io/import-source: '{"name":"default","buildImage":{"image":"some-registry.io/repo/build-image"},"runImage":{"image":"some-registry.io/repo/run-image"}}'
     kpack.io/import-timestamp: "2006-01-02T15:04:05Z"
   creationTimestamp: null
   name: default
@@ -1048,7 +1446,7 @@ status:
 						defaultBuilder,
 					},
 					ExpectPatches: []string{
-						`{"metadata":{"annotations":{"kpack.io/import-timestamp":"2006-01-02T15:04:05Z","kubectl.kubernetes.io/last-applied-configuration":"{\"kind\":\"ClusterLifecycle\",\"apiVersion\":\"kpack.io/v1alpha2\",\"metadata\":{\"name\":\"default\",\"creationTimestamp\":null},\"spec\":{\"image\":\"default-registry.io/default-repo@sha256:lifecycle-image-digest\",\"serviceAccountRef\":{\"namespace\":\"some-namespace\",\"name\":\"some-serviceaccount\"}},\"status\":{\"image\":{},\"api\":{},\"apis\":{\"buildpack\":{\"deprecated\":null,\"supported\":null},\"platform\":{\"deprecated\":null,\"supported\":null}}}}"}},"spec":{"image":"default-registry.io/default-repo@sha256:lifecycle-image-digest","serviceAccountRef":{"name":"some-serviceaccount","namespace":"some-namespace"}}}`,
+						`{"metadata":{"annotations":{"kpack.io/import-source":"{\"name\":\"default\",\"image\":\"some-registry.io/repo/lifecycle-image\"}","kpack.io/import-timestamp":"2006-01-02T15:04:05Z","kubectl.kubernetes.io/last-applied-configuration":"{\"kind\":\"ClusterLifecycle\",\"apiVersion\":\"kpack.io/v1alpha2\",\"metadata\":{\"name\":\"default\",\"creationTimestamp\":null},\"spec\":{\"image\":\"default-registry.io/default-repo@sha256:lifecycle-image-digest\",\"serviceAccountRef\":{\"namespace\":\"some-namespace\",\"name\":\"some-serviceaccount\"}},\"status\":{\"image\":{},\"api\":{},\"apis\":{\"buildpack\":{\"deprecated\":null,\"supported\":null},\"platform\":{\"deprecated\":null,\"supported\":null}}}}"}},"spec":{"image":"default-registry.io/default-repo@sha256:lifecycle-image-digest","serviceAccountRef":{"name":"some-serviceaccount","namespace":"some-namespace"}}}`,
 					},
 				}.TestK8sAndKpack(t, cmdFunc)
 			})
@@ -1138,6 +1536,7 @@ Importing ClusterBuilder 'default'... (dry run with image upload)
         "name": "default",
         "creationTimestamp": null,
         "annotations": {
+            "kpack.io/import-source": "{\"name\":\"default\",\"image\":\"some-registry.io/repo/lifecycle-image\"}",
             "kpack.io/import-timestamp": "2006-01-02T15:04:05Z",
             "kubectl.kubernetes.io/last-applied-configuration": "{\"kind\":\"ClusterLifecycle\",\"apiVersion\":\"kpack.io/v1alpha2\",\"metadata\":{\"name\":\"default\",\"creationTimestamp\":null},\"spec\":{\"image\":\"default-registry.io/default-repo@sha256:lifecycle-image-digest\",\"serviceAccountRef\":{\"namespace\":\"some-namespace\",\"name\":\"some-serviceaccount\"}},\"status\":{\"image\":{},\"api\":{},\"apis\":{\"buildpack\":{\"deprecated\":null,\"supported\":null},\"platform\":{\"deprecated\":null,\"supported\":null}}}}"
         }
@@ -1170,6 +1569,7 @@ Importing ClusterBuilder 'default'... (dry run with image upload)
         "name": "my-buildpack",
         "creationTimestamp": null,
         "annotations": {
+            "kpack.io/import-source": "{\"name\":\"my-buildpack\",\"image\":\"some-registry.io/repo/standalone-buildpack\"}",
             "kpack.io/import-timestamp": "2006-01-02T15:04:05Z",
             "kubectl.kubernetes.io/last-applied-configuration": "{\"kind\":\"ClusterBuildpack\",\"apiVersion\":\"kpack.io/v1alpha2\",\"metadata\":{\"name\":\"my-buildpack\",\"creationTimestamp\":null},\"spec\":{\"image\":\"default-registry.io/default-repo@sha256:standalone-buildpack-digest\",\"serviceAccountRef\":{\"namespace\":\"some-namespace\",\"name\":\"some-serviceaccount\"}},\"status\":{}}"
         }
@@ -1189,6 +1589,7 @@ Importing ClusterBuilder 'default'... (dry run with image upload)
         "name": "store-name",
         "creationTimestamp": null,
         "annotations": {
+            "kpack.io/import-source": "{\"name\":\"store-name\",\"sources\":[{\"image\":\"some-registry.io/repo/buildpack-image\"}],\"relocated\":{\"default-registry.io/default-repo@sha256:buildpack-image-digest\":\"some-registry.io/repo/buildpack-image\"}}",
             "kpack.io/import-timestamp": "2006-01-02T15:04:05Z",
             "kubectl.kubernetes.io/last-applied-configuration": "{\"kind\":\"ClusterStore\",\"apiVersion\":\"kpack.io/v1alpha2\",\"metadata\":{\"name\":\"store-name\",\"creationTimestamp\":null},\"spec\":{\"sources\":[{\"image\":\"default-registry.io/default-repo@sha256:buildpack-image-digest\"}],\"serviceAccountRef\":{\"namespace\":\"some-namespace\",\"name\":\"some-serviceaccount\"}},\"status\":{}}"
         }
@@ -1212,6 +1613,7 @@ Importing ClusterBuilder 'default'... (dry run with image upload)
         "name": "stack-name",
         "creationTimestamp": null,
         "annotations": {
+            "kpack.io/import-source": "{\"name\":\"stack-name\",\"buildImage\":{\"image\":\"some-registry.io/repo/build-image\"},\"runImage\":{\"image\":\"some-registry.io/repo/run-image\"}}",
             "kpack.io/import-timestamp": "2006-01-02T15:04:05Z"
         }
     },
@@ -1239,6 +1641,7 @@ Importing ClusterBuilder 'default'... (dry run with image upload)
         "name": "default",
         "creationTimestamp": null,
         "annotations": {
+            "kpack.io/import-source": "{\"name\":\"default\",\"buildImage\":{\"image\":\"some-registry.io/repo/build-image\"},\"runImage\":{\"image\":\"some-registry.io/repo/run-image\"}}",
             "kpack.io/import-timestamp": "2006-01-02T15:04:05Z"
         }
     },
@@ -1388,7 +1791,7 @@ Importing ClusterBuilder 'default'... (dry run with image upload)
 					defaultBuilder,
 				},
 				ExpectPatches: []string{
-					`{"metadata":{"annotations":{"kpack.io/import-timestamp":"2006-01-02T15:04:05Z","kubectl.kubernetes.io/last-applied-configuration":"{\"kind\":\"ClusterLifecycle\",\"apiVersion\":\"kpack.io/v1alpha2\",\"metadata\":{\"name\":\"default\",\"creationTimestamp\":null},\"spec\":{\"image\":\"default-registry.io/default-repo@sha256:lifecycle-image-digest\",\"serviceAccountRef\":{\"namespace\":\"some-namespace\",\"name\":\"some-serviceaccount\"}},\"status\":{\"image\":{},\"api\":{},\"apis\":{\"buildpack\":{\"deprecated\":null,\"supported\":null},\"platform\":{\"deprecated\":null,\"supported\":null}}}}"}},"spec":{"image":"default-registry.io/default-repo@sha256:lifecycle-image-digest","serviceAccountRef":{"name":"some-serviceaccount","namespace":"some-namespace"}}}`,
+					`{"metadata":{"annotations":{"kpack.io/import-source":"{\"name\":\"default\",\"image\":\"some-registry.io/repo/lifecycle-image\"}","kpack.io/import-timestamp":"2006-01-02T15:04:05Z","kubectl.kubernetes.io/last-applied-configuration":"{\"kind\":\"ClusterLifecycle\",\"apiVersion\":\"kpack.io/v1alpha2\",\"metadata\":{\"name\":\"default\",\"creationTimestamp\":null},\"spec\":{\"image\":\"default-registry.io/default-repo@sha256:lifecycle-image-digest\",\"serviceAccountRef\":{\"namespace\":\"some-namespace\",\"name\":\"some-serviceaccount\"}},\"status\":{\"image\":{},\"api\":{},\"apis\":{\"buildpack\":{\"deprecated\":null,\"supported\":null},\"platform\":{\"deprecated\":null,\"supported\":null}}}}"}},"spec":{"image":"default-registry.io/default-repo@sha256:lifecycle-image-digest","serviceAccountRef":{"name":"some-serviceaccount","namespace":"some-namespace"}}}`,
 				},
 			}.TestK8sAndKpack(t, cmdFunc)
 		})
@@ -0,0 +1,142 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/commands"
+	"github.com/buildpacks-community/kpack-cli/pkg/config"
+	"github.com/buildpacks-community/kpack-cli/pkg/dockercreds"
+	"github.com/buildpacks-community/kpack-cli/pkg/gc"
+	"github.com/buildpacks-community/kpack-cli/pkg/k8s"
+	"github.com/buildpacks-community/kpack-cli/pkg/registry"
+)
+
+type ConfirmationProvider interface {
+	Confirm(message string, okayResponses ...string) (bool, error)
+}
+
+func NewGCCommand(clientSetProvider k8s.ClientSetProvider, rup registry.UtilProvider, confirmationProvider ConfirmationProvider) *cobra.Command {
+	var (
+		keep   int
+		force  bool
+		tlsCfg registry.TLSConfig
+	)
+
+	cmd := &cobra.Command{
+		Use:   "gc",
+		Short: "Remove unused images from the default repository",
+		Long: `Remove the images uploaded to the kp default repository that are no longer used by the cluster.
+
+Every image kp uploads to the default repository is tagged with the time of the upload. Images with such a tag are removed
+unless they are referenced by a clusterlifecycle, clusterbuildpack, buildpack, clusterstore, clusterstack, clusterbuilder or builder,
+or are the source of an image resource. Images that also have other tags, like the tags of builders, only lose their
+timestamp tags.
+
+Use --keep to keep the most recently uploaded unused images, as a way to roll back to them.
+
+Env vars can be used for registry auth as described in https://github.com/buildpacks-community/kpack-cli/blob/main/docs/auth.md`,
+		Example: `kp registry gc --dry-run
+kp registry gc --keep 5 --force`,
+		Args:         commands.ExactArgsWithUsage(0),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if keep < 0 {
+				return errors.New("keep must be at least 0")
+			}
+
+			cs, err := clientSetProvider.GetClientSet("")
+			if err != nil {
+				return err
+			}
+
+			ch, err := commands.NewCommandHelper(cmd)
+			if err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+
+			repository, err := config.NewKpConfigProvider(cs.K8sClient).GetKpConfig(ctx).DefaultRepository()
+			if err != nil {
+				return err
+			}
+
+			if err := ch.PrintStatus("Listing images in '%s'...", repository); err != nil {
+				return err
+			}
+
+			cleaner := rup.Cleaner(tlsCfg)
+			tags, err := cleaner.Tags(dockercreds.DefaultKeychain, repository)
+			if err != nil {
+				return err
+			}
+
+			referenced, err := gc.ReferencedImages(ctx, cs.KpackClient)
+			if err != nil {
+				return err
+			}
+
+			plan, err := gc.NewPlan(repository, tags, referenced, keep)
+			if err != nil {
+				return err
+			}
+
+			if err := ch.Printlnf("\t%d in use, %d kept, %d to delete, %d tags to remove", plan.Referenced, plan.Kept, len(plan.Delete), len(plan.Untag)); err != nil {
+				return err
+			}
+
+			if len(plan.Delete) == 0 && len(plan.Untag) == 0 {
+				return ch.PrintResult("No images to remove")
+			}
+
+			if !ch.IsDryRun() && !force {
+				confirmed, err := confirmationProvider.Confirm("Confirm removal with y:")
+				if err != nil {
+					return err
+				}
+
+				if !confirmed {
+					_, err = fmt.Fprintln(cmd.OutOrStdout(), "Skipping image removal")
+					return err
+				}
+			}
+
+			for _, ref := range plan.Delete {
+				if err := remove(ch, cleaner, "Deleting", ref); err != nil {
+					return err
+				}
+			}
+
+			for _, ref := range plan.Untag {
+				if err := remove(ch, cleaner, "Untagging", ref); err != nil {
+					return err
+				}
+			}
+
+			return ch.PrintResult("Deleted %d images and removed %d tags", len(plan.Delete), len(plan.Untag))
+		},
+	}
+	cmd.Flags().IntVar(&keep, "keep", 0, "number of the most recently uploaded unused images to keep")
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "remove images without confirmation")
+	cmd.Flags().Bool(commands.DryRunFlag, false, "list the images that would be removed without removing them")
+	commands.SetTLSFlags(cmd, &tlsCfg)
+	return cmd
+}
+
+func remove(ch *commands.CommandHelper, cleaner registry.Cleaner, action, ref string) error {
+	if err := ch.Printlnf("\t%s '%s'", action, ref); err != nil {
+		return err
+	}
+
+	if !ch.CanChangeState() {
+		return nil
+	}
+
+	return cleaner.Delete(dockercreds.DefaultKeychain, ref)
+}
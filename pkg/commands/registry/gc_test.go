@@ -0,0 +1,141 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package registry_test
+
+import (
+	"testing"
+
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	kpackfakes "github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
+	"github.com/sclevine/spec"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfakes "k8s.io/client-go/kubernetes/fake"
+
+	commandsfakes "github.com/buildpacks-community/kpack-cli/pkg/commands/fakes"
+	registrycmds "github.com/buildpacks-community/kpack-cli/pkg/commands/registry"
+	registryfakes "github.com/buildpacks-community/kpack-cli/pkg/registry/fakes"
+	"github.com/buildpacks-community/kpack-cli/pkg/testhelpers"
+)
+
+func TestGCCommand(t *testing.T) {
+	spec.Run(t, "TestGCCommand", testGCCommand)
+}
+
+func testGCCommand(t *testing.T, when spec.G, it spec.S) {
+	const (
+		repo      = "default-registry.io/default-repo"
+		oldDigest = "sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+		newDigest = "sha256:bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+	)
+
+	var (
+		cleaner              *registryfakes.Cleaner
+		confirmationProvider *commandsfakes.FakeConfirmationProvider
+	)
+
+	config := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kp-config",
+			Namespace: "kpack",
+		},
+		Data: map[string]string{
+			"default.repository": repo,
+		},
+	}
+
+	stack := &v1alpha2.ClusterStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "some-stack"},
+		Spec: v1alpha2.ClusterStackSpec{
+			BuildImage: v1alpha2.ClusterStackSpecImage{Image: repo + "@" + newDigest},
+			RunImage:   v1alpha2.ClusterStackSpecImage{Image: "some-registry.io/run@" + newDigest},
+		},
+	}
+
+	it.Before(func() {
+		cleaner = &registryfakes.Cleaner{
+			Repositories: map[string]map[string]string{
+				repo: {
+					"20240101000000": oldDigest,
+					"20240201000000": newDigest,
+				},
+			},
+		}
+		confirmationProvider = commandsfakes.NewFakeConfirmationProvider(true, nil)
+	})
+
+	cmdFunc := func(k8sClientSet *k8sfakes.Clientset, kpackClientSet *kpackfakes.Clientset) *cobra.Command {
+		clientSetProvider := testhelpers.GetFakeClusterProvider(k8sClientSet, kpackClientSet)
+		return registrycmds.NewGCCommand(clientSetProvider, registryfakes.UtilProvider{FakeCleaner: cleaner}, confirmationProvider)
+	}
+
+	it("deletes the images that are no longer referenced after confirmation", func() {
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{config, stack},
+			ExpectedOutput: `Listing images in 'default-registry.io/default-repo'...
+	1 in use, 0 kept, 1 to delete, 0 tags to remove
+	Deleting 'default-registry.io/default-repo@sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa'
+Deleted 1 images and removed 0 tags
+`,
+		}.TestK8sAndKpack(t, cmdFunc)
+
+		require.NoError(t, confirmationProvider.WasRequestedWithMsg("Confirm removal with y:"))
+		require.Equal(t, []string{repo + "@" + oldDigest}, cleaner.Deleted)
+	})
+
+	it("does not delete images when not confirmed", func() {
+		confirmationProvider = commandsfakes.NewFakeConfirmationProvider(false, nil)
+
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{config, stack},
+			ExpectedOutput: `Listing images in 'default-registry.io/default-repo'...
+	1 in use, 0 kept, 1 to delete, 0 tags to remove
+Skipping image removal
+`,
+		}.TestK8sAndKpack(t, cmdFunc)
+
+		require.Empty(t, cleaner.Deleted)
+	})
+
+	it("keeps the most recently uploaded unused images with --keep", func() {
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{config},
+			Args:    []string{"--keep", "1", "--force"},
+			ExpectedOutput: `Listing images in 'default-registry.io/default-repo'...
+	0 in use, 1 kept, 1 to delete, 0 tags to remove
+	Deleting 'default-registry.io/default-repo@sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa'
+Deleted 1 images and removed 0 tags
+`,
+		}.TestK8sAndKpack(t, cmdFunc)
+
+		require.False(t, confirmationProvider.WasRequested())
+		require.Equal(t, []string{repo + "@" + oldDigest}, cleaner.Deleted)
+	})
+
+	it("does not delete images with --dry-run", func() {
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{config, stack},
+			Args:    []string{"--dry-run"},
+			ExpectedOutput: `Listing images in 'default-registry.io/default-repo'... (dry run)
+	1 in use, 0 kept, 1 to delete, 0 tags to remove
+	Deleting 'default-registry.io/default-repo@sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa'
+Deleted 1 images and removed 0 tags (dry run)
+`,
+		}.TestK8sAndKpack(t, cmdFunc)
+
+		require.False(t, confirmationProvider.WasRequested())
+		require.Empty(t, cleaner.Deleted)
+	})
+
+	it("fails without a default repository", func() {
+		testhelpers.CommandTest{
+			ExpectErr: true,
+			ExpectedErrorOutput: `Error: failed to get default repository: use "kp config default-repository" to set
+`,
+		}.TestK8sAndKpack(t, cmdFunc)
+	})
+}
@@ -43,9 +43,13 @@ The flags for this command determine the type of secret that will be created:
   "--registry" and "--registry-user" to create credentials for other registries.
   Use the "REGISTRY_PASSWORD" env var to bypass the password prompt.
 
+  "--docker-config" to copy the credentials of a registry from the local docker config or its credential helper.
+  Repeat the flag to store the credentials of multiple registries in a single secret.
+
   "--git-url" and "--git-ssh-key" to create SSH based git credentials.
   "--git-url" should not contain the repository path (eg. git@github.com not git@github.com:my/repo)
   Alternatively, provided the credentials in the "GIT_SSH_KEY_PATH" env var instead of the "--git-ssh-key" flag.
+  Optionally, add "--git-known-hosts" with a known_hosts file to verify the git server host key.
 
   "--git-url" and "--git-user" to create Basic Auth based git credentials.
   "--git-url" should not contain the repository path (eg. https://github.com not https://github.com/my/repo) 
@@ -53,7 +57,9 @@ The flags for this command determine the type of secret that will be created:
 		Example: `kp secret create my-docker-hub-creds --dockerhub dockerhub-id
 kp secret create my-gcr-creds --gcr /path/to/gcr/service-account.json
 kp secret create my-registry-cred --registry example-registry.io --registry-user my-registry-user
+kp secret create my-registry-creds --docker-config example-registry.io --docker-config other-registry.io
 kp secret create my-git-ssh-cred --git-url git@github.com --git-ssh-key /path/to/git/ssh-private-key.pem
+kp secret create my-git-ssh-cred --git-url git@github.com --git-ssh-key /path/to/git/ssh-private-key.pem --git-known-hosts ~/.ssh/known_hosts
 kp secret create my-git-cred --git-url https://github.com --git-user my-git-user`,
 		Args:         commands.ExactArgsWithUsage(1),
 		SilenceUsage: true,
@@ -68,13 +74,7 @@ kp secret create my-git-cred --git-url https://github.com --git-user my-git-user
 				return err
 			}
 
-			if val, ok := os.LookupEnv("GCR_SERVICE_ACCOUNT_PATH"); ok {
-				secretFactory.GcrServiceAccountFile = val
-			}
-
-			if val, ok := os.LookupEnv("GIT_SSH_KEY_PATH"); ok {
-				secretFactory.GitSshKeyFile = val
-			}
+			setFactoryFromEnv(secretFactory)
 
 			secret, target, err := secretFactory.MakeSecret(args[0], cs.Namespace)
 			if err != nil {
@@ -136,16 +136,32 @@ kp secret create my-git-cred --git-url https://github.com --git-user my-git-user
 	}
 
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "kubernetes namespace")
+	setFactoryFlags(cmd, secretFactory)
+	cmd.Flags().StringVar(&serviceAccount, "service-account", "default", "service account name to use")
+	commands.SetDryRunOutputFlags(cmd)
+	return cmd
+}
+
+func setFactoryFlags(cmd *cobra.Command, secretFactory *secret.Factory) {
 	cmd.Flags().StringVarP(&secretFactory.DockerhubId, "dockerhub", "", "", "dockerhub id")
 	cmd.Flags().StringVarP(&secretFactory.Registry, "registry", "", "", "registry")
 	cmd.Flags().StringVarP(&secretFactory.RegistryUser, "registry-user", "", "", "registry user")
+	cmd.Flags().StringArrayVar(&secretFactory.DockerConfigRegistries, "docker-config", []string{}, "registry to copy the credentials of from the local docker config (can be set more than once)")
 	cmd.Flags().StringVarP(&secretFactory.GcrServiceAccountFile, "gcr", "", "", "path to a file containing the GCR service account")
 	cmd.Flags().StringVarP(&secretFactory.GitUrl, "git-url", "", "", "git url")
 	cmd.Flags().StringVarP(&secretFactory.GitSshKeyFile, "git-ssh-key", "", "", "path to a file containing the GitUrl SSH private key")
+	cmd.Flags().StringVar(&secretFactory.GitKnownHostsFile, "git-known-hosts", "", "path to a known_hosts file for the git SSH server")
 	cmd.Flags().StringVarP(&secretFactory.GitUser, "git-user", "", "", "git user")
-	cmd.Flags().StringVar(&serviceAccount, "service-account", "default", "service account name to use")
-	commands.SetDryRunOutputFlags(cmd)
-	return cmd
+}
+
+func setFactoryFromEnv(secretFactory *secret.Factory) {
+	if val, ok := os.LookupEnv("GCR_SERVICE_ACCOUNT_PATH"); ok {
+		secretFactory.GcrServiceAccountFile = val
+	}
+
+	if val, ok := os.LookupEnv("GIT_SSH_KEY_PATH"); ok {
+		secretFactory.GitSshKeyFile = val
+	}
 }
 
 func updateManagedSecretsAnnotation(err error, sa *corev1.ServiceAccount, name, target string) error {
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/pkg/errors"
 	"github.com/sclevine/spec"
 	"github.com/spf13/cobra"
@@ -36,6 +37,10 @@ func testSecretCreateCommand(t *testing.T, when spec.G, it spec.S) {
 
 	factory := &secret.Factory{
 		CredentialFetcher: fetcher,
+		Keychain: fakeKeychain{
+			"registry.io":       {Username: "some-user", Password: "some-password"},
+			"other-registry.io": {Username: "other-user", Password: "other-password"},
+		},
 	}
 
 	cmdFunc := func(k8sClient *fake.Clientset) *cobra.Command {
@@ -228,6 +233,36 @@ func testSecretCreateCommand(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("creating a secret from the docker config", func() {
+			it("creates a secret with the credentials of each registry and updates the default service account", func() {
+				expectedDockerSecret := &corev1.Secret{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-registry-creds",
+						Namespace: namespace,
+					},
+					Data: map[string][]byte{
+						corev1.DockerConfigJsonKey: []byte(`{"auths":{"other-registry.io":{"username":"other-user","password":"other-password","auth":"b3RoZXItdXNlcjpvdGhlci1wYXNzd29yZA=="},"registry.io":{"username":"some-user","password":"some-password","auth":"c29tZS11c2VyOnNvbWUtcGFzc3dvcmQ="}}}`),
+					},
+					Type: corev1.SecretTypeDockerConfigJson,
+				}
+
+				testhelpers.CommandTest{
+					Objects: []runtime.Object{
+						defaultNamespacedServiceAccount,
+					},
+					Args: []string{"my-registry-creds", "--docker-config", "registry.io", "--docker-config", "other-registry.io", "-n", namespace},
+					ExpectedOutput: `Secret "my-registry-creds" created for registry.io,other-registry.io
+`,
+					ExpectCreates: []runtime.Object{
+						expectedDockerSecret,
+					},
+					ExpectPatches: []string{
+						`{"imagePullSecrets":[{"name":"my-registry-creds"}],"metadata":{"annotations":{"kpack.io/managedSecret":"{\"my-registry-creds\":\"registry.io,other-registry.io\"}"}},"secrets":[{"name":"my-registry-creds"}]}`,
+					},
+				}.TestK8s(t, cmdFunc)
+			})
+		})
+
 		when("creating a gcr registry secret", func() {
 			var (
 				gcrServiceAccountFile  = "./testdata/gcr-service-account.json"
@@ -296,6 +331,38 @@ func testSecretCreateCommand(t *testing.T, when spec.G, it spec.S) {
 					},
 					Args: []string{secretName, "--git-url", gitRepo, "--git-ssh-key", gitSshFile, "-n", namespace},
 					ExpectedOutput: `Secret "my-git-ssh-cred" created for git@github.com
+`,
+					ExpectCreates: []runtime.Object{
+						expectedGitSecret,
+					},
+					ExpectPatches: []string{
+						`{"metadata":{"annotations":{"kpack.io/managedSecret":"{\"my-git-ssh-cred\":\"git@github.com\"}"}},"secrets":[{"name":"my-git-ssh-cred"}]}`,
+					},
+				}.TestK8s(t, cmdFunc)
+			})
+
+			it("adds known hosts to the secret", func() {
+				expectedGitSecret := &corev1.Secret{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      secretName,
+						Namespace: namespace,
+						Annotations: map[string]string{
+							secret.GitAnnotation: gitRepo,
+						},
+					},
+					Data: map[string][]byte{
+						corev1.SSHAuthPrivateKey:    []byte("some git ssh key"),
+						secret.SSHAuthKnownHostsKey: []byte("github.com ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIOMqqnkVzrm0SdG6UOoqKLsabgH5C9okWi0dh2l9GKJl\n"),
+					},
+					Type: corev1.SecretTypeSSHAuth,
+				}
+
+				testhelpers.CommandTest{
+					Objects: []runtime.Object{
+						defaultNamespacedServiceAccount,
+					},
+					Args: []string{secretName, "--git-url", gitRepo, "--git-ssh-key", gitSshFile, "--git-known-hosts", "./testdata/known_hosts", "-n", namespace},
+					ExpectedOutput: `Secret "my-git-ssh-cred" created for git@github.com
 `,
 					ExpectCreates: []runtime.Object{
 						expectedGitSecret,
@@ -748,3 +815,12 @@ func (f *fakeCredentialFetcher) FetchPassword(envVar, _ string) (string, error)
 	}
 	return "", errors.Errorf("secret for %s not found", envVar)
 }
+
+type fakeKeychain map[string]authn.AuthConfig
+
+func (k fakeKeychain) Resolve(r authn.Resource) (authn.Authenticator, error) {
+	if cfg, ok := k[r.RegistryStr()]; ok {
+		return authn.FromConfig(cfg), nil
+	}
+	return authn.Anonymous, nil
+}
@@ -50,7 +50,7 @@ The service account defaults to "default".`,
 			}
 
 			if len(serviceAccount.Secrets) == 0 && len(serviceAccount.ImagePullSecrets) == 0 {
-				return errors.Errorf("no secrets found in %q namespace for %q service account", cs.Namespace, serviceAccount.Name)
+				return commands.EmptyList(cmd, "no secrets found in %q namespace for %q service account", cs.Namespace, serviceAccount.Name)
 			} else {
 				return displaySecretsTable(cmd, serviceAccount, secretsList)
 			}
@@ -59,16 +59,35 @@ The service account defaults to "default".`,
 
 	command.Flags().StringVarP(&namespace, "namespace", "n", "", "kubernetes namespace")
 	command.Flags().StringVar(&serviceAccount, "service-account", "default", "service account to list secrets for")
+	commands.SetListOutputFlag(&command)
 
 	return &command
 }
 
+type secretRow struct {
+	Name      string `json:"name"`
+	Target    string `json:"target"`
+	Available bool   `json:"available"`
+}
+
 func displaySecretsTable(cmd *cobra.Command, sa *corev1.ServiceAccount, secretsList *corev1.SecretList) error {
 	secretNames, err := getServiceAccountSecretsInfo(sa, secretsList)
 	if err != nil {
 		return errors.WithMessage(err, "could not retrieve secrets information from service account.")
 	}
-	writer, err := commands.NewTableWriter(cmd.OutOrStdout(), "NAME", "TARGET", "AVAILABLE")
+
+	printer, err := commands.NewDataPrinter(cmd)
+	if err != nil {
+		return err
+	} else if printer != nil {
+		rows := make([]secretRow, 0, len(secretNames))
+		for _, secret := range secretNames {
+			rows = append(rows, secretRow{Name: secret.name, Target: secret.target, Available: secret.isAvailable})
+		}
+		return printer.Print(cmd.OutOrStdout(), rows)
+	}
+
+	writer, err := commands.NewCommandTableWriter(cmd, "NAME", "TARGET", "AVAILABLE")
 	if err != nil {
 		return err
 	}
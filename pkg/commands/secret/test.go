@@ -0,0 +1,211 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package secret
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/commands"
+	"github.com/buildpacks-community/kpack-cli/pkg/git"
+	"github.com/buildpacks-community/kpack-cli/pkg/k8s"
+	"github.com/buildpacks-community/kpack-cli/pkg/registry"
+	"github.com/buildpacks-community/kpack-cli/pkg/secret"
+)
+
+func NewTestCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
+	var (
+		namespace       string
+		serviceAccount  string
+		gitRepositories []string
+		insecureHostKey bool
+		tlsCfg          registry.TLSConfig
+	)
+
+	command := cobra.Command{
+		Use:   "test [<name>...]",
+		Short: "Test the credentials of secrets attached to a service account",
+		Long: `Test the credentials of the secrets created with kp for a service account in the provided namespace.
+
+Registry credentials are tested by authenticating to each registry of the secret.
+Git credentials are tested by listing the references of each repository provided with --git-repository that the secret applies to,
+as done by "git ls-remote" over HTTPS or SSH. Git secrets without a matching repository are skipped.
+SSH host keys are verified with the known hosts of the secret or, when it has none, with the default known_hosts files.
+Use --insecure-ignore-host-key to skip host key verification for secrets without known hosts, as done by kpack.
+
+Credentials are never printed. The command fails when any credential is rejected.
+
+The namespace defaults to the kubernetes current-context namespace.
+
+The service account defaults to "default".`,
+		Example: `kp secret test
+kp secret test my-registry-cred -n my-namespace
+kp secret test --git-repository https://github.com/my-org/my-repo --git-repository git@github.com:my-org/my-repo.git`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cs, err := clientSetProvider.GetClientSet(namespace)
+			if err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+
+			sa, err := cs.K8sClient.CoreV1().ServiceAccounts(cs.Namespace).Get(ctx, serviceAccount, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+
+			managedSecrets, err := readManagedSecrets(sa)
+			if err != nil {
+				return err
+			}
+
+			names, err := secretsToTest(managedSecrets, args)
+			if err != nil {
+				return err
+			} else if len(names) == 0 {
+				return errors.Errorf("no secrets created with kp found in %q namespace for %q service account", cs.Namespace, sa.Name)
+			}
+
+			var rows []secretTestRow
+			for _, name := range names {
+				s, err := cs.K8sClient.CoreV1().Secrets(cs.Namespace).Get(ctx, name, metav1.GetOptions{})
+				if k8serrors.IsNotFound(err) {
+					rows = append(rows, failedRow(name, managedSecrets[name], errors.New("secret not found")))
+					continue
+				} else if err != nil {
+					return err
+				}
+
+				rows = append(rows, testSecret(ctx, s, tlsCfg, gitRepositories, insecureHostKey)...)
+			}
+
+			if err := displaySecretTestRows(cmd, rows); err != nil {
+				return err
+			}
+
+			failed := 0
+			for _, row := range rows {
+				if row.failed {
+					failed++
+				}
+			}
+			if failed > 0 {
+				return errors.Errorf("%d of %d credentials failed", failed, len(rows))
+			}
+			return nil
+		},
+	}
+
+	command.Flags().StringVarP(&namespace, "namespace", "n", "", "kubernetes namespace")
+	command.Flags().StringVar(&serviceAccount, "service-account", "default", "service account to test secrets for")
+	command.Flags().StringArrayVar(&gitRepositories, "git-repository", nil, "git repository to test the git secrets with (can be set more than once)")
+	command.Flags().BoolVar(&insecureHostKey, "insecure-ignore-host-key", false, "skip ssh host key verification for git secrets without known hosts")
+	commands.SetTLSFlags(&command, &tlsCfg)
+	commands.SetListOutputFlag(&command)
+
+	return &command
+}
+
+type secretTestRow struct {
+	Name   string `json:"name"`
+	Target string `json:"target"`
+	Result string `json:"result"`
+	failed bool
+}
+
+func secretsToTest(managedSecrets map[string]string, args []string) ([]string, error) {
+	var names []string
+	if len(args) == 0 {
+		for name := range managedSecrets {
+			names = append(names, name)
+		}
+	}
+
+	for _, name := range args {
+		if _, ok := managedSecrets[name]; !ok {
+			return nil, errors.Errorf("secret %q was not created with kp for the service account", name)
+		}
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names, nil
+}
+
+func testSecret(ctx context.Context, s *corev1.Secret, tlsCfg registry.TLSConfig, gitRepositories []string, insecureHostKey bool) []secretTestRow {
+	switch s.Type {
+	case corev1.SecretTypeDockerConfigJson:
+		var configJson secret.DockerConfigJson
+		if err := json.Unmarshal(s.Data[corev1.DockerConfigJsonKey], &configJson); err != nil {
+			return []secretTestRow{failedRow(s.Name, "", errors.New("invalid docker config"))}
+		}
+
+		registries := make([]string, 0, len(configJson.Auths))
+		for r := range configJson.Auths {
+			registries = append(registries, r)
+		}
+		sort.Strings(registries)
+
+		var rows []secretTestRow
+		for _, r := range registries {
+			rows = append(rows, resultRow(s.Name, r, registry.CheckAuth(ctx, tlsCfg, r, configJson.Auths[r])))
+		}
+		return rows
+	case corev1.SecretTypeBasicAuth, corev1.SecretTypeSSHAuth:
+		var rows []secretTestRow
+		for _, repository := range gitRepositories {
+			if git.SecretApplies(s, repository) {
+				rows = append(rows, resultRow(s.Name, repository, git.CheckAccess(ctx, s, repository, insecureHostKey)))
+			}
+		}
+
+		if len(rows) == 0 {
+			return []secretTestRow{{Name: s.Name, Target: s.Annotations[secret.GitAnnotation], Result: "skipped: no --git-repository for this secret"}}
+		}
+		return rows
+	default:
+		return []secretTestRow{{Name: s.Name, Result: "skipped: unsupported secret type " + string(s.Type)}}
+	}
+}
+
+func resultRow(name, target string, err error) secretTestRow {
+	if err != nil {
+		return failedRow(name, target, err)
+	}
+	return secretTestRow{Name: name, Target: target, Result: "ok"}
+}
+
+func failedRow(name, target string, err error) secretTestRow {
+	return secretTestRow{Name: name, Target: target, Result: "failed: " + err.Error(), failed: true}
+}
+
+func displaySecretTestRows(cmd *cobra.Command, rows []secretTestRow) error {
+	printer, err := commands.NewDataPrinter(cmd)
+	if err != nil {
+		return err
+	} else if printer != nil {
+		return printer.Print(cmd.OutOrStdout(), rows)
+	}
+
+	writer, err := commands.NewTableWriter(cmd.OutOrStdout(), "NAME", "TARGET", "RESULT")
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		if err := writer.AddRow(row.Name, row.Target, row.Result); err != nil {
+			return err
+		}
+	}
+
+	return writer.Write()
+}
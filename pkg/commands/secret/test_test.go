@@ -0,0 +1,127 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package secret_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/commands"
+	secretcmds "github.com/buildpacks-community/kpack-cli/pkg/commands/secret"
+	"github.com/buildpacks-community/kpack-cli/pkg/testhelpers"
+)
+
+func TestSecretTestCommand(t *testing.T) {
+	spec.Run(t, "TestSecretTestCommand", testSecretTestCommand)
+}
+
+func testSecretTestCommand(t *testing.T, when spec.G, it spec.S) {
+	const namespace = "some-namespace"
+
+	cmdFunc := func(k8sClient *fake.Clientset) *cobra.Command {
+		clientSetProvider := testhelpers.GetFakeK8sProvider(k8sClient, namespace)
+		return secretcmds.NewTestCommand(clientSetProvider)
+	}
+
+	var (
+		registryHost string
+		gitUrl       string
+		objects      []runtime.Object
+	)
+
+	registrySecret := func(name, password string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: v1.ObjectMeta{Name: name, Namespace: namespace},
+			Data: map[string][]byte{
+				corev1.DockerConfigJsonKey: []byte(fmt.Sprintf(`{"auths":{%q:{"username":"some-user","password":%q}}}`, registryHost, password)),
+			},
+			Type: corev1.SecretTypeDockerConfigJson,
+		}
+	}
+
+	it.Before(func() {
+		registryHost = testhelpers.NewBasicAuthRegistry(t, "some-user", "some-password")
+		gitUrl = testhelpers.NewGitServer(t, "some-user", "some-password")
+
+		objects = []runtime.Object{
+			&corev1.ServiceAccount{
+				ObjectMeta: v1.ObjectMeta{
+					Name:      "default",
+					Namespace: namespace,
+					Annotations: map[string]string{
+						"kpack.io/managedSecret": fmt.Sprintf(`{"bad-registry-cred":%q,"git-cred":%q,"registry-cred":%q}`, registryHost, gitUrl, registryHost),
+					},
+				},
+			},
+			registrySecret("registry-cred", "some-password"),
+			registrySecret("bad-registry-cred", "wrong-password"),
+			&corev1.Secret{
+				ObjectMeta: v1.ObjectMeta{
+					Name:        "git-cred",
+					Namespace:   namespace,
+					Annotations: map[string]string{"kpack.io/git": gitUrl},
+				},
+				Data: map[string][]byte{
+					corev1.BasicAuthUsernameKey: []byte("some-user"),
+					corev1.BasicAuthPasswordKey: []byte("some-password"),
+				},
+				Type: corev1.SecretTypeBasicAuth,
+			},
+		}
+	})
+
+	it("reports the credentials that work", func() {
+		testhelpers.CommandTest{
+			Objects: objects,
+			Args:    []string{"registry-cred", "git-cred", "--git-repository", gitUrl + "/some-repo.git"},
+			ExpectedOutput: tableOutput(t,
+				[]string{"git-cred", gitUrl + "/some-repo.git", "ok"},
+				[]string{"registry-cred", registryHost, "ok"},
+			),
+		}.TestK8s(t, cmdFunc)
+	})
+
+	it("fails when a credential is rejected", func() {
+		testhelpers.CommandTest{
+			Objects: objects,
+			Args:    []string{},
+			ExpectedOutput: tableOutput(t,
+				[]string{"bad-registry-cred", registryHost, "failed: invalid credentials"},
+				[]string{"git-cred", gitUrl, "skipped: no --git-repository for this secret"},
+				[]string{"registry-cred", registryHost, "ok"},
+			),
+			ExpectErr:           true,
+			ExpectedErrorOutput: "Error: 1 of 3 credentials failed\n",
+		}.TestK8s(t, cmdFunc)
+	})
+
+	it("errors when a secret was not created with kp", func() {
+		testhelpers.CommandTest{
+			Objects:             objects,
+			Args:                []string{"other-secret"},
+			ExpectErr:           true,
+			ExpectedErrorOutput: "Error: secret \"other-secret\" was not created with kp for the service account\n",
+		}.TestK8s(t, cmdFunc)
+	})
+}
+
+func tableOutput(t *testing.T, rows ...[]string) string {
+	out := &bytes.Buffer{}
+	writer, err := commands.NewTableWriter(out, "NAME", "TARGET", "RESULT")
+	require.NoError(t, err)
+	for _, row := range rows {
+		require.NoError(t, writer.AddRow(row...))
+	}
+	require.NoError(t, writer.Write())
+	return out.String()
+}
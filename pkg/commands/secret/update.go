@@ -0,0 +1,250 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package secret
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pivotal/kpack/pkg/client/clientset/versioned"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/build"
+	"github.com/buildpacks-community/kpack-cli/pkg/commands"
+	"github.com/buildpacks-community/kpack-cli/pkg/k8s"
+	"github.com/buildpacks-community/kpack-cli/pkg/secret"
+)
+
+func NewUpdateCommand(clientSetProvider k8s.ClientSetProvider, secretFactory *secret.Factory) *cobra.Command {
+	var (
+		namespace      string
+		serviceAccount string
+		rebuild        bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "update <name>",
+		Short: "Update the credentials of a secret",
+		Long: `Update the registry or git credentials of an existing secret in the provided namespace.
+
+The credentials are replaced in place, so the secret stays attached to the service account while it is rotated.
+Only secrets created by "kp secret create" for the service account can be updated.
+The flags are the same as for "kp secret create" and must create a secret of the same type and for the same registry or git url as the existing one.
+
+The namespace defaults to the kubernetes current-context namespace.
+
+The service account defaults to the "default" service account.
+
+Use --rebuild to trigger a build of every image resource in the namespace that uses the service account.`,
+		Example: `kp secret update my-registry-cred --registry example-registry.io --registry-user my-registry-user
+kp secret update my-git-cred --git-url https://github.com --git-user my-git-user --rebuild`,
+		Args:         commands.ExactArgsWithUsage(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cs, err := clientSetProvider.GetClientSet(namespace)
+			if err != nil {
+				return err
+			}
+
+			ch, err := commands.NewCommandHelper(cmd)
+			if err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+
+			existing, err := cs.K8sClient.CoreV1().Secrets(cs.Namespace).Get(ctx, args[0], metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+
+			sa, err := cs.K8sClient.CoreV1().ServiceAccounts(cs.Namespace).Get(ctx, serviceAccount, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+
+			managedSecrets, err := readManagedSecrets(sa)
+			if err != nil {
+				return err
+			}
+
+			existingTarget, ok := managedSecrets[existing.Name]
+			if !ok {
+				return errors.Errorf("secret %q is not managed by kp for service account %q", existing.Name, sa.Name)
+			}
+
+			setFactoryFromEnv(secretFactory)
+
+			s, target, err := secretFactory.MakeSecret(args[0], cs.Namespace)
+			if err != nil {
+				return err
+			}
+
+			if s.Type != existing.Type {
+				return errors.Errorf("cannot change secret %q from type %s to %s", existing.Name, existing.Type, s.Type)
+			}
+
+			if target != existingTarget {
+				return errors.Errorf("cannot change secret %q from %s to %s", existing.Name, existingTarget, target)
+			}
+
+			updated := existing.DeepCopy()
+			updated.Data = s.Data
+			// rotating an ssh key without --git-known-hosts keeps the known hosts
+			if knownHosts, ok := existing.Data[secret.SSHAuthKnownHostsKey]; ok {
+				if _, ok := updated.Data[secret.SSHAuthKnownHostsKey]; !ok {
+					updated.Data[secret.SSHAuthKnownHostsKey] = knownHosts
+				}
+			}
+			for k, v := range s.Annotations {
+				if updated.Annotations == nil {
+					updated.Annotations = map[string]string{}
+				}
+				updated.Annotations[k] = v
+			}
+
+			updatedSA, err := attachSecret(sa, updated, target)
+			if err != nil {
+				return err
+			}
+
+			if !ch.IsDryRun() {
+				patch, err := k8s.CreatePatch(existing, updated)
+				if err != nil {
+					return err
+				}
+
+				if len(patch) > 0 {
+					updated, err = cs.K8sClient.CoreV1().Secrets(cs.Namespace).Patch(ctx, updated.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+					if err != nil {
+						return err
+					}
+				}
+
+				patch, err = k8s.CreatePatch(sa, updatedSA)
+				if err != nil {
+					return err
+				}
+
+				if len(patch) > 0 {
+					updatedSA, err = cs.K8sClient.CoreV1().ServiceAccounts(cs.Namespace).Patch(ctx, updatedSA.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+					if err != nil {
+						return err
+					}
+				}
+			}
+
+			if err = ch.PrintObjs([]runtime.Object{updated, updatedSA}); err != nil {
+				return err
+			}
+
+			if err = ch.PrintResult("Secret %q updated for %s", updated.Name, target); err != nil {
+				return err
+			}
+
+			if !rebuild {
+				return nil
+			}
+
+			return rebuildImages(ctx, cs.KpackClient, ch, cs.Namespace, updatedSA.Name)
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "kubernetes namespace")
+	setFactoryFlags(cmd, secretFactory)
+	cmd.Flags().StringVar(&serviceAccount, "service-account", "default", "service account name to use")
+	cmd.Flags().BoolVar(&rebuild, "rebuild", false, "trigger a build of the image resources using the service account")
+	commands.SetDryRunOutputFlags(cmd)
+	return cmd
+}
+
+// attachSecret returns a copy of the service account that references the
+// secret, with its managed secret annotation pointing to the target.
+func attachSecret(sa *corev1.ServiceAccount, s *corev1.Secret, target string) (*corev1.ServiceAccount, error) {
+	updatedSA := sa.DeepCopy()
+
+	if !hasSecret(updatedSA.Secrets, s.Name) {
+		updatedSA.Secrets = append(updatedSA.Secrets, corev1.ObjectReference{Name: s.Name})
+	}
+
+	if s.Type == corev1.SecretTypeDockerConfigJson && !hasImagePullSecret(updatedSA.ImagePullSecrets, s.Name) {
+		updatedSA.ImagePullSecrets = append(updatedSA.ImagePullSecrets, corev1.LocalObjectReference{Name: s.Name})
+	}
+
+	if err := updateManagedSecretsAnnotation(nil, updatedSA, s.Name, target); err != nil {
+		return nil, err
+	}
+	return updatedSA, nil
+}
+
+func hasSecret(refs []corev1.ObjectReference, name string) bool {
+	for _, ref := range refs {
+		if ref.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasImagePullSecret(refs []corev1.LocalObjectReference, name string) bool {
+	for _, ref := range refs {
+		if ref.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func rebuildImages(ctx context.Context, client versioned.Interface, ch *commands.CommandHelper, namespace, serviceAccount string) error {
+	imageList, err := client.KpackV1alpha2().Images(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(imageList.Items, func(i, j int) bool {
+		return imageList.Items[i].Name < imageList.Items[j].Name
+	})
+
+	for _, img := range imageList.Items {
+		sa := img.Spec.ServiceAccountName
+		if sa == "" {
+			sa = "default"
+		}
+		if sa != serviceAccount {
+			continue
+		}
+
+		buildNumber, err := triggerBuild(ctx, client, ch, namespace, img.Name)
+		if err == build.ErrNoBuilds {
+			if err := ch.Printlnf("Skipping Image Resource %q: %s", img.Name, err); err != nil {
+				return err
+			}
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		if err := ch.PrintResult("Triggered build for Image Resource %q with Build Number %d", img.Name, buildNumber); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func triggerBuild(ctx context.Context, client versioned.Interface, ch *commands.CommandHelper, namespace, name string) (int, error) {
+	if !ch.IsDryRun() {
+		return build.Trigger(ctx, client, namespace, name)
+	}
+
+	bld, err := build.Latest(ctx, client, namespace, name)
+	if err != nil {
+		return 0, err
+	}
+	return build.NextBuildNumber(bld), nil
+}
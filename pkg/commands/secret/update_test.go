@@ -0,0 +1,248 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package secret_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	kpackfakes "github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
+	"github.com/sclevine/spec"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	secretcmds "github.com/buildpacks-community/kpack-cli/pkg/commands/secret"
+	"github.com/buildpacks-community/kpack-cli/pkg/secret"
+	"github.com/buildpacks-community/kpack-cli/pkg/testhelpers"
+)
+
+func TestSecretUpdateCommand(t *testing.T) {
+	spec.Run(t, "TestSecretUpdateCommand", testSecretUpdateCommand)
+}
+
+func testSecretUpdateCommand(t *testing.T, when spec.G, it spec.S) {
+	const namespace = "some-namespace"
+
+	fetcher := &fakeCredentialFetcher{
+		passwords: map[string]string{
+			"REGISTRY_PASSWORD": "new-password",
+		},
+	}
+
+	factory := &secret.Factory{
+		CredentialFetcher: fetcher,
+	}
+
+	cmdFunc := func(k8sClient *fake.Clientset, kpackClient *kpackfakes.Clientset) *cobra.Command {
+		clientSetProvider := testhelpers.GetFakeProvider(k8sClient, kpackClient, namespace)
+		return secretcmds.NewUpdateCommand(clientSetProvider, factory)
+	}
+
+	var (
+		registrySecret *corev1.Secret
+		serviceAccount *corev1.ServiceAccount
+	)
+
+	it.Before(func() {
+		registrySecret = &corev1.Secret{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "my-registry-cred",
+				Namespace: namespace,
+			},
+			Data: map[string][]byte{
+				corev1.DockerConfigJsonKey: []byte(`{"auths":{"registry.io":{"username":"my-user","password":"old-password"}}}`),
+			},
+			Type: corev1.SecretTypeDockerConfigJson,
+		}
+
+		serviceAccount = &corev1.ServiceAccount{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "default",
+				Namespace: namespace,
+				Annotations: map[string]string{
+					"kpack.io/managedSecret": `{"my-registry-cred":"registry.io"}`,
+				},
+			},
+			Secrets:          []corev1.ObjectReference{{Name: "my-registry-cred"}},
+			ImagePullSecrets: []corev1.LocalObjectReference{{Name: "my-registry-cred"}},
+		}
+	})
+
+	it("replaces the credentials without changing the service account", func() {
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{
+				registrySecret,
+				serviceAccount,
+			},
+			Args: []string{"my-registry-cred", "--registry", "registry.io", "--registry-user", "my-user"},
+			ExpectedOutput: `Secret "my-registry-cred" updated for registry.io
+`,
+			ExpectPatches: []string{
+				`{"data":{".dockerconfigjson":"eyJhdXRocyI6eyJyZWdpc3RyeS5pbyI6eyJ1c2VybmFtZSI6Im15LXVzZXIiLCJwYXNzd29yZCI6Im5ldy1wYXNzd29yZCIsImF1dGgiOiJiWGt0ZFhObGNqcHVaWGN0Y0dGemMzZHZjbVE9In19fQ=="}}`,
+			},
+		}.TestK8sAndKpack(t, cmdFunc)
+	})
+
+	it("reattaches the secret to the service account", func() {
+		serviceAccount.Secrets = nil
+		serviceAccount.ImagePullSecrets = nil
+
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{
+				registrySecret,
+				serviceAccount,
+			},
+			Args: []string{"my-registry-cred", "--registry", "registry.io", "--registry-user", "my-user"},
+			ExpectedOutput: `Secret "my-registry-cred" updated for registry.io
+`,
+			ExpectPatches: []string{
+				`{"data":{".dockerconfigjson":"eyJhdXRocyI6eyJyZWdpc3RyeS5pbyI6eyJ1c2VybmFtZSI6Im15LXVzZXIiLCJwYXNzd29yZCI6Im5ldy1wYXNzd29yZCIsImF1dGgiOiJiWGt0ZFhObGNqcHVaWGN0Y0dGemMzZHZjbVE9In19fQ=="}}`,
+				`{"imagePullSecrets":[{"name":"my-registry-cred"}],"secrets":[{"name":"my-registry-cred"}]}`,
+			},
+		}.TestK8sAndKpack(t, cmdFunc)
+	})
+
+	it("errors when the secret is not managed by kp for the service account", func() {
+		serviceAccount.Annotations = nil
+
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{
+				registrySecret,
+				serviceAccount,
+			},
+			Args:                []string{"my-registry-cred", "--registry", "registry.io", "--registry-user", "my-user"},
+			ExpectErr:           true,
+			ExpectedErrorOutput: "Error: secret \"my-registry-cred\" is not managed by kp for service account \"default\"\n",
+		}.TestK8sAndKpack(t, cmdFunc)
+	})
+
+	it("errors when the target changes", func() {
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{
+				registrySecret,
+				serviceAccount,
+			},
+			Args:                []string{"my-registry-cred", "--registry", "other-registry.io", "--registry-user", "my-user"},
+			ExpectErr:           true,
+			ExpectedErrorOutput: "Error: cannot change secret \"my-registry-cred\" from registry.io to other-registry.io\n",
+		}.TestK8sAndKpack(t, cmdFunc)
+	})
+
+	it("errors when the secret type changes", func() {
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{
+				registrySecret,
+				serviceAccount,
+			},
+			Args:                []string{"my-registry-cred", "--git-url", "git@github.com", "--git-ssh-key", "./testdata/git-ssh.pem"},
+			ExpectErr:           true,
+			ExpectedErrorOutput: "Error: cannot change secret \"my-registry-cred\" from type kubernetes.io/dockerconfigjson to kubernetes.io/ssh-auth\n",
+		}.TestK8sAndKpack(t, cmdFunc)
+	})
+
+	it("keeps the known hosts when rotating an ssh key without new known hosts", func() {
+		sshSecret := &corev1.Secret{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "my-git-ssh-cred",
+				Namespace: namespace,
+				Annotations: map[string]string{
+					secret.GitAnnotation: "git@github.com",
+				},
+			},
+			Data: map[string][]byte{
+				corev1.SSHAuthPrivateKey:    []byte("old git ssh key"),
+				secret.SSHAuthKnownHostsKey: []byte("github.com ssh-ed25519 some-host-key\n"),
+			},
+			Type: corev1.SecretTypeSSHAuth,
+		}
+		serviceAccount.Annotations["kpack.io/managedSecret"] = `{"my-git-ssh-cred":"git@github.com"}`
+		serviceAccount.Secrets = []corev1.ObjectReference{{Name: "my-git-ssh-cred"}}
+		serviceAccount.ImagePullSecrets = nil
+
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{
+				sshSecret,
+				serviceAccount,
+			},
+			Args: []string{"my-git-ssh-cred", "--git-url", "git@github.com", "--git-ssh-key", "./testdata/git-ssh.pem"},
+			ExpectedOutput: `Secret "my-git-ssh-cred" updated for git@github.com
+`,
+			ExpectPatches: []string{
+				`{"data":{"ssh-privatekey":"c29tZSBnaXQgc3NoIGtleQ=="}}`,
+			},
+		}.TestK8sAndKpack(t, cmdFunc)
+	})
+
+	it("errors when the secret does not exist", func() {
+		testhelpers.CommandTest{
+			Objects: []runtime.Object{
+				serviceAccount,
+			},
+			Args:                []string{"my-registry-cred", "--registry", "registry.io", "--registry-user", "my-user"},
+			ExpectErr:           true,
+			ExpectedErrorOutput: "Error: secrets \"my-registry-cred\" not found\n",
+		}.TestK8sAndKpack(t, cmdFunc)
+	})
+
+	when("--rebuild is used", func() {
+		var images []runtime.Object
+
+		it.Before(func() {
+			images = []runtime.Object{
+				&v1alpha2.Image{
+					ObjectMeta: v1.ObjectMeta{Name: "some-image", Namespace: namespace},
+				},
+				&v1alpha2.Image{
+					ObjectMeta: v1.ObjectMeta{Name: "image-without-builds", Namespace: namespace},
+				},
+				&v1alpha2.Image{
+					ObjectMeta: v1.ObjectMeta{Name: "other-sa-image", Namespace: namespace},
+					Spec:       v1alpha2.ImageSpec{ServiceAccountName: "other-sa"},
+				},
+			}
+			images = append(images, testhelpers.BuildsToRuntimeObjs(testhelpers.MakeTestBuilds("some-image", namespace))...)
+		})
+
+		it("triggers builds of the images using the service account", func() {
+			k8sClient := fake.NewSimpleClientset(registrySecret, serviceAccount)
+			kpackClient := kpackfakes.NewSimpleClientset(images...)
+
+			cmd := cmdFunc(k8sClient, kpackClient)
+			out := &bytes.Buffer{}
+			cmd.SetOut(out)
+			cmd.SetErr(out)
+			cmd.SetArgs([]string{"my-registry-cred", "--registry", "registry.io", "--registry-user", "my-user", "--rebuild"})
+
+			require.NoError(t, cmd.Execute())
+			require.Equal(t, `Secret "my-registry-cred" updated for registry.io
+Skipping Image Resource "image-without-builds": no builds found
+Triggered build for Image Resource "some-image" with Build Number 4
+`, out.String())
+
+			actions, err := testhelpers.ActionRecorderList{kpackClient}.ActionsByVerb()
+			require.NoError(t, err)
+			require.Len(t, actions.Patches, 1)
+			require.Equal(t, "build-three", actions.Patches[0].GetName())
+		})
+
+		it("does not trigger builds with --dry-run", func() {
+			testhelpers.CommandTest{
+				Objects: append([]runtime.Object{
+					registrySecret,
+					serviceAccount,
+				}, images...),
+				Args: []string{"my-registry-cred", "--registry", "registry.io", "--registry-user", "my-user", "--rebuild", "--dry-run"},
+				ExpectedOutput: `Secret "my-registry-cred" updated for registry.io (dry run)
+Skipping Image Resource "image-without-builds": no builds found
+Triggered build for Image Resource "some-image" with Build Number 4 (dry run)
+`,
+			}.TestK8sAndKpack(t, cmdFunc)
+		})
+	})
+}
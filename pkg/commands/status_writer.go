@@ -9,10 +9,12 @@ import (
 	"text/tabwriter"
 
 	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
 )
 
 type StatusWriter struct {
-	writer *tabwriter.Writer
+	writer    *tabwriter.Writer
+	collector *TableCollector
 }
 
 const StatusWriterTabWidth = 4
@@ -24,11 +26,31 @@ func NewStatusWriter(out io.Writer) *StatusWriter {
 	}
 }
 
+// NewCollectorStatusWriter returns a status writer adding its first block to
+// the collector.
+func NewCollectorStatusWriter(c *TableCollector) *StatusWriter {
+	return &StatusWriter{collector: c}
+}
+
+// NewCommandStatusWriter returns a status writer for the output of cmd, or for
+// the table collector of its context when it runs as part of several contexts.
+func NewCommandStatusWriter(cmd *cobra.Command) *StatusWriter {
+	if c := tableCollectorFrom(cmd.Context()); c != nil {
+		return NewCollectorStatusWriter(c)
+	}
+	return NewStatusWriter(cmd.OutOrStdout())
+}
+
 func (s *StatusWriter) AddBlock(header string, items ...string) error {
 	if len(items)%2 != 0 {
 		return errors.Errorf("block must contain an equal number of items")
 	}
 
+	if s.collector != nil {
+		s.collector.addBlock(header, items)
+		return nil
+	}
+
 	if header != "" {
 		_, err := fmt.Fprintln(s.writer, header)
 		if err != nil {
@@ -53,5 +75,9 @@ func (s *StatusWriter) AddBlock(header string, items ...string) error {
 }
 
 func (s *StatusWriter) Write() error {
+	if s.collector != nil {
+		return nil
+	}
+
 	return s.writer.Flush()
 }
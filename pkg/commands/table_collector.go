@@ -0,0 +1,57 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package commands
+
+import "context"
+
+// TableCollector collects the first table and summary block of list and
+// status commands instead of printing them, so the results of several
+// clusters can be merged into one table.
+type TableCollector struct {
+	headers []string
+	rows    [][]string
+	keys    []string
+	values  []string
+	blocks  int
+}
+
+type tableCollectorKey struct{}
+
+// withTableCollector returns a context for running a list or status command
+// whose table writers add to the collector.
+func withTableCollector(ctx context.Context, c *TableCollector) context.Context {
+	return context.WithValue(ctx, tableCollectorKey{}, c)
+}
+
+func tableCollectorFrom(ctx context.Context) *TableCollector {
+	if ctx == nil {
+		return nil
+	}
+	c, _ := ctx.Value(tableCollectorKey{}).(*TableCollector)
+	return c
+}
+
+func (c *TableCollector) addTable(headers []string) bool {
+	if c.headers != nil {
+		return false
+	}
+	c.headers = headers
+	return true
+}
+
+func (c *TableCollector) addBlock(header string, items []string) {
+	c.blocks++
+	if c.blocks > 1 || header != "" {
+		return
+	}
+
+	for i := 0; i < len(items); i += 2 {
+		value := items[i+1]
+		if value == "" {
+			value = "--"
+		}
+		c.keys = append(c.keys, items[i])
+		c.values = append(c.values, value)
+	}
+}
@@ -10,17 +10,19 @@ import (
 	"text/tabwriter"
 
 	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
 )
 
 type TableWriter struct {
 	numColumns int
 	writer     *tabwriter.Writer
+	collector  *TableCollector
 }
 
 func NewTableWriter(out io.Writer, headers ...string) (*TableWriter, error) {
 	writer := tabwriter.NewWriter(out, 0, 4, 4, ' ', 0)
 
-	_, err := fmt.Fprintln(writer, strings.ToUpper(strings.Join(headers, "\t")))
+	_, err := fmt.Fprintln(writer, strings.Join(upper(headers), "\t"))
 	if err != nil {
 		return nil, err
 	}
@@ -31,19 +33,58 @@ func NewTableWriter(out io.Writer, headers ...string) (*TableWriter, error) {
 	}, nil
 }
 
+// NewCollectorTableWriter returns a table writer adding its rows to the
+// collector when the collector has no table yet, otherwise the rows are
+// discarded.
+func NewCollectorTableWriter(c *TableCollector, headers ...string) *TableWriter {
+	w := &TableWriter{numColumns: len(headers)}
+	if c.addTable(upper(headers)) {
+		w.collector = c
+	}
+	return w
+}
+
+// NewCommandTableWriter returns a table writer for the output of cmd, or for
+// the table collector of its context when it runs as part of several contexts.
+func NewCommandTableWriter(cmd *cobra.Command, headers ...string) (*TableWriter, error) {
+	if c := tableCollectorFrom(cmd.Context()); c != nil {
+		return NewCollectorTableWriter(c, headers...), nil
+	}
+	return NewTableWriter(cmd.OutOrStdout(), headers...)
+}
+
 func (w *TableWriter) AddRow(columns ...string) error {
 	if len(columns) != w.numColumns {
 		return errors.New("incorrect number of columns for row")
 	}
 
+	if w.writer == nil {
+		if w.collector != nil {
+			w.collector.rows = append(w.collector.rows, columns)
+		}
+		return nil
+	}
+
 	_, err := fmt.Fprintln(w.writer, strings.Join(columns, "\t"))
 	return err
 }
 
 func (w *TableWriter) Write() error {
+	if w.writer == nil {
+		return nil
+	}
+
 	_, err := fmt.Fprintln(w.writer, "")
 	if err != nil {
 		return err
 	}
 	return w.writer.Flush()
 }
+
+func upper(headers []string) []string {
+	upper := make([]string, 0, len(headers))
+	for _, h := range headers {
+		upper = append(upper, strings.ToUpper(h))
+	}
+	return upper
+}
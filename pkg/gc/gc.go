@@ -0,0 +1,221 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gc
+
+import (
+	"context"
+	"sort"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/client/clientset/versioned"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/registry"
+)
+
+// Plan is what to remove from a repository to collect the images that are
+// no longer referenced by the cluster.
+type Plan struct {
+	// Delete are the digest references of manifests to delete.
+	Delete []string
+	// Untag are the relocation tags to remove from manifests that are kept
+	// for their other tags.
+	Untag []string
+	// Referenced is the number of relocated digests still in use.
+	Referenced int
+	// Kept is the number of unreferenced digests kept by the keep policy.
+	Kept int
+}
+
+// ReferencedImages returns the images referenced by the ClusterLifecycles,
+// ClusterBuildpacks, Buildpacks, ClusterStores, ClusterStacks, ClusterBuilders,
+// Builders and the source of the Images in the cluster.
+func ReferencedImages(ctx context.Context, client versioned.Interface) ([]string, error) {
+	var images []string
+
+	lifecycles, err := client.KpackV1alpha2().ClusterLifecycles().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, lifecycle := range lifecycles.Items {
+		images = append(images, lifecycle.Spec.Image, lifecycle.Status.Image.LatestImage)
+	}
+
+	buildpacks, err := client.KpackV1alpha2().ClusterBuildpacks().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, buildpack := range buildpacks.Items {
+		images = append(images, buildpack.Spec.Image)
+		images = appendStoreImages(images, buildpack.Status.Buildpacks)
+	}
+
+	namespacedBuildpacks, err := client.KpackV1alpha2().Buildpacks("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, buildpack := range namespacedBuildpacks.Items {
+		images = append(images, buildpack.Spec.Image)
+		images = appendStoreImages(images, buildpack.Status.Buildpacks)
+	}
+
+	stores, err := client.KpackV1alpha2().ClusterStores().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, store := range stores.Items {
+		for _, source := range store.Spec.Sources {
+			images = append(images, source.Image)
+		}
+		images = appendStoreImages(images, store.Status.Buildpacks)
+	}
+
+	stacks, err := client.KpackV1alpha2().ClusterStacks().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, stack := range stacks.Items {
+		images = append(images,
+			stack.Spec.BuildImage.Image, stack.Status.BuildImage.LatestImage,
+			stack.Spec.RunImage.Image, stack.Status.RunImage.LatestImage,
+		)
+	}
+
+	clusterBuilders, err := client.KpackV1alpha2().ClusterBuilders().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, builder := range clusterBuilders.Items {
+		images = append(images, builder.Spec.Tag, builder.Status.LatestImage)
+	}
+
+	builders, err := client.KpackV1alpha2().Builders("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, builder := range builders.Items {
+		images = append(images, builder.Spec.Tag, builder.Status.LatestImage)
+	}
+
+	imgs, err := client.KpackV1alpha2().Images("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, img := range imgs.Items {
+		if img.Spec.Source.Registry != nil {
+			images = append(images, img.Spec.Source.Registry.Image)
+		}
+	}
+
+	return images, nil
+}
+
+func appendStoreImages(images []string, buildpacks []corev1alpha1.BuildpackStatus) []string {
+	for _, buildpack := range buildpacks {
+		images = append(images, buildpack.StoreImage.Image)
+	}
+	return images
+}
+
+// NewPlan works out which digests of repository to remove, given the digest of
+// each of its tags and the images referenced by the cluster. Only digests
+// tagged by a relocation are collected, and the keep most recently relocated
+// of the unreferenced ones are kept.
+func NewPlan(repository string, tags map[string]string, referenced []string, keep int) (Plan, error) {
+	repo, err := name.NewRepository(repository, name.WeakValidation)
+	if err != nil {
+		return Plan{}, err
+	}
+
+	tagsByDigest := map[string][]string{}
+	for tag, digest := range tags {
+		tagsByDigest[digest] = append(tagsByDigest[digest], tag)
+	}
+
+	inUse := map[string]bool{}
+	for _, image := range referenced {
+		if image == "" {
+			continue
+		}
+
+		ref, err := name.ParseReference(image, name.WeakValidation)
+		if err != nil || ref.Context().Name() != repo.Name() {
+			continue
+		}
+
+		switch r := ref.(type) {
+		case name.Digest:
+			inUse[r.DigestStr()] = true
+		case name.Tag:
+			if digest, ok := tags[r.TagStr()]; ok {
+				inUse[digest] = true
+			}
+		}
+	}
+
+	var plan Plan
+	type candidate struct {
+		digest string
+		latest string
+	}
+	var candidates []candidate
+	for digest, digestTags := range tagsByDigest {
+		latest := latestRelocationTag(digestTags)
+		if latest == "" {
+			continue
+		}
+		if inUse[digest] {
+			plan.Referenced++
+			continue
+		}
+		candidates = append(candidates, candidate{digest: digest, latest: latest})
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].latest != candidates[j].latest {
+			return candidates[i].latest > candidates[j].latest
+		}
+		return candidates[i].digest < candidates[j].digest
+	})
+
+	for i, c := range candidates {
+		if i < keep {
+			plan.Kept++
+			continue
+		}
+
+		digestTags := tagsByDigest[c.digest]
+		sort.Strings(digestTags)
+		if !onlyRelocationTags(digestTags) {
+			for _, tag := range digestTags {
+				if registry.IsRelocationTag(tag) {
+					plan.Untag = append(plan.Untag, repo.Tag(tag).String())
+				}
+			}
+			continue
+		}
+		plan.Delete = append(plan.Delete, repo.Digest(c.digest).String())
+	}
+
+	return plan, nil
+}
+
+func latestRelocationTag(tags []string) string {
+	var latest string
+	for _, tag := range tags {
+		if registry.IsRelocationTag(tag) && tag > latest {
+			latest = tag
+		}
+	}
+	return latest
+}
+
+func onlyRelocationTags(tags []string) bool {
+	for _, tag := range tags {
+		if !registry.IsRelocationTag(tag) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,146 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package gc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	kpackfakes "github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/gc"
+)
+
+func TestGC(t *testing.T) {
+	spec.Run(t, "TestGC", testGC)
+}
+
+func testGC(t *testing.T, when spec.G, it spec.S) {
+	const (
+		repo    = "some-registry.io/some-repo"
+		digestA = "sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+		digestB = "sha256:bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+		digestC = "sha256:cccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccc"
+		digestD = "sha256:dddddddddddddddddddddddddddddddddddddddddddddddddddddddddddddddd"
+	)
+
+	when("NewPlan", func() {
+		tags := map[string]string{
+			"20240101000000":         digestA,
+			"20240201000000":         digestB,
+			"20240202000000":         digestB,
+			"20240301000000":         digestC,
+			"clusterbuilder-default": digestC,
+			"20240401000000":         digestD,
+			"some-user-tag":          "sha256:eeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeee",
+		}
+
+		it("removes the relocated digests that are not referenced", func() {
+			plan, err := gc.NewPlan(repo, tags, []string{
+				repo + "@" + digestD,
+				"some-other-registry.io/some-repo@" + digestA,
+				"",
+			}, 0)
+			require.NoError(t, err)
+
+			require.Equal(t, gc.Plan{
+				Delete:     []string{repo + "@" + digestB, repo + "@" + digestA},
+				Untag:      []string{repo + ":20240301000000"},
+				Referenced: 1,
+			}, plan)
+		})
+
+		it("resolves referenced tags", func() {
+			plan, err := gc.NewPlan(repo, tags, []string{repo + ":clusterbuilder-default", repo + ":20240101000000"}, 0)
+			require.NoError(t, err)
+
+			require.Equal(t, gc.Plan{
+				Delete:     []string{repo + "@" + digestD, repo + "@" + digestB},
+				Referenced: 2,
+			}, plan)
+		})
+
+		it("keeps the most recently relocated unreferenced digests", func() {
+			plan, err := gc.NewPlan(repo, tags, []string{repo + "@" + digestD}, 2)
+			require.NoError(t, err)
+
+			require.Equal(t, gc.Plan{
+				Delete:     []string{repo + "@" + digestA},
+				Referenced: 1,
+				Kept:       2,
+			}, plan)
+		})
+	})
+
+	when("ReferencedImages", func() {
+		it("returns the images referenced by cluster resources, builders and image sources", func() {
+			client := kpackfakes.NewSimpleClientset(
+				&v1alpha2.ClusterLifecycle{
+					ObjectMeta: metav1.ObjectMeta{Name: "some-lifecycle"},
+					Spec:       v1alpha2.ClusterLifecycleSpec{ImageSource: corev1alpha1.ImageSource{Image: "lifecycle-image"}},
+				},
+				&v1alpha2.ClusterBuildpack{
+					ObjectMeta: metav1.ObjectMeta{Name: "some-buildpack"},
+					Spec:       v1alpha2.ClusterBuildpackSpec{ImageSource: corev1alpha1.ImageSource{Image: "buildpack-image"}},
+				},
+				&v1alpha2.Buildpack{
+					ObjectMeta: metav1.ObjectMeta{Name: "some-namespaced-buildpack", Namespace: "some-namespace"},
+					Spec:       v1alpha2.BuildpackSpec{ImageSource: corev1alpha1.ImageSource{Image: "namespaced-buildpack-image"}},
+					Status: v1alpha2.BuildpackStatus{
+						Buildpacks: []corev1alpha1.BuildpackStatus{{StoreImage: corev1alpha1.ImageSource{Image: "namespaced-buildpack-status-image"}}},
+					},
+				},
+				&v1alpha2.Buildpack{
+					ObjectMeta: metav1.ObjectMeta{Name: "other-namespaced-buildpack", Namespace: "other-namespace"},
+					Spec:       v1alpha2.BuildpackSpec{ImageSource: corev1alpha1.ImageSource{Image: "other-namespaced-buildpack-image"}},
+				},
+				&v1alpha2.ClusterStore{
+					ObjectMeta: metav1.ObjectMeta{Name: "some-store"},
+					Spec:       v1alpha2.ClusterStoreSpec{Sources: []corev1alpha1.ImageSource{{Image: "store-image"}}},
+					Status: v1alpha2.ClusterStoreStatus{
+						Buildpacks: []corev1alpha1.BuildpackStatus{{StoreImage: corev1alpha1.ImageSource{Image: "store-status-image"}}},
+					},
+				},
+				&v1alpha2.ClusterStack{
+					ObjectMeta: metav1.ObjectMeta{Name: "some-stack"},
+					Spec: v1alpha2.ClusterStackSpec{
+						BuildImage: v1alpha2.ClusterStackSpecImage{Image: "build-image"},
+						RunImage:   v1alpha2.ClusterStackSpecImage{Image: "run-image"},
+					},
+				},
+				&v1alpha2.ClusterBuilder{
+					ObjectMeta: metav1.ObjectMeta{Name: "some-cluster-builder"},
+					Spec:       v1alpha2.ClusterBuilderSpec{BuilderSpec: v1alpha2.BuilderSpec{Tag: "cluster-builder-tag"}},
+					Status:     v1alpha2.BuilderStatus{LatestImage: "cluster-builder-image"},
+				},
+				&v1alpha2.Builder{
+					ObjectMeta: metav1.ObjectMeta{Name: "some-builder", Namespace: "some-namespace"},
+					Spec:       v1alpha2.NamespacedBuilderSpec{BuilderSpec: v1alpha2.BuilderSpec{Tag: "builder-tag"}},
+				},
+				&v1alpha2.Image{
+					ObjectMeta: metav1.ObjectMeta{Name: "some-image", Namespace: "some-namespace"},
+					Spec: v1alpha2.ImageSpec{
+						Source: corev1alpha1.SourceConfig{Registry: &corev1alpha1.Registry{Image: "source-image"}},
+					},
+				},
+			)
+
+			images, err := gc.ReferencedImages(context.Background(), client)
+			require.NoError(t, err)
+
+			for _, image := range []string{
+				"lifecycle-image", "buildpack-image", "namespaced-buildpack-image", "namespaced-buildpack-status-image",
+				"other-namespaced-buildpack-image", "store-image", "store-status-image", "build-image", "run-image",
+				"cluster-builder-tag", "cluster-builder-image", "builder-tag", "source-image",
+			} {
+				require.Contains(t, images, image)
+			}
+		})
+	})
+}
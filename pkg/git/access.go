@@ -0,0 +1,50 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"context"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/storage/memory"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/secret"
+)
+
+// SecretApplies returns whether kpack would use the git secret for the
+// repository at gitUrl, based on its kpack.io/git annotation and type.
+func SecretApplies(s *corev1.Secret, gitUrl string) bool {
+	host, isSsh := parseHost(gitUrl)
+	if !hostMatches(host, s.Annotations[secret.GitAnnotation]) {
+		return false
+	}
+	return s.Type == corev1.SecretTypeBasicAuth && !isSsh || s.Type == corev1.SecretTypeSSHAuth && isSsh
+}
+
+// CheckAccess lists the references of the repository at gitUrl with the
+// credentials of the git secret, as done by "git ls-remote". Host keys of ssh
+// repositories are not verified for secrets without known hosts when
+// insecureIgnoreHostKey is set, as done by kpack.
+func CheckAccess(ctx context.Context, s *corev1.Secret, gitUrl string, insecureIgnoreHostKey bool) error {
+	var auth transport.AuthMethod
+	if s.Type == corev1.SecretTypeSSHAuth {
+		var err error
+		if auth, err = sshAuth(s, gitUrl, insecureIgnoreHostKey); err != nil {
+			return err
+		}
+	} else {
+		auth = basicAuth(s)
+	}
+
+	remote := gogit.NewRemote(memory.NewStorage(), &config.RemoteConfig{
+		Name: "origin",
+		URLs: []string{gitUrl},
+	})
+
+	_, err := remote.ListContext(ctx, &gogit.ListOptions{Auth: auth})
+	return err
+}
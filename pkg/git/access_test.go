@@ -0,0 +1,113 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package git_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/git"
+	"github.com/buildpacks-community/kpack-cli/pkg/testhelpers"
+)
+
+func TestAccess(t *testing.T) {
+	spec.Run(t, "TestAccess", testAccess)
+}
+
+func testAccess(t *testing.T, when spec.G, it spec.S) {
+	basicAuthSecret := func(gitUrl, password string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "some-git-secret",
+				Annotations: map[string]string{"kpack.io/git": gitUrl},
+			},
+			Data: map[string][]byte{
+				corev1.BasicAuthUsernameKey: []byte("some-user"),
+				corev1.BasicAuthPasswordKey: []byte(password),
+			},
+			Type: corev1.SecretTypeBasicAuth,
+		}
+	}
+
+	when("SecretApplies", func() {
+		it("matches the secret host and type to the repository", func() {
+			s := basicAuthSecret("https://github.com", "")
+			require.True(t, git.SecretApplies(s, "https://github.com/some-org/some-repo"))
+			require.False(t, git.SecretApplies(s, "https://gitlab.com/some-org/some-repo"))
+			require.False(t, git.SecretApplies(s, "git@github.com:some-org/some-repo.git"))
+
+			s.Type = corev1.SecretTypeSSHAuth
+			s.Annotations["kpack.io/git"] = "git@github.com"
+			require.True(t, git.SecretApplies(s, "git@github.com:some-org/some-repo.git"))
+		})
+	})
+
+	when("CheckAccess", func() {
+		var repoUrl string
+
+		it.Before(func() {
+			repoUrl = testhelpers.NewGitServer(t, "some-user", "some-password") + "/some-repo.git"
+		})
+
+		it("lists the repository references with valid credentials", func() {
+			s := basicAuthSecret(strings.TrimSuffix(repoUrl, "/some-repo.git"), "some-password")
+			require.NoError(t, git.CheckAccess(context.Background(), s, repoUrl, false))
+		})
+
+		it("fails with invalid credentials", func() {
+			s := basicAuthSecret(strings.TrimSuffix(repoUrl, "/some-repo.git"), "wrong-password")
+			require.Error(t, git.CheckAccess(context.Background(), s, repoUrl, false))
+		})
+
+		when("the ssh secret has no known hosts", func() {
+			const sshRepoUrl = "ssh://git@127.0.0.1:1/some-repo.git"
+
+			it.Before(func() {
+				t.Setenv("SSH_KNOWN_HOSTS", filepath.Join(t.TempDir(), "missing_known_hosts"))
+			})
+
+			it("verifies host keys with the default known hosts", func() {
+				err := git.CheckAccess(context.Background(), sshSecret(t), sshRepoUrl, false)
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "failed to read default known hosts for secret 'some-ssh-secret' without known hosts")
+			})
+
+			it("skips host key verification when requested", func() {
+				err := git.CheckAccess(context.Background(), sshSecret(t), sshRepoUrl, true)
+				require.Error(t, err)
+				require.NotContains(t, err.Error(), "known hosts")
+			})
+		})
+	})
+}
+
+func sshSecret(t *testing.T) *corev1.Secret {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	block, err := ssh.MarshalPrivateKey(key, "")
+	require.NoError(t, err)
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "some-ssh-secret",
+			Annotations: map[string]string{"kpack.io/git": "git@127.0.0.1"},
+		},
+		Data: map[string][]byte{
+			corev1.SSHAuthPrivateKey: pem.EncodeToMemory(block),
+		},
+		Type: corev1.SecretTypeSSHAuth,
+	}
+}
@@ -0,0 +1,244 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ssh"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/secret"
+)
+
+var commitShaRegex = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
+
+// Resolver resolves git revisions to commit shas the same way the kpack
+// source resolver does, using the git secrets of the image's service account.
+type Resolver struct {
+	k8sClient kubernetes.Interface
+}
+
+func NewResolver(k8sClient kubernetes.Interface) *Resolver {
+	return &Resolver{k8sClient: k8sClient}
+}
+
+// Resolve returns the commit sha of the branch or tag named revision in the
+// repository at gitUrl. Commit shas are expanded when they match the commit of
+// a listed reference and returned as is otherwise, as commits that are not
+// listed cannot be checked without fetching the repository. The returned bool
+// reports whether the sha was found in the repository.
+func (r *Resolver) Resolve(ctx context.Context, namespace, serviceAccount, gitUrl, revision string) (string, bool, error) {
+	auth, err := r.auth(ctx, namespace, serviceAccount, gitUrl)
+	if err != nil {
+		return "", false, err
+	}
+
+	remote := gogit.NewRemote(memory.NewStorage(), &config.RemoteConfig{
+		Name: "origin",
+		URLs: []string{gitUrl},
+	})
+
+	refs, err := remote.ListContext(ctx, &gogit.ListOptions{Auth: auth, PeelingOption: gogit.AppendPeeled})
+	if err != nil {
+		return "", false, errors.Wrapf(err, "failed to list revisions of git repository '%s'", gitUrl)
+	}
+
+	sha := ""
+	for _, ref := range refs {
+		name := strings.TrimSuffix(ref.Name().String(), "^{}")
+		if name != revision && plumbing.ReferenceName(name).Short() != revision {
+			continue
+		}
+
+		// annotated tags are listed twice, the peeled reference points to the commit
+		if sha == "" || strings.HasSuffix(ref.Name().String(), "^{}") {
+			sha = ref.Hash().String()
+		}
+	}
+
+	if sha != "" {
+		return sha, true, nil
+	}
+
+	if commitShaRegex.MatchString(revision) {
+		sha, found := expandCommitSha(refs, revision)
+		return sha, found, nil
+	}
+
+	return "", false, errors.Errorf("git revision '%s' not found in repository '%s', use a branch, tag or full commit sha", revision, gitUrl)
+}
+
+// expandCommitSha returns the full sha of the single listed commit that starts
+// with the abbreviated sha, otherwise the sha is returned unchanged and false.
+func expandCommitSha(refs []*plumbing.Reference, sha string) (string, bool) {
+	peeled := map[string]bool{}
+	for _, ref := range refs {
+		if name := ref.Name().String(); strings.HasSuffix(name, "^{}") {
+			peeled[strings.TrimSuffix(name, "^{}")] = true
+		}
+	}
+
+	matches := map[string]bool{}
+	for _, ref := range refs {
+		// the unpeeled reference of an annotated tag points to the tag object
+		if ref.Type() != plumbing.HashReference || peeled[ref.Name().String()] {
+			continue
+		}
+		if hash := ref.Hash().String(); strings.HasPrefix(hash, sha) {
+			matches[hash] = true
+		}
+	}
+
+	if len(matches) != 1 {
+		return sha, false
+	}
+	for hash := range matches {
+		return hash, true
+	}
+	return sha, false
+}
+
+func (r *Resolver) auth(ctx context.Context, namespace, serviceAccount, gitUrl string) (transport.AuthMethod, error) {
+	sa, err := r.k8sClient.CoreV1().ServiceAccounts(namespace).Get(ctx, serviceAccount, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	host, isSsh := parseHost(gitUrl)
+
+	names := make([]string, 0, len(sa.Secrets))
+	for _, ref := range sa.Secrets {
+		names = append(names, ref.Name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		s, err := r.k8sClient.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		if !hostMatches(host, s.Annotations[secret.GitAnnotation]) {
+			continue
+		}
+
+		switch {
+		case s.Type == corev1.SecretTypeBasicAuth && !isSsh:
+			return basicAuth(s), nil
+		case s.Type == corev1.SecretTypeSSHAuth && isSsh:
+			// like kpack, only verify host keys when the secret has known hosts
+			return sshAuth(s, gitUrl, true)
+		}
+	}
+
+	return nil, nil
+}
+
+func basicAuth(s *corev1.Secret) transport.AuthMethod {
+	return &http.BasicAuth{
+		Username: string(s.Data[corev1.BasicAuthUsernameKey]),
+		Password: string(s.Data[corev1.BasicAuthPasswordKey]),
+	}
+}
+
+// sshAuth verifies host keys with the known hosts of the secret, falling back
+// to the default known_hosts files unless insecureIgnoreHostKey is set.
+func sshAuth(s *corev1.Secret, gitUrl string, insecureIgnoreHostKey bool) (transport.AuthMethod, error) {
+	auth, err := gitssh.NewPublicKeys(sshUser(gitUrl), s.Data[corev1.SSHAuthPrivateKey], "")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read ssh key from secret '%s'", s.Name)
+	}
+
+	switch knownHosts := s.Data[secret.SSHAuthKnownHostsKey]; {
+	case len(knownHosts) > 0:
+		auth.HostKeyCallback, err = knownHostsCallback(knownHosts)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read known hosts from secret '%s'", s.Name)
+		}
+	case insecureIgnoreHostKey:
+		auth.HostKeyCallback = ssh.InsecureIgnoreHostKey()
+	default:
+		auth.HostKeyCallback, err = gitssh.NewKnownHostsCallback()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read default known hosts for secret '%s' without known hosts", s.Name)
+		}
+	}
+	return auth, nil
+}
+
+func knownHostsCallback(knownHosts []byte) (ssh.HostKeyCallback, error) {
+	f, err := os.CreateTemp("", "known_hosts")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	if _, err := f.Write(knownHosts); err != nil {
+		return nil, err
+	}
+
+	return gitssh.NewKnownHostsCallback(f.Name())
+}
+
+// parseHost returns the host of a git url and whether it uses ssh, accepting
+// scp-like urls such as git@github.com:org/repo.git.
+func parseHost(gitUrl string) (string, bool) {
+	if u, err := url.Parse(gitUrl); err == nil && u.Scheme != "" && u.Host != "" {
+		return u.Host, u.Scheme == "ssh"
+	}
+
+	hostAndPath := gitUrl
+	if i := strings.Index(hostAndPath, "@"); i >= 0 {
+		hostAndPath = hostAndPath[i+1:]
+	}
+	if i := strings.Index(hostAndPath, ":"); i >= 0 {
+		return hostAndPath[:i], true
+	}
+	return "", false
+}
+
+func sshUser(gitUrl string) string {
+	if u, err := url.Parse(gitUrl); err == nil && u.User != nil {
+		return u.User.Username()
+	}
+	if i := strings.Index(gitUrl, "@"); i >= 0 && !strings.Contains(gitUrl[:i], "/") {
+		return gitUrl[:i]
+	}
+	return "git"
+}
+
+// hostMatches uses the same forms of the kpack.io/git annotation as kpack.
+func hostMatches(host, annotation string) bool {
+	if host == "" {
+		return false
+	}
+	for _, format := range []string{"%s", "https://%s", "http://%s", "git@%s"} {
+		if strings.Replace(format, "%s", host, 1) == annotation {
+			return true
+		}
+	}
+	return false
+}
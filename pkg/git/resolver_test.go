@@ -0,0 +1,162 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package git_test
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/git"
+)
+
+func TestResolver(t *testing.T) {
+	spec.Run(t, "TestResolver", testResolver)
+}
+
+func testResolver(t *testing.T, when spec.G, it spec.S) {
+	const namespace = "some-namespace"
+
+	var (
+		repoPath  string
+		commitSha string
+		tagSha    string
+		resolver  *git.Resolver
+	)
+
+	it.Before(func() {
+		repoPath = t.TempDir()
+		repo, err := gogit.PlainInit(repoPath, false)
+		require.NoError(t, err)
+
+		signature := &object.Signature{Name: "some-author", Email: "some@example.com", When: time.Now()}
+
+		worktree, err := repo.Worktree()
+		require.NoError(t, err)
+		first, err := worktree.Commit("first", &gogit.CommitOptions{AllowEmptyCommits: true, Author: signature})
+		require.NoError(t, err)
+		tagSha = first.String()
+
+		_, err = repo.CreateTag("v1.0.0", first, &gogit.CreateTagOptions{Tagger: signature, Message: "v1.0.0"})
+		require.NoError(t, err)
+
+		second, err := worktree.Commit("second", &gogit.CommitOptions{AllowEmptyCommits: true, Author: signature})
+		require.NoError(t, err)
+		commitSha = second.String()
+
+		require.NoError(t, repo.Storer.SetReference(plumbing.NewHashReference("refs/heads/some-branch", second)))
+
+		resolver = git.NewResolver(fake.NewSimpleClientset())
+	})
+
+	it("resolves a branch to its commit sha", func() {
+		sha, found, err := resolver.Resolve(context.Background(), namespace, "default", repoPath, "some-branch")
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, commitSha, sha)
+	})
+
+	it("resolves an annotated tag to the commit it points to", func() {
+		sha, found, err := resolver.Resolve(context.Background(), namespace, "default", repoPath, "v1.0.0")
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, tagSha, sha)
+	})
+
+	it("returns full commit shas as is", func() {
+		sha, found, err := resolver.Resolve(context.Background(), namespace, "default", repoPath, tagSha)
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, tagSha, sha)
+
+		unlisted := strings.Repeat("0", 40)
+		sha, found, err = resolver.Resolve(context.Background(), namespace, "default", repoPath, unlisted)
+		require.NoError(t, err)
+		require.False(t, found)
+		require.Equal(t, unlisted, sha)
+	})
+
+	it("expands abbreviated commit shas of listed references", func() {
+		sha, found, err := resolver.Resolve(context.Background(), namespace, "default", repoPath, commitSha[:7])
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, commitSha, sha)
+
+		sha, found, err = resolver.Resolve(context.Background(), namespace, "default", repoPath, tagSha[:10])
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, tagSha, sha)
+	})
+
+	it("returns abbreviated commit shas that are not listed as is", func() {
+		sha, found, err := resolver.Resolve(context.Background(), namespace, "default", repoPath, "0000000")
+		require.NoError(t, err)
+		require.False(t, found)
+		require.Equal(t, "0000000", sha)
+	})
+
+	it("fails when the revision does not exist", func() {
+		_, _, err := resolver.Resolve(context.Background(), namespace, "default", repoPath, "missing-branch")
+		require.EqualError(t, err, "git revision 'missing-branch' not found in repository '"+repoPath+"', use a branch, tag or full commit sha")
+	})
+
+	it("fails when the repository cannot be listed", func() {
+		_, _, err := resolver.Resolve(context.Background(), namespace, "default", repoPath+"/missing", "main")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to list revisions of git repository '"+repoPath+"/missing'")
+	})
+
+	it("fails when the git secret of the service account has an invalid ssh key", func() {
+		resolver = git.NewResolver(fake.NewSimpleClientset(
+			&corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-sa", Namespace: namespace},
+				Secrets:    []corev1.ObjectReference{{Name: "unrelated-secret"}, {Name: "ssh-secret"}},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "ssh-secret",
+					Namespace:   namespace,
+					Annotations: map[string]string{"kpack.io/git": "git@github.com"},
+				},
+				Type: corev1.SecretTypeSSHAuth,
+				Data: map[string][]byte{corev1.SSHAuthPrivateKey: []byte("not-a-key")},
+			},
+		))
+
+		_, _, err := resolver.Resolve(context.Background(), namespace, "some-sa", "git@github.com:some-org/some-repo.git", "main")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to read ssh key from secret 'ssh-secret'")
+	})
+
+	it("does not verify host keys when the git secret has no known hosts", func() {
+		t.Setenv("SSH_KNOWN_HOSTS", filepath.Join(t.TempDir(), "missing_known_hosts"))
+
+		s := sshSecret(t)
+		s.Namespace = namespace
+		s.Annotations["kpack.io/git"] = "git@127.0.0.1:1"
+		resolver = git.NewResolver(fake.NewSimpleClientset(
+			&corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-sa", Namespace: namespace},
+				Secrets:    []corev1.ObjectReference{{Name: s.Name}},
+			},
+			s,
+		))
+
+		_, _, err := resolver.Resolve(context.Background(), namespace, "some-sa", "ssh://git@127.0.0.1:1/some-repo.git", "main")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to list revisions of git repository 'ssh://git@127.0.0.1:1/some-repo.git'")
+		require.NotContains(t, err.Error(), "known hosts")
+	})
+}
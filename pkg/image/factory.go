@@ -30,7 +30,7 @@ var (
 )
 
 type SourceUploader interface {
-	Upload(keychain authn.Keychain, ref, path string) (string, error)
+	Upload(keychain authn.Keychain, ref, path string, excludes []string) (string, error)
 }
 
 type Printer interface {
@@ -47,6 +47,7 @@ type Factory struct {
 	Blob                      string
 	LocalPath                 string
 	LocalPathDestinationImage string
+	Excludes                  []string
 	SubPath                   *string
 	Builder                   string
 	ClusterBuilder            string
@@ -167,6 +168,10 @@ func (f *Factory) validateSourceCreate() error {
 		return errors.New("git-revision is incompatible with blob and local path image sources")
 	}
 
+	if !sourceSet.contains("local-path") && len(f.Excludes) > 0 {
+		return errors.New("exclude can only be used with local path image sources")
+	}
+
 	return nil
 }
 
@@ -291,7 +296,7 @@ func (f *Factory) makeSource(tag string) (corev1alpha1.SourceConfig, error) {
 			return corev1alpha1.SourceConfig{}, err
 		}
 
-		sourceRef, err := f.SourceUploader.Upload(keychain, imgRepo, f.LocalPath)
+		sourceRef, err := f.SourceUploader.Upload(keychain, imgRepo, f.LocalPath, f.Excludes)
 		if err != nil {
 			return corev1alpha1.SourceConfig{}, err
 		}
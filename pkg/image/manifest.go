@@ -0,0 +1,128 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package image
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	ManifestAPIVersion = "kp.kpack.io/v1"
+	ManifestKind       = "ImageManifest"
+)
+
+// Manifest is a concise description of image resources, using the same
+// shorthand as the kp image flags.
+type Manifest struct {
+	APIVersion string          `json:"apiVersion"`
+	Kind       string          `json:"kind"`
+	Images     []ManifestImage `json:"images"`
+}
+
+type ManifestImage struct {
+	Name                      string            `json:"name"`
+	Namespace                 string            `json:"namespace,omitempty"`
+	Tag                       string            `json:"tag"`
+	AdditionalTags            []string          `json:"additionalTags,omitempty"`
+	Git                       *ManifestGit      `json:"git,omitempty"`
+	Blob                      string            `json:"blob,omitempty"`
+	LocalPath                 string            `json:"localPath,omitempty"`
+	LocalPathDestinationImage string            `json:"localPathDestinationImage,omitempty"`
+	Excludes                  []string          `json:"excludes,omitempty"`
+	SubPath                   string            `json:"subPath,omitempty"`
+	Builder                   string            `json:"builder,omitempty"`
+	ClusterBuilder            string            `json:"clusterBuilder,omitempty"`
+	Env                       map[string]string `json:"env,omitempty"`
+	ServiceBindings           []string          `json:"serviceBindings,omitempty"`
+	ServiceAccount            string            `json:"serviceAccount,omitempty"`
+	CacheSize                 string            `json:"cacheSize,omitempty"`
+	SuccessBuildHistoryLimit  *int64            `json:"successBuildHistoryLimit,omitempty"`
+	FailedBuildHistoryLimit   *int64            `json:"failedBuildHistoryLimit,omitempty"`
+}
+
+type ManifestGit struct {
+	URL      string `json:"url"`
+	Revision string `json:"revision,omitempty"`
+}
+
+func ReadManifest(data []byte) (Manifest, error) {
+	var m Manifest
+	if err := yaml.UnmarshalStrict(data, &m); err != nil {
+		return Manifest{}, errors.Wrap(err, "invalid image manifest")
+	}
+
+	if m.APIVersion != ManifestAPIVersion || m.Kind != ManifestKind {
+		return Manifest{}, errors.Errorf("image manifest must have apiVersion %s and kind %s", ManifestAPIVersion, ManifestKind)
+	}
+
+	if len(m.Images) == 0 {
+		return Manifest{}, errors.New("image manifest does not contain any images")
+	}
+
+	names := map[string]bool{}
+	for _, img := range m.Images {
+		if img.Name == "" {
+			return Manifest{}, errors.New("image name cannot be empty")
+		}
+		if img.Tag == "" {
+			return Manifest{}, errors.Errorf("image %q must have a tag", img.Name)
+		}
+
+		key := img.Namespace + "/" + img.Name
+		if names[key] {
+			return Manifest{}, errors.Errorf("duplicate image %q", img.Name)
+		}
+		names[key] = true
+	}
+
+	return m, nil
+}
+
+// Factory returns a Factory with the source, builder and build configuration
+// of the manifest image.
+func (m ManifestImage) Factory(uploader SourceUploader, printer Printer) *Factory {
+	f := &Factory{
+		SourceUploader:            uploader,
+		Printer:                   printer,
+		AdditionalTags:            m.AdditionalTags,
+		Blob:                      m.Blob,
+		LocalPath:                 m.LocalPath,
+		LocalPathDestinationImage: m.LocalPathDestinationImage,
+		Excludes:                  m.Excludes,
+		Builder:                   m.Builder,
+		ClusterBuilder:            m.ClusterBuilder,
+		ServiceBinding:            m.ServiceBindings,
+		ServiceAccount:            m.ServiceAccount,
+		CacheSize:                 m.CacheSize,
+	}
+
+	if m.Git != nil {
+		f.GitRepo = m.Git.URL
+		f.GitRevision = m.Git.Revision
+	}
+
+	if m.SubPath != "" {
+		subPath := m.SubPath
+		f.SubPath = &subPath
+	}
+
+	for key, value := range m.Env {
+		f.Env = append(f.Env, fmt.Sprintf("%s=%s", key, value))
+	}
+	sort.Strings(f.Env)
+
+	if m.SuccessBuildHistoryLimit != nil {
+		f.SuccessBuildHistoryLimit = strconv.FormatInt(*m.SuccessBuildHistoryLimit, 10)
+	}
+	if m.FailedBuildHistoryLimit != nil {
+		f.FailedBuildHistoryLimit = strconv.FormatInt(*m.FailedBuildHistoryLimit, 10)
+	}
+
+	return f
+}
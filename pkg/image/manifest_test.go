@@ -0,0 +1,92 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package image_test
+
+import (
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/image"
+)
+
+func TestManifest(t *testing.T) {
+	spec.Run(t, "TestManifest", testManifest)
+}
+
+func testManifest(t *testing.T, when spec.G, it spec.S) {
+	when("ReadManifest", func() {
+		it("reads the images of a manifest", func() {
+			m, err := image.ReadManifest([]byte(`apiVersion: kp.kpack.io/v1
+kind: ImageManifest
+images:
+- name: some-image
+  namespace: some-namespace
+  tag: some-registry.io/some-repo
+  git:
+    url: some-git-url
+  subPath: some-sub-path
+  builder: some-builder
+  env:
+    some-key: some-val
+    another-key: another-val
+  serviceBindings:
+  - some-binding
+  successBuildHistoryLimit: 5
+`))
+			require.NoError(t, err)
+			require.Len(t, m.Images, 1)
+
+			f := m.Images[0].Factory(nil, nil)
+			require.Equal(t, "some-git-url", f.GitRepo)
+			require.Equal(t, "some-sub-path", *f.SubPath)
+			require.Equal(t, "some-builder", f.Builder)
+			require.Equal(t, []string{"another-key=another-val", "some-key=some-val"}, f.Env)
+			require.Equal(t, []string{"some-binding"}, f.ServiceBinding)
+			require.Equal(t, "5", f.SuccessBuildHistoryLimit)
+			require.Equal(t, "", f.FailedBuildHistoryLimit)
+		})
+
+		it("fails with unknown fields", func() {
+			_, err := image.ReadManifest([]byte(`apiVersion: kp.kpack.io/v1
+kind: ImageManifest
+images:
+- name: some-image
+  tag: some-registry.io/some-repo
+  gitUrl: some-git-url
+`))
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "invalid image manifest")
+		})
+
+		it("fails with the wrong kind", func() {
+			_, err := image.ReadManifest([]byte(`apiVersion: kp.kpack.io/v1
+kind: DependencyDescriptor
+`))
+			require.EqualError(t, err, "image manifest must have apiVersion kp.kpack.io/v1 and kind ImageManifest")
+		})
+
+		it("fails without a tag", func() {
+			_, err := image.ReadManifest([]byte(`apiVersion: kp.kpack.io/v1
+kind: ImageManifest
+images:
+- name: some-image
+`))
+			require.EqualError(t, err, `image "some-image" must have a tag`)
+		})
+
+		it("fails with duplicate images", func() {
+			_, err := image.ReadManifest([]byte(`apiVersion: kp.kpack.io/v1
+kind: ImageManifest
+images:
+- name: some-image
+  tag: some-registry.io/some-repo
+- name: some-image
+  tag: some-registry.io/some-other-repo
+`))
+			require.EqualError(t, err, `duplicate image "some-image"`)
+		})
+	})
+}
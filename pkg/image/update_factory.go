@@ -83,6 +83,10 @@ func (f *Factory) validateSourceUpdate(img *v1alpha2.Image) error {
 		return errors.New("git-revision is incompatible with blob and local path image sources")
 	}
 
+	if !sourceSet.contains("local-path") && len(f.Excludes) > 0 {
+		return errors.New("exclude can only be used with local path image sources")
+	}
+
 	if len(sourceSet) == 0 && img.Spec.Source.Git == nil && f.GitRevision != "" {
 		return errors.New("git-revision is incompatible with existing image source")
 	}
@@ -195,7 +199,7 @@ func (f *Factory) setSource(image *v1alpha2.Image) error {
 			sourceImageDest = ref.Context().Name() + "-source"
 		}
 
-		sourceRef, err := f.SourceUploader.Upload(dockercreds.DefaultKeychain, sourceImageDest, f.LocalPath)
+		sourceRef, err := f.SourceUploader.Upload(dockercreds.DefaultKeychain, sourceImageDest, f.LocalPath, f.Excludes)
 		if err != nil {
 			return err
 		}
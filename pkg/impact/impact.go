@@ -0,0 +1,235 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package impact
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/client/clientset/versioned"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/commands"
+)
+
+const (
+	Rebase  = "rebase"
+	Rebuild = "rebuild"
+)
+
+// Change is a change to a resource builders depend on, and what it makes the
+// images using those builders do. Namespace is only set for Buildpacks. Ids
+// are the buildpack ids provided by a changed ClusterBuildpack or Buildpack,
+// which order entries that only reference an id resolve to.
+type Change struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Action    string
+	Ids       []string
+}
+
+// BuildpackChange returns the rebuild of a changed ClusterBuildpack or
+// Buildpack providing the buildpacks of its status.
+func BuildpackChange(kind, namespace, name string, buildpacks []corev1alpha1.BuildpackStatus) Change {
+	change := Change{Kind: kind, Namespace: namespace, Name: name, Action: Rebuild}
+	for _, buildpack := range buildpacks {
+		change.Ids = append(change.Ids, buildpack.Id)
+	}
+	return change
+}
+
+// StackChange returns the change of a ClusterStack, images only rebase onto
+// a new run image and are not affected by a new build image alone.
+func StackChange(old, updated *v1alpha2.ClusterStack) []Change {
+	if old.Spec.RunImage.Image == updated.Spec.RunImage.Image {
+		return nil
+	}
+	return []Change{{Kind: v1alpha2.ClusterStackKind, Name: updated.Name, Action: Rebase}}
+}
+
+// StoreChange returns the change of a ClusterStore, images rebuild when new
+// buildpacks are available to their builders.
+func StoreChange(old, updated *v1alpha2.ClusterStore) []Change {
+	if reflect.DeepEqual(old.Spec.Sources, updated.Spec.Sources) {
+		return nil
+	}
+	return []Change{{Kind: v1alpha2.ClusterStoreKind, Name: updated.Name, Action: Rebuild}}
+}
+
+// Image is an Image resource that would rebase or rebuild.
+type Image struct {
+	Namespace string
+	Name      string
+	Builder   string
+	Action    string
+}
+
+// Analyze returns the Images built by a ClusterBuilder or Builder that
+// references a changed resource, sorted by namespace and name.
+func Analyze(ctx context.Context, client versioned.Interface, changes []Change) ([]Image, error) {
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	affected := map[string]string{}
+
+	clusterBuilders, err := client.KpackV1alpha2().ClusterBuilders().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, builder := range clusterBuilders.Items {
+		if action := builderAction(builder.Spec.BuilderSpec, "", changes); action != "" {
+			affected[builderKey(v1alpha2.ClusterBuilderKind, "", builder.Name)] = action
+		}
+	}
+
+	builders, err := client.KpackV1alpha2().Builders("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, builder := range builders.Items {
+		if action := builderAction(builder.Spec.BuilderSpec, builder.Namespace, changes); action != "" {
+			affected[builderKey(v1alpha2.BuilderKind, builder.Namespace, builder.Name)] = action
+		}
+	}
+
+	if len(affected) == 0 {
+		return nil, nil
+	}
+
+	imgs, err := client.KpackV1alpha2().Images("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var images []Image
+	for _, img := range imgs.Items {
+		namespace := ""
+		if img.Spec.Builder.Kind == v1alpha2.BuilderKind {
+			namespace = img.Namespace
+		}
+
+		action, ok := affected[builderKey(img.Spec.Builder.Kind, namespace, img.Spec.Builder.Name)]
+		if !ok {
+			continue
+		}
+
+		images = append(images, Image{
+			Namespace: img.Namespace,
+			Name:      img.Name,
+			Builder:   fmt.Sprintf("%s/%s", img.Spec.Builder.Kind, img.Spec.Builder.Name),
+			Action:    action,
+		})
+	}
+
+	sort.Slice(images, func(i, j int) bool {
+		if images[i].Namespace != images[j].Namespace {
+			return images[i].Namespace < images[j].Namespace
+		}
+		return images[i].Name < images[j].Name
+	})
+	return images, nil
+}
+
+func builderAction(spec v1alpha2.BuilderSpec, namespace string, changes []Change) string {
+	action := ""
+	for _, change := range changes {
+		if !references(spec, namespace, change) {
+			continue
+		}
+		if change.Action == Rebuild || action == "" {
+			action = change.Action
+		}
+	}
+	return action
+}
+
+func references(spec v1alpha2.BuilderSpec, namespace string, change Change) bool {
+	switch change.Kind {
+	case v1alpha2.ClusterStackKind:
+		return spec.Stack.Name == change.Name
+	case v1alpha2.ClusterStoreKind:
+		return spec.Store.Name == change.Name
+	case v1alpha2.BuildpackKind:
+		if namespace != change.Namespace {
+			return false
+		}
+	}
+
+	for _, entry := range spec.Order {
+		for _, ref := range entry.Group {
+			if ref.Kind == change.Kind && ref.Name == change.Name {
+				return true
+			}
+			if ref.Name == "" && ref.Image == "" && ref.Id != "" && providesId(change, ref.Id) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func providesId(change Change, id string) bool {
+	for _, changed := range change.Ids {
+		if changed == id {
+			return true
+		}
+	}
+	return false
+}
+
+func builderKey(kind, namespace, name string) string {
+	return fmt.Sprintf("%s/%s/%s", kind, namespace, name)
+}
+
+// Write writes a table of the images and their count per namespace.
+func Write(w io.Writer, images []Image) error {
+	if _, err := fmt.Fprint(w, "Impact\n\n"); err != nil {
+		return err
+	}
+
+	if len(images) == 0 {
+		_, err := fmt.Fprint(w, "No Images affected\n\n")
+		return err
+	}
+
+	writer, err := commands.NewTableWriter(w, "Namespace", "Image", "Builder", "Action")
+	if err != nil {
+		return err
+	}
+
+	var namespaces []string
+	counts := map[string]map[string]int{}
+	for _, img := range images {
+		if err := writer.AddRow(img.Namespace, img.Name, img.Builder, img.Action); err != nil {
+			return err
+		}
+
+		if _, ok := counts[img.Namespace]; !ok {
+			namespaces = append(namespaces, img.Namespace)
+			counts[img.Namespace] = map[string]int{}
+		}
+		counts[img.Namespace][img.Action]++
+	}
+	if err := writer.Write(); err != nil {
+		return err
+	}
+
+	writer, err = commands.NewTableWriter(w, "Namespace", "Rebase", "Rebuild")
+	if err != nil {
+		return err
+	}
+	for _, namespace := range namespaces {
+		if err := writer.AddRow(namespace, fmt.Sprint(counts[namespace][Rebase]), fmt.Sprint(counts[namespace][Rebuild])); err != nil {
+			return err
+		}
+	}
+	return writer.Write()
+}
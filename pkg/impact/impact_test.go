@@ -0,0 +1,173 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package impact_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	kpackfakes "github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/impact"
+)
+
+func TestImpact(t *testing.T) {
+	spec.Run(t, "TestImpact", testImpact)
+}
+
+func testImpact(t *testing.T, when spec.G, it spec.S) {
+	builderSpec := func(stack, store string, clusterBuildpacks ...string) v1alpha2.BuilderSpec {
+		var group []v1alpha2.BuilderBuildpackRef
+		for _, bp := range clusterBuildpacks {
+			group = append(group, v1alpha2.BuilderBuildpackRef{ObjectReference: corev1.ObjectReference{Kind: v1alpha2.ClusterBuildpackKind, Name: bp}})
+		}
+		return v1alpha2.BuilderSpec{
+			Stack: corev1.ObjectReference{Kind: v1alpha2.ClusterStackKind, Name: stack},
+			Store: corev1.ObjectReference{Kind: v1alpha2.ClusterStoreKind, Name: store},
+			Order: []v1alpha2.BuilderOrderEntry{{Group: group}},
+		}
+	}
+	image := func(namespace, name, builderKind, builderName string) *v1alpha2.Image {
+		return &v1alpha2.Image{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec: v1alpha2.ImageSpec{
+				Builder: corev1.ObjectReference{Kind: builderKind, Name: builderName},
+			},
+		}
+	}
+
+	client := kpackfakes.NewSimpleClientset(
+		&v1alpha2.ClusterBuilder{
+			ObjectMeta: metav1.ObjectMeta{Name: "some-cluster-builder"},
+			Spec:       v1alpha2.ClusterBuilderSpec{BuilderSpec: builderSpec("some-stack", "some-store", "some-buildpack")},
+		},
+		&v1alpha2.ClusterBuilder{
+			ObjectMeta: metav1.ObjectMeta{Name: "other-cluster-builder"},
+			Spec:       v1alpha2.ClusterBuilderSpec{BuilderSpec: builderSpec("other-stack", "other-store")},
+		},
+		&v1alpha2.ClusterBuilder{
+			ObjectMeta: metav1.ObjectMeta{Name: "id-cluster-builder"},
+			Spec: v1alpha2.ClusterBuilderSpec{BuilderSpec: v1alpha2.BuilderSpec{
+				Stack: corev1.ObjectReference{Kind: v1alpha2.ClusterStackKind, Name: "other-stack"},
+				Order: []v1alpha2.BuilderOrderEntry{{Group: []v1alpha2.BuilderBuildpackRef{
+					{BuildpackRef: corev1alpha1.BuildpackRef{BuildpackInfo: corev1alpha1.BuildpackInfo{Id: "some-buildpack-id"}}},
+				}}},
+			}},
+		},
+		&v1alpha2.Builder{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns-b", Name: "some-builder"},
+			Spec:       v1alpha2.NamespacedBuilderSpec{BuilderSpec: builderSpec("some-stack", "other-store")},
+		},
+		image("ns-b", "img-3", v1alpha2.BuilderKind, "some-builder"),
+		image("ns-a", "img-2", v1alpha2.ClusterBuilderKind, "some-cluster-builder"),
+		image("ns-a", "img-1", v1alpha2.ClusterBuilderKind, "some-cluster-builder"),
+		image("ns-a", "img-4", v1alpha2.ClusterBuilderKind, "other-cluster-builder"),
+		image("ns-c", "img-5", v1alpha2.BuilderKind, "some-builder"),
+		image("ns-c", "img-6", v1alpha2.ClusterBuilderKind, "id-cluster-builder"),
+	)
+
+	when("Analyze", func() {
+		it("returns the images of builders that reference a changed stack", func() {
+			images, err := impact.Analyze(context.Background(), client, []impact.Change{
+				{Kind: v1alpha2.ClusterStackKind, Name: "some-stack", Action: impact.Rebase},
+			})
+			require.NoError(t, err)
+
+			require.Equal(t, []impact.Image{
+				{Namespace: "ns-a", Name: "img-1", Builder: "ClusterBuilder/some-cluster-builder", Action: impact.Rebase},
+				{Namespace: "ns-a", Name: "img-2", Builder: "ClusterBuilder/some-cluster-builder", Action: impact.Rebase},
+				{Namespace: "ns-b", Name: "img-3", Builder: "Builder/some-builder", Action: impact.Rebase},
+			}, images)
+		})
+
+		it("prefers rebuilds over rebases", func() {
+			images, err := impact.Analyze(context.Background(), client, []impact.Change{
+				{Kind: v1alpha2.ClusterStackKind, Name: "some-stack", Action: impact.Rebase},
+				{Kind: v1alpha2.ClusterBuildpackKind, Name: "some-buildpack", Action: impact.Rebuild},
+			})
+			require.NoError(t, err)
+
+			require.Equal(t, []impact.Image{
+				{Namespace: "ns-a", Name: "img-1", Builder: "ClusterBuilder/some-cluster-builder", Action: impact.Rebuild},
+				{Namespace: "ns-a", Name: "img-2", Builder: "ClusterBuilder/some-cluster-builder", Action: impact.Rebuild},
+				{Namespace: "ns-b", Name: "img-3", Builder: "Builder/some-builder", Action: impact.Rebase},
+			}, images)
+		})
+
+		it("returns the images of builders that reference a changed buildpack by id", func() {
+			images, err := impact.Analyze(context.Background(), client, []impact.Change{
+				impact.BuildpackChange(v1alpha2.ClusterBuildpackKind, "", "unreferenced-buildpack", []corev1alpha1.BuildpackStatus{
+					{BuildpackInfo: corev1alpha1.BuildpackInfo{Id: "some-buildpack-id"}},
+				}),
+			})
+			require.NoError(t, err)
+
+			require.Equal(t, []impact.Image{
+				{Namespace: "ns-c", Name: "img-6", Builder: "ClusterBuilder/id-cluster-builder", Action: impact.Rebuild},
+			}, images)
+		})
+
+		it("returns nothing without changes", func() {
+			images, err := impact.Analyze(context.Background(), client, nil)
+			require.NoError(t, err)
+			require.Empty(t, images)
+		})
+	})
+
+	when("StackChange", func() {
+		it("only returns a change for a new run image", func() {
+			old := &v1alpha2.ClusterStack{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-stack"},
+				Spec: v1alpha2.ClusterStackSpec{
+					BuildImage: v1alpha2.ClusterStackSpecImage{Image: "build@sha256:1"},
+					RunImage:   v1alpha2.ClusterStackSpecImage{Image: "run@sha256:1"},
+				},
+			}
+
+			updated := old.DeepCopy()
+			updated.Spec.BuildImage.Image = "build@sha256:2"
+			require.Empty(t, impact.StackChange(old, updated))
+
+			updated.Spec.RunImage.Image = "run@sha256:2"
+			require.Equal(t, []impact.Change{{Kind: v1alpha2.ClusterStackKind, Name: "some-stack", Action: impact.Rebase}}, impact.StackChange(old, updated))
+		})
+	})
+
+	when("Write", func() {
+		it("writes the images and their count per namespace", func() {
+			out := &bytes.Buffer{}
+			require.NoError(t, impact.Write(out, []impact.Image{
+				{Namespace: "ns-a", Name: "img-1", Builder: "ClusterBuilder/some-cluster-builder", Action: impact.Rebuild},
+				{Namespace: "ns-a", Name: "img-2", Builder: "ClusterBuilder/some-cluster-builder", Action: impact.Rebase},
+				{Namespace: "ns-b", Name: "img-3", Builder: "Builder/some-builder", Action: impact.Rebase},
+			}))
+
+			require.Equal(t, `Impact
+
+NAMESPACE    IMAGE    BUILDER                                ACTION
+ns-a         img-1    ClusterBuilder/some-cluster-builder    rebuild
+ns-a         img-2    ClusterBuilder/some-cluster-builder    rebase
+ns-b         img-3    Builder/some-builder                   rebase
+
+NAMESPACE    REBASE    REBUILD
+ns-a         1         1
+ns-b         1         0
+
+`, out.String())
+		})
+
+		it("writes when no images are affected", func() {
+			out := &bytes.Buffer{}
+			require.NoError(t, impact.Write(out, nil))
+			require.Equal(t, "Impact\n\nNo Images affected\n\n", out.String())
+		})
+	})
+}
@@ -0,0 +1,192 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package _import
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/archive"
+	"github.com/buildpacks-community/kpack-cli/pkg/registry"
+)
+
+const bundleDescriptorFile = "descriptor.yaml"
+
+// BundleWriter writes a dependency descriptor and every image it references
+// into a single OCI layout tarball that can be imported without registry access.
+type BundleWriter struct {
+	printer Printer
+	fetcher registry.Fetcher
+}
+
+func NewBundleWriter(printer Printer, fetcher registry.Fetcher) *BundleWriter {
+	return &BundleWriter{printer: printer, fetcher: fetcher}
+}
+
+func (b *BundleWriter) WriteBundle(keychain authn.Keychain, rawDescriptor, bundlePath string) error {
+	desc, err := ReadDescriptor(rawDescriptor)
+	if err != nil {
+		return err
+	}
+
+	tempDir, err := ioutil.TempDir("", "kp-bundle")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tempDir)
+
+	layoutPath, err := registry.NewLayout(tempDir)
+	if err != nil {
+		return err
+	}
+
+	for _, ref := range descriptorImages(desc) {
+		if err := b.printer.PrintStatus("Exporting '%s'...", ref); err != nil {
+			return err
+		}
+
+		img, err := b.fetcher.Fetch(keychain, ref)
+		if err != nil {
+			return err
+		}
+
+		if err := registry.WriteLayoutImage(layoutPath, ref, img); err != nil {
+			return err
+		}
+
+		if err := b.writeSignature(keychain, layoutPath, ref, img); err != nil {
+			return err
+		}
+	}
+
+	err = ioutil.WriteFile(filepath.Join(tempDir, bundleDescriptorFile), []byte(rawDescriptor), 0644)
+	if err != nil {
+		return err
+	}
+
+	bundle, err := os.Create(bundlePath)
+	if err != nil {
+		return err
+	}
+
+	if err := archive.WriteTar(bundle, tempDir); err != nil {
+		bundle.Close()
+		return err
+	}
+	return bundle.Close()
+}
+
+// writeSignature adds the cosign signature of img to the layout so the bundle
+// can be imported with signature verification. Images without a signature
+// are exported as is.
+func (b *BundleWriter) writeSignature(keychain authn.Keychain, layoutPath layout.Path, ref string, img v1.Image) error {
+	sigRef, err := registry.SignatureTag(ref, img)
+	if err != nil {
+		return err
+	}
+
+	sigImg, err := b.fetcher.Fetch(keychain, sigRef)
+	if registry.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Wrapf(err, "fetching signature '%s'", sigRef)
+	}
+
+	return registry.WriteLayoutImage(layoutPath, sigRef, sigImg)
+}
+
+// Bundle is an extracted dependency bundle. Close removes the extracted files.
+type Bundle struct {
+	dir           string
+	RawDescriptor string
+	Fetcher       registry.Fetcher
+}
+
+func ReadBundle(bundlePath string) (*Bundle, error) {
+	dir, err := ioutil.TempDir("", "kp-bundle")
+	if err != nil {
+		return nil, err
+	}
+
+	bundle, err := readBundleDir(bundlePath, dir)
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	return bundle, nil
+}
+
+func readBundleDir(bundlePath, dir string) (*Bundle, error) {
+	file, err := os.Open(bundlePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	if err := archive.ReadTar(file, dir); err != nil {
+		return nil, errors.Wrapf(err, "invalid bundle %s", bundlePath)
+	}
+
+	rawDescriptor, err := ioutil.ReadFile(filepath.Join(dir, bundleDescriptorFile))
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid bundle %s", bundlePath)
+	}
+
+	fetcher, err := registry.NewLayoutFetcher(dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid bundle %s", bundlePath)
+	}
+
+	return &Bundle{
+		dir:           dir,
+		RawDescriptor: string(rawDescriptor),
+		Fetcher:       fetcher,
+	}, nil
+}
+
+func (b *Bundle) Close() error {
+	return os.RemoveAll(b.dir)
+}
+
+func descriptorImages(desc DependencyDescriptor) []string {
+	var refs []string
+	seen := map[string]bool{}
+	add := func(ref string) {
+		if !seen[ref] {
+			seen[ref] = true
+			refs = append(refs, ref)
+		}
+	}
+
+	for _, lifecycle := range GetClusterLifecycles(desc) {
+		add(lifecycle.Image)
+	}
+
+	for _, buildpack := range GetClusterBuildpacks(desc) {
+		add(buildpack.Image)
+	}
+
+	for _, buildpack := range GetBuildpacks(desc) {
+		add(buildpack.Image)
+	}
+
+	for _, store := range GetClusterStores(desc) {
+		for _, src := range store.Sources {
+			add(src.Image)
+		}
+	}
+
+	for _, stack := range GetClusterStacks(desc) {
+		add(stack.BuildImage.Image)
+		add(stack.RunImage.Image)
+	}
+
+	return refs
+}
@@ -0,0 +1,149 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package _import
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/registry"
+	"github.com/buildpacks-community/kpack-cli/pkg/testhelpers"
+)
+
+func TestBundle(t *testing.T) {
+	spec.Run(t, "TestBundle", testBundle)
+}
+
+func testBundle(t *testing.T, when spec.G, it spec.S) {
+	const rawDescriptor = `apiVersion: kp.kpack.io/v1
+kind: DependencyDescriptor
+clusterLifecycles:
+- name: default
+  image: some-registry.io/repo/lifecycle
+clusterStores:
+- name: some-store
+  sources:
+  - image: some-registry.io/repo/buildpack
+clusterStacks:
+- name: some-stack
+  buildImage:
+    image: some-registry.io/repo/build
+  runImage:
+    image: some-registry.io/repo/run
+- name: another-stack
+  buildImage:
+    image: some-registry.io/repo/build
+  runImage:
+    image: some-registry.io/repo/run
+`
+
+	var (
+		images     map[string]v1.Image
+		tempDir    string
+		bundlePath string
+		out        *bytes.Buffer
+	)
+
+	it.Before(func() {
+		images = map[string]v1.Image{}
+		for _, ref := range []string{
+			"some-registry.io/repo/lifecycle",
+			"some-registry.io/repo/buildpack",
+			"some-registry.io/repo/build",
+			"some-registry.io/repo/run",
+		} {
+			img, err := random.Image(10, 1)
+			require.NoError(t, err)
+			images[ref] = img
+		}
+
+		var err error
+		tempDir, err = ioutil.TempDir("", "bundle-test")
+		require.NoError(t, err)
+		bundlePath = filepath.Join(tempDir, "bundle.tar")
+		out = &bytes.Buffer{}
+	})
+
+	it.After(func() {
+		require.NoError(t, os.RemoveAll(tempDir))
+	})
+
+	it("round trips the descriptor and every referenced image", func() {
+		writer := NewBundleWriter(testLogger{writer: out}, &fakeFetcher{Images: images})
+		require.NoError(t, writer.WriteBundle(authn.DefaultKeychain, rawDescriptor, bundlePath))
+
+		require.Equal(t, "Exporting 'some-registry.io/repo/lifecycle'..."+
+			"Exporting 'some-registry.io/repo/buildpack'..."+
+			"Exporting 'some-registry.io/repo/build'..."+
+			"Exporting 'some-registry.io/repo/run'...", out.String())
+
+		bundle, err := ReadBundle(bundlePath)
+		require.NoError(t, err)
+		defer bundle.Close()
+
+		require.Equal(t, rawDescriptor, bundle.RawDescriptor)
+
+		for ref, expected := range images {
+			img, err := bundle.Fetcher.Fetch(authn.DefaultKeychain, ref)
+			require.NoError(t, err)
+
+			expectedDigest, err := expected.Digest()
+			require.NoError(t, err)
+			digest, err := img.Digest()
+			require.NoError(t, err)
+			require.Equal(t, expectedDigest, digest)
+		}
+
+		_, err = bundle.Fetcher.Fetch(authn.DefaultKeychain, "some-registry.io/repo/missing")
+		require.EqualError(t, err, "image 'some-registry.io/repo/missing' not found in bundle")
+	})
+
+	it("includes cosign signatures so the bundle can be imported with verification", func() {
+		key, publicKey := testhelpers.MakeCosignKey(t)
+		digest, err := images["some-registry.io/repo/run"].Digest()
+		require.NoError(t, err)
+		images["some-registry.io/repo/run:"+strings.Replace(digest.String(), ":", "-", 1)+".sig"] = testhelpers.MakeCosignSignature(t, key, digest)
+
+		writer := NewBundleWriter(testLogger{writer: out}, &fakeFetcher{Images: images})
+		require.NoError(t, writer.WriteBundle(authn.DefaultKeychain, rawDescriptor, bundlePath))
+
+		bundle, err := ReadBundle(bundlePath)
+		require.NoError(t, err)
+		defer bundle.Close()
+
+		fetcher, err := registry.NewVerifyingFetcher(bundle.Fetcher, registry.VerificationConfig{PublicKeyPath: publicKey})
+		require.NoError(t, err)
+
+		_, err = fetcher.Fetch(authn.DefaultKeychain, "some-registry.io/repo/run")
+		require.NoError(t, err)
+
+		_, err = fetcher.Fetch(authn.DefaultKeychain, "some-registry.io/repo/build")
+		require.Error(t, err)
+	})
+
+	it("errors when an image cannot be fetched", func() {
+		delete(images, "some-registry.io/repo/run")
+
+		writer := NewBundleWriter(testLogger{writer: out}, &fakeFetcher{Images: images})
+		err := writer.WriteBundle(authn.DefaultKeychain, rawDescriptor, bundlePath)
+		require.Error(t, err)
+	})
+
+	it("errors when the bundle is not a valid bundle", func() {
+		require.NoError(t, ioutil.WriteFile(bundlePath, []byte("not a tar"), 0644))
+
+		_, err := ReadBundle(bundlePath)
+		require.Error(t, err)
+	})
+}
@@ -20,7 +20,8 @@ func SummarizeChange(
 	desc DependencyDescriptor,
 	kpConfig config.KpConfig,
 	relocatedImageProvider RelocatedImageProvider,
-	differ Differ, cs buildk8s.ClientSet) (hasChanges bool, changes string, err error) {
+	differ Differ, cs buildk8s.ClientSet,
+	pruneCandidates []PruneCandidate) (hasChanges bool, changes string, err error) {
 
 	var summarizer changeSummarizer
 	iDiffer := &ImportDiffer{
@@ -53,6 +54,28 @@ func SummarizeChange(
 		return
 	}
 
+	// Namespaced resources only exist in v2 descriptors, so only summarize them when present
+	if buildpacks := GetBuildpacks(desc); len(buildpacks) > 0 {
+		err = writeBuildpacksChange(ctx, keychain, kpConfig, buildpacks, iDiffer, cs, &summarizer)
+		if err != nil {
+			return
+		}
+	}
+
+	if builders := GetBuilders(desc); len(builders) > 0 {
+		err = writeBuildersChange(ctx, kpConfig, builders, iDiffer, cs, &summarizer)
+		if err != nil {
+			return
+		}
+	}
+
+	if len(pruneCandidates) > 0 {
+		err = writePruneChange(pruneCandidates, iDiffer, &summarizer)
+		if err != nil {
+			return
+		}
+	}
+
 	return summarizer.hasChanges, summarizer.changes.String(), nil
 }
 
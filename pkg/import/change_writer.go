@@ -5,13 +5,16 @@ package _import
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/buildpacks-community/kpack-cli/pkg/config"
 	buildk8s "github.com/buildpacks-community/kpack-cli/pkg/k8s"
+	"github.com/buildpacks-community/kpack-cli/pkg/registry"
 )
 
 type changeWriter interface {
@@ -19,6 +22,15 @@ type changeWriter interface {
 	writeChange(header string)
 }
 
+// skippedDiff describes a resource that is left out of the import because
+// one of its images was skipped by the signature verification policy.
+func skippedDiff(kind, name string, err error) (string, error) {
+	if !registry.IsVerificationSkipped(err) {
+		return "", err
+	}
+	return fmt.Sprintf("Skipping %s '%s': %s", kind, name, err), nil
+}
+
 func writeClusterLifecyclesChange(ctx context.Context, keychain authn.Keychain, kpConfig config.KpConfig, lifecycles []ClusterLifecycle, differ *ImportDiffer, cs buildk8s.ClientSet, cw changeWriter) error {
 	for _, lifecycle := range lifecycles {
 		oldLifecycle, err := cs.KpackClient.KpackV1alpha2().ClusterLifecycles().Get(ctx, lifecycle.Name, metav1.GetOptions{})
@@ -31,7 +43,9 @@ func writeClusterLifecyclesChange(ctx context.Context, keychain authn.Keychain,
 
 		diff, err := differ.DiffClusterLifecycle(keychain, kpConfig, oldLifecycle, lifecycle)
 		if err != nil {
-			return err
+			if diff, err = skippedDiff(v1alpha2.ClusterLifecycleKind, lifecycle.Name, err); err != nil {
+				return err
+			}
 		}
 		if err = cw.writeDiff(diff); err != nil {
 			return err
@@ -54,7 +68,9 @@ func writeClusterBuildpacksChange(ctx context.Context, keychain authn.Keychain,
 
 		diff, err := differ.DiffClusterBuildpack(keychain, kpConfig, oldBuildpack, buildpack)
 		if err != nil {
-			return err
+			if diff, err = skippedDiff(v1alpha2.ClusterBuildpackKind, buildpack.Name, err); err != nil {
+				return err
+			}
 		}
 		if err = cw.writeDiff(diff); err != nil {
 			return err
@@ -77,7 +93,9 @@ func writeClusterStoresChange(ctx context.Context, keychain authn.Keychain, kpCo
 
 		diff, err := differ.DiffClusterStore(keychain, kpConfig, oldStore, store)
 		if err != nil {
-			return err
+			if diff, err = skippedDiff(v1alpha2.ClusterStoreKind, store.Name, err); err != nil {
+				return err
+			}
 		}
 		if err = cw.writeDiff(diff); err != nil {
 			return err
@@ -100,7 +118,9 @@ func writeClusterStacksChange(ctx context.Context, keychain authn.Keychain, kpCo
 
 		diff, err := differ.DiffClusterStack(keychain, kpConfig, oldStack, stack)
 		if err != nil {
-			return err
+			if diff, err = skippedDiff(v1alpha2.ClusterStackKind, stack.Name, err); err != nil {
+				return err
+			}
 		}
 		if err = cw.writeDiff(diff); err != nil {
 			return err
@@ -133,3 +153,66 @@ func writeClusterBuildersChange(ctx context.Context, builders []ClusterBuilder,
 	cw.writeChange("ClusterBuilders")
 	return nil
 }
+
+func writeBuildpacksChange(ctx context.Context, keychain authn.Keychain, kpConfig config.KpConfig, buildpacks []Buildpack, differ *ImportDiffer, cs buildk8s.ClientSet, cw changeWriter) error {
+	for _, buildpack := range buildpacks {
+		oldBuildpack, err := cs.KpackClient.KpackV1alpha2().Buildpacks(buildpack.Namespace).Get(ctx, buildpack.Name, metav1.GetOptions{})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+		if k8serrors.IsNotFound(err) {
+			oldBuildpack = nil
+		}
+
+		diff, err := differ.DiffBuildpack(keychain, kpConfig, oldBuildpack, buildpack)
+		if err != nil {
+			if diff, err = skippedDiff(v1alpha2.BuildpackKind, buildpack.Namespace+"/"+buildpack.Name, err); err != nil {
+				return err
+			}
+		}
+		if err = cw.writeDiff(diff); err != nil {
+			return err
+		}
+	}
+
+	cw.writeChange("Buildpacks")
+	return nil
+}
+
+func writeBuildersChange(ctx context.Context, kpConfig config.KpConfig, builders []Builder, differ *ImportDiffer, cs buildk8s.ClientSet, cw changeWriter) error {
+	for _, builder := range builders {
+		oldBuilder, err := cs.KpackClient.KpackV1alpha2().Builders(builder.Namespace).Get(ctx, builder.Name, metav1.GetOptions{})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+		if k8serrors.IsNotFound(err) {
+			oldBuilder = nil
+		}
+
+		diff, err := differ.DiffBuilder(kpConfig, oldBuilder, builder)
+		if err != nil {
+			return err
+		}
+		if err = cw.writeDiff(diff); err != nil {
+			return err
+		}
+	}
+
+	cw.writeChange("Builders")
+	return nil
+}
+
+func writePruneChange(candidates []PruneCandidate, differ *ImportDiffer, cw changeWriter) error {
+	for _, candidate := range candidates {
+		diff, err := differ.Differ.Diff(candidate, nil)
+		if err != nil {
+			return err
+		}
+		if err = cw.writeDiff(diff); err != nil {
+			return err
+		}
+	}
+
+	cw.writeChange("Pruned Resources")
+	return nil
+}
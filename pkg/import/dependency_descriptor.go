@@ -9,9 +9,10 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/buildpacks-community/kpack-cli/pkg/import/descriptor"
+	"github.com/buildpacks-community/kpack-cli/pkg/registry"
 )
 
-const CurrentAPIVersion = descriptor.APIVersionV1
+const CurrentAPIVersion = descriptor.APIVersionV2
 
 type API struct {
 	Version string `yaml:"apiVersion" json:"apiVersion"`
@@ -28,9 +29,21 @@ type (
 	ClusterStore                 = descriptor.ClusterStore
 	ClusterStack                 = descriptor.ClusterStack
 	ClusterBuilder               = descriptor.ClusterBuilder
+	Buildpack                    = descriptor.Buildpack
+	Builder                      = descriptor.Builder
+	Verification                 = descriptor.Verification
 )
 
 func ValidateDescriptor(d DependencyDescriptor) error {
+	if d.Verification != nil {
+		if d.Verification.PublicKey == "" {
+			return errors.New("verification public key cannot be empty")
+		}
+		if err := GetVerificationConfig(d, registry.VerificationConfig{}).Validate(); err != nil {
+			return err
+		}
+	}
+
 	lifecycleSet := map[string]bool{}
 	for _, lifecycle := range d.ClusterLifecycles {
 		if lifecycle.Name == "" {
@@ -117,6 +130,48 @@ func ValidateDescriptor(d DependencyDescriptor) error {
 		return errors.Errorf("default cluster builder '%s' not found", d.DefaultClusterBuilder)
 	}
 
+	namespacedBuildpackSet := map[string]bool{}
+	for _, buildpack := range d.Buildpacks {
+		if buildpack.Name == "" {
+			return errors.New("buildpack name cannot be empty")
+		}
+		if buildpack.Namespace == "" {
+			return errors.Errorf("buildpack '%s' must have a namespace", buildpack.Name)
+		}
+		key := buildpack.Namespace + "/" + buildpack.Name
+		if namespacedBuildpackSet[key] {
+			return errors.Errorf("duplicate buildpack name '%s' in namespace '%s'", buildpack.Name, buildpack.Namespace)
+		}
+		namespacedBuildpackSet[key] = true
+
+		_, err := name.ParseReference(buildpack.Image, name.WeakValidation)
+		if err != nil {
+			return err
+		}
+	}
+
+	builderSet := map[string]bool{}
+	for _, builder := range d.Builders {
+		if builder.Name == "" {
+			return errors.New("builder name cannot be empty")
+		}
+		if builder.Namespace == "" {
+			return errors.Errorf("builder '%s' must have a namespace", builder.Name)
+		}
+		key := builder.Namespace + "/" + builder.Name
+		if builderSet[key] {
+			return errors.Errorf("duplicate builder name '%s' in namespace '%s'", builder.Name, builder.Namespace)
+		}
+		builderSet[key] = true
+
+		if builder.Tag != "" {
+			_, err := name.NewTag(builder.Tag, name.WeakValidation)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -157,6 +212,14 @@ func GetClusterStacks(d DependencyDescriptor) []ClusterStack {
 	return stacks
 }
 
+func GetBuildpacks(d DependencyDescriptor) []Buildpack {
+	return d.Buildpacks
+}
+
+func GetBuilders(d DependencyDescriptor) []Builder {
+	return d.Builders
+}
+
 func GetClusterBuilders(d DependencyDescriptor) []ClusterBuilder {
 	builders := d.ClusterBuilders
 	for _, cb := range d.ClusterBuilders {
@@ -172,3 +235,18 @@ func GetClusterBuilders(d DependencyDescriptor) []ClusterBuilder {
 	}
 	return builders
 }
+
+// GetVerificationConfig returns the signature verification of the descriptor,
+// with the public key and policy of cfg taking precedence when they are set.
+func GetVerificationConfig(d DependencyDescriptor, cfg registry.VerificationConfig) registry.VerificationConfig {
+	if d.Verification == nil {
+		return cfg
+	}
+	if cfg.PublicKeyPath == "" {
+		cfg.PublicKeyPath = d.Verification.PublicKey
+	}
+	if cfg.Policy == "" {
+		cfg.Policy = d.Verification.Policy
+	}
+	return cfg
+}
@@ -138,6 +138,32 @@ func testDescriptor(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("there is a namespaced buildpack without a namespace", func() {
+			it("fails validation", func() {
+				descWithoutNamespace := importpkg.DependencyDescriptor{
+					Buildpacks: []importpkg.Buildpack{
+						{Name: "my-bp", Image: "image1"},
+					},
+				}
+				err := importpkg.ValidateDescriptor(descWithoutNamespace)
+				require.EqualError(t, err, "buildpack 'my-bp' must have a namespace")
+			})
+		})
+
+		when("there is a duplicate namespaced builder name", func() {
+			it("fails validation with the duplicate name in the error message", func() {
+				descWithDupe := importpkg.DependencyDescriptor{
+					Builders: []importpkg.Builder{
+						{Name: "my-builder", Namespace: "team-a", ClusterStack: "some-stack"},
+						{Name: "my-builder", Namespace: "team-a", ClusterStack: "some-stack"},
+						{Name: "my-builder", Namespace: "team-b", ClusterStack: "some-stack"},
+					},
+				}
+				err := importpkg.ValidateDescriptor(descWithDupe)
+				require.EqualError(t, err, "duplicate builder name 'my-builder' in namespace 'team-a'")
+			})
+		})
+
 		when("there is a duplicate store name", func() {
 			it("fails validation with the duplicate name in the error message", func() {
 				descWithDupe := importpkg.DependencyDescriptor{
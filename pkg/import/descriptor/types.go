@@ -11,7 +11,7 @@ import (
 
 // Source represents an image source
 type Source struct {
-	Image string `yaml:"image"`
+	Image string `yaml:"image" json:"image"`
 }
 
 // ClusterStore represents a ClusterStore in the descriptor
@@ -17,10 +17,12 @@ type ClusterBuildpack struct {
 	Image string `yaml:"image" json:"image"`
 }
 
-// DependencyDescriptor represents the target v1 format that all conversions produce
+// DependencyDescriptor represents the target format that all conversions produce.
+// Buildpacks, Builders and Verification are only allowed with APIVersionV2.
 type DependencyDescriptor struct {
 	APIVersion              string             `yaml:"apiVersion" json:"apiVersion"`
 	Kind                    string             `yaml:"kind" json:"kind"`
+	Verification            *Verification      `yaml:"verification,omitempty" json:"verification,omitempty"`
 	DefaultClusterLifecycle string             `yaml:"defaultClusterLifecycle,omitempty" json:"defaultClusterLifecycle,omitempty"`
 	DefaultClusterStack     string             `yaml:"defaultClusterStack,omitempty" json:"defaultClusterStack,omitempty"`
 	DefaultClusterBuilder   string             `yaml:"defaultClusterBuilder,omitempty" json:"defaultClusterBuilder,omitempty"`
@@ -29,4 +31,6 @@ type DependencyDescriptor struct {
 	ClusterStores           []ClusterStore     `yaml:"clusterStores,omitempty" json:"clusterStores,omitempty"`
 	ClusterStacks           []ClusterStack     `yaml:"clusterStacks,omitempty" json:"clusterStacks,omitempty"`
 	ClusterBuilders         []ClusterBuilder   `yaml:"clusterBuilders,omitempty" json:"clusterBuilders,omitempty"`
+	Buildpacks              []Buildpack        `yaml:"buildpacks,omitempty" json:"buildpacks,omitempty"`
+	Builders                []Builder          `yaml:"builders,omitempty" json:"builders,omitempty"`
 }
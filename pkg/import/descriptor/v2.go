@@ -0,0 +1,37 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package descriptor
+
+import (
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+)
+
+// APIVersionV2 is the API version string for v2 descriptors, which add
+// namespaced Buildpacks, Builders and signature verification to the v1 format
+const APIVersionV2 = "kp.kpack.io/v2"
+
+// Verification configures the signature verification of the images in the v2 descriptor
+type Verification struct {
+	PublicKey string `yaml:"publicKey" json:"publicKey"`
+	Policy    string `yaml:"policy,omitempty" json:"policy,omitempty"`
+}
+
+// Buildpack represents a namespaced Buildpack in the v2 descriptor
+type Buildpack struct {
+	Name           string `yaml:"name" json:"name"`
+	Namespace      string `yaml:"namespace" json:"namespace"`
+	Image          string `yaml:"image" json:"image"`
+	ServiceAccount string `yaml:"serviceAccount,omitempty" json:"serviceAccount,omitempty"`
+}
+
+// Builder represents a namespaced Builder in the v2 descriptor
+type Builder struct {
+	Name           string                       `yaml:"name" json:"name"`
+	Namespace      string                       `yaml:"namespace" json:"namespace"`
+	Tag            string                       `yaml:"tag,omitempty" json:"tag,omitempty"`
+	ClusterStack   string                       `yaml:"clusterStack" json:"clusterStack"`
+	ClusterStore   string                       `yaml:"clusterStore,omitempty" json:"clusterStore,omitempty"`
+	ServiceAccount string                       `yaml:"serviceAccount,omitempty" json:"serviceAccount,omitempty"`
+	Order          []v1alpha2.BuilderOrderEntry `yaml:"order" json:"order"`
+}
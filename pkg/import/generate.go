@@ -0,0 +1,231 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package _import
+
+import (
+	"context"
+	"reflect"
+	"sort"
+
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/pivotal/kpack/pkg/client/clientset/versioned"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/import/descriptor"
+)
+
+const (
+	descriptorKind     = "DependencyDescriptor"
+	defaultStackName   = "default"
+	defaultBuilderName = "default"
+)
+
+// Generator creates a dependency descriptor from the resources in a cluster,
+// the reverse of relocating and importing a descriptor.
+type Generator struct {
+	client versioned.Interface
+}
+
+func NewGenerator(client versioned.Interface) *Generator {
+	return &Generator{client: client}
+}
+
+// Generate returns a v1 descriptor of the ClusterLifecycles, ClusterBuildpacks,
+// ClusterStores, ClusterStacks and ClusterBuilders in the cluster. The default
+// resources are written as the default of the resource they are a copy of.
+// With useSourceImages, the images recorded by a previous import are used
+// instead of the relocated images for resources that have them.
+func (g *Generator) Generate(ctx context.Context, useSourceImages bool) (DependencyDescriptor, error) {
+	d := DependencyDescriptor{
+		APIVersion: descriptor.APIVersionV1,
+		Kind:       descriptorKind,
+	}
+
+	if err := g.addLifecycles(ctx, &d, useSourceImages); err != nil {
+		return DependencyDescriptor{}, err
+	}
+	if err := g.addBuildpacks(ctx, &d, useSourceImages); err != nil {
+		return DependencyDescriptor{}, err
+	}
+	if err := g.addStores(ctx, &d, useSourceImages); err != nil {
+		return DependencyDescriptor{}, err
+	}
+	if err := g.addStacks(ctx, &d, useSourceImages); err != nil {
+		return DependencyDescriptor{}, err
+	}
+	if err := g.addBuilders(ctx, &d); err != nil {
+		return DependencyDescriptor{}, err
+	}
+
+	return d, nil
+}
+
+func (g *Generator) addLifecycles(ctx context.Context, d *DependencyDescriptor, useSourceImages bool) error {
+	list, err := g.client.KpackV1alpha2().ClusterLifecycles().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	sort.Slice(list.Items, func(i, j int) bool { return list.Items[i].Name < list.Items[j].Name })
+
+	defaultIndex := findDefault(len(list.Items), v1alpha2.DefaultLifecycleName, func(i int) string { return list.Items[i].Name }, func(i, j int) bool {
+		return list.Items[i].Spec.Image == list.Items[j].Spec.Image
+	})
+
+	for i, lifecycle := range list.Items {
+		if lifecycle.Name == v1alpha2.DefaultLifecycleName && defaultIndex >= 0 {
+			d.DefaultClusterLifecycle = list.Items[defaultIndex].Name
+			continue
+		}
+
+		entry := ClusterLifecycle{Name: lifecycle.Name, Image: lifecycle.Spec.Image}
+		var recorded ClusterLifecycle
+		if ok, err := recordedSource(&list.Items[i], useSourceImages, &recorded); err != nil {
+			return err
+		} else if ok {
+			entry.Image = recorded.Image
+		}
+		d.ClusterLifecycles = append(d.ClusterLifecycles, entry)
+	}
+	return nil
+}
+
+func (g *Generator) addBuildpacks(ctx context.Context, d *DependencyDescriptor, useSourceImages bool) error {
+	list, err := g.client.KpackV1alpha2().ClusterBuildpacks().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	sort.Slice(list.Items, func(i, j int) bool { return list.Items[i].Name < list.Items[j].Name })
+
+	for i, buildpack := range list.Items {
+		entry := ClusterBuildpack{Name: buildpack.Name, Image: buildpack.Spec.Image}
+		var recorded ClusterBuildpack
+		if ok, err := recordedSource(&list.Items[i], useSourceImages, &recorded); err != nil {
+			return err
+		} else if ok {
+			entry.Image = recorded.Image
+		}
+		d.ClusterBuildpacks = append(d.ClusterBuildpacks, entry)
+	}
+	return nil
+}
+
+func (g *Generator) addStores(ctx context.Context, d *DependencyDescriptor, useSourceImages bool) error {
+	list, err := g.client.KpackV1alpha2().ClusterStores().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	sort.Slice(list.Items, func(i, j int) bool { return list.Items[i].Name < list.Items[j].Name })
+
+	for i, store := range list.Items {
+		entry := ClusterStore{Name: store.Name, Sources: []Source{}}
+		for _, source := range store.Spec.Sources {
+			entry.Sources = append(entry.Sources, Source{Image: source.Image})
+		}
+		var recorded importedStore
+		if ok, err := recordedSource(&list.Items[i], useSourceImages, &recorded); err != nil {
+			return err
+		} else if ok {
+			// sources added after the import have no recorded source and are kept as is
+			for j, source := range entry.Sources {
+				if original, ok := recorded.Relocated[source.Image]; ok {
+					entry.Sources[j] = Source{Image: original}
+				}
+			}
+		}
+		d.ClusterStores = append(d.ClusterStores, entry)
+	}
+	return nil
+}
+
+func (g *Generator) addStacks(ctx context.Context, d *DependencyDescriptor, useSourceImages bool) error {
+	list, err := g.client.KpackV1alpha2().ClusterStacks().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	sort.Slice(list.Items, func(i, j int) bool { return list.Items[i].Name < list.Items[j].Name })
+
+	defaultIndex := findDefault(len(list.Items), defaultStackName, func(i int) string { return list.Items[i].Name }, func(i, j int) bool {
+		return list.Items[i].Spec.BuildImage == list.Items[j].Spec.BuildImage && list.Items[i].Spec.RunImage == list.Items[j].Spec.RunImage
+	})
+
+	for i, stack := range list.Items {
+		if stack.Name == defaultStackName && defaultIndex >= 0 {
+			d.DefaultClusterStack = list.Items[defaultIndex].Name
+			continue
+		}
+
+		entry := ClusterStack{
+			Name:       stack.Name,
+			BuildImage: Source{Image: stack.Spec.BuildImage.Image},
+			RunImage:   Source{Image: stack.Spec.RunImage.Image},
+		}
+		var recorded ClusterStack
+		if ok, err := recordedSource(&list.Items[i], useSourceImages, &recorded); err != nil {
+			return err
+		} else if ok {
+			entry.BuildImage = recorded.BuildImage
+			entry.RunImage = recorded.RunImage
+		}
+		d.ClusterStacks = append(d.ClusterStacks, entry)
+	}
+	return nil
+}
+
+func (g *Generator) addBuilders(ctx context.Context, d *DependencyDescriptor) error {
+	list, err := g.client.KpackV1alpha2().ClusterBuilders().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	sort.Slice(list.Items, func(i, j int) bool { return list.Items[i].Name < list.Items[j].Name })
+
+	defaultIndex := findDefault(len(list.Items), defaultBuilderName, func(i int) string { return list.Items[i].Name }, func(i, j int) bool {
+		a, b := list.Items[i].Spec, list.Items[j].Spec
+		return a.Stack.Name == b.Stack.Name && a.Store.Name == b.Store.Name && reflect.DeepEqual(a.Order, b.Order)
+	})
+
+	for _, builder := range list.Items {
+		if builder.Name == defaultBuilderName && defaultIndex >= 0 {
+			d.DefaultClusterBuilder = list.Items[defaultIndex].Name
+			continue
+		}
+
+		d.ClusterBuilders = append(d.ClusterBuilders, ClusterBuilder{
+			Name:         builder.Name,
+			ClusterStack: builder.Spec.Stack.Name,
+			ClusterStore: builder.Spec.Store.Name,
+			Order:        builder.Spec.Order,
+		})
+	}
+	return nil
+}
+
+// findDefault returns the index of the first resource that the resource named
+// defaultName is a copy of, or -1 when there is none.
+func findDefault(n int, defaultName string, name func(int) string, equal func(int, int) bool) int {
+	defaultIndex := -1
+	for i := 0; i < n; i++ {
+		if name(i) == defaultName {
+			defaultIndex = i
+		}
+	}
+	if defaultIndex < 0 {
+		return -1
+	}
+
+	for i := 0; i < n; i++ {
+		if i != defaultIndex && equal(i, defaultIndex) {
+			return i
+		}
+	}
+	return -1
+}
+
+// recordedSource reads the descriptor entry recorded on obj by a previous
+// import into entry, when useSourceImages is set and there is one.
+func recordedSource(obj metav1.Object, useSourceImages bool, entry interface{}) (bool, error) {
+	if !useSourceImages {
+		return false, nil
+	}
+	return getImportSource(obj, entry)
+}
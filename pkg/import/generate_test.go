@@ -0,0 +1,183 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package _import
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	kpackfakes "github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestGenerator(t *testing.T) {
+	spec.Run(t, "TestGenerator", testGenerator)
+}
+
+func testGenerator(t *testing.T, when spec.G, it spec.S) {
+	lifecycle := func(name, image string) *v1alpha2.ClusterLifecycle {
+		return &v1alpha2.ClusterLifecycle{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: v1alpha2.ClusterLifecycleSpec{
+				ImageSource: corev1alpha1.ImageSource{Image: image},
+			},
+		}
+	}
+	stack := func(name, buildImage, runImage string) *v1alpha2.ClusterStack {
+		return &v1alpha2.ClusterStack{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: v1alpha2.ClusterStackSpec{
+				Id:         "some-stack-id",
+				BuildImage: v1alpha2.ClusterStackSpecImage{Image: buildImage},
+				RunImage:   v1alpha2.ClusterStackSpecImage{Image: runImage},
+			},
+		}
+	}
+	order := []v1alpha2.BuilderOrderEntry{
+		{Group: []v1alpha2.BuilderBuildpackRef{{ObjectReference: corev1.ObjectReference{Name: "some-buildpack", Kind: v1alpha2.ClusterBuildpackKind}}}},
+	}
+	builder := func(name string) *v1alpha2.ClusterBuilder {
+		return &v1alpha2.ClusterBuilder{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: v1alpha2.ClusterBuilderSpec{
+				BuilderSpec: v1alpha2.BuilderSpec{
+					Tag:   "some-registry.io/" + name,
+					Stack: corev1.ObjectReference{Name: "some-stack", Kind: v1alpha2.ClusterStackKind},
+					Store: corev1.ObjectReference{Name: "some-store", Kind: v1alpha2.ClusterStoreKind},
+					Order: order,
+				},
+			},
+		}
+	}
+	buildpack := &v1alpha2.ClusterBuildpack{
+		ObjectMeta: metav1.ObjectMeta{Name: "some-buildpack"},
+		Spec: v1alpha2.ClusterBuildpackSpec{
+			ImageSource: corev1alpha1.ImageSource{Image: "relocated.io/buildpack:123"},
+		},
+	}
+	store := &v1alpha2.ClusterStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "some-store"},
+		Spec: v1alpha2.ClusterStoreSpec{
+			Sources: []corev1alpha1.ImageSource{{Image: "relocated.io/store:123"}},
+		},
+	}
+
+	generate := func(useSourceImages bool, objects ...runtime.Object) DependencyDescriptor {
+		d, err := NewGenerator(kpackfakes.NewSimpleClientset(objects...)).Generate(context.Background(), useSourceImages)
+		require.NoError(t, err)
+		return d
+	}
+
+	it("writes the default resources as defaults of the resources they are a copy of", func() {
+		d := generate(false,
+			lifecycle("some-lifecycle", "relocated.io/lifecycle:123"),
+			lifecycle(v1alpha2.DefaultLifecycleName, "relocated.io/lifecycle:123"),
+			buildpack,
+			store,
+			stack("some-stack", "relocated.io/build:123", "relocated.io/run:123"),
+			stack("default", "relocated.io/build:123", "relocated.io/run:123"),
+			builder("some-builder"),
+			builder("default"),
+		)
+
+		require.Equal(t, DependencyDescriptor{
+			APIVersion:              "kp.kpack.io/v1",
+			Kind:                    "DependencyDescriptor",
+			DefaultClusterLifecycle: "some-lifecycle",
+			DefaultClusterStack:     "some-stack",
+			DefaultClusterBuilder:   "some-builder",
+			ClusterLifecycles:       []ClusterLifecycle{{Name: "some-lifecycle", Image: "relocated.io/lifecycle:123"}},
+			ClusterBuildpacks:       []ClusterBuildpack{{Name: "some-buildpack", Image: "relocated.io/buildpack:123"}},
+			ClusterStores: []ClusterStore{
+				{Name: "some-store", Sources: []Source{{Image: "relocated.io/store:123"}}},
+			},
+			ClusterStacks: []ClusterStack{{
+				Name:       "some-stack",
+				BuildImage: Source{Image: "relocated.io/build:123"},
+				RunImage:   Source{Image: "relocated.io/run:123"},
+			}},
+			ClusterBuilders: []ClusterBuilder{
+				{Name: "some-builder", ClusterStack: "some-stack", ClusterStore: "some-store", Order: order},
+			},
+		}, d)
+
+		data, err := yaml.Marshal(d)
+		require.NoError(t, err)
+		read, err := ReadDescriptor(string(data))
+		require.NoError(t, err)
+		require.Equal(t, d.ClusterStacks, read.ClusterStacks)
+		require.Equal(t, d.DefaultClusterBuilder, read.DefaultClusterBuilder)
+	})
+
+	it("keeps default resources that are not a copy of another resource", func() {
+		d := generate(false,
+			lifecycle(v1alpha2.DefaultLifecycleName, "relocated.io/lifecycle:123"),
+			stack("some-stack", "relocated.io/build:123", "relocated.io/run:123"),
+			stack("default", "relocated.io/build:456", "relocated.io/run:456"),
+		)
+
+		require.Empty(t, d.DefaultClusterLifecycle)
+		require.Empty(t, d.DefaultClusterStack)
+		require.Equal(t, []ClusterLifecycle{{Name: v1alpha2.DefaultLifecycleName, Image: "relocated.io/lifecycle:123"}}, d.ClusterLifecycles)
+		require.Len(t, d.ClusterStacks, 2)
+		require.Equal(t, "default", d.ClusterStacks[0].Name)
+	})
+
+	when("using source images", func() {
+		it("uses the images recorded by a previous import", func() {
+			importedBuildpack := buildpack.DeepCopy()
+			require.NoError(t, setImportSource(importedBuildpack, ClusterBuildpack{Name: "some-buildpack", Image: "source.io/buildpack:1"}))
+			importedStack := stack("some-stack", "relocated.io/build:123", "relocated.io/run:123")
+			require.NoError(t, setImportSource(importedStack, ClusterStack{
+				Name:       "some-stack",
+				BuildImage: Source{Image: "source.io/build:1"},
+				RunImage:   Source{Image: "source.io/run:1"},
+			}))
+
+			d := generate(true, importedBuildpack, importedStack, store)
+
+			require.Equal(t, []ClusterBuildpack{{Name: "some-buildpack", Image: "source.io/buildpack:1"}}, d.ClusterBuildpacks)
+			require.Equal(t, Source{Image: "source.io/build:1"}, d.ClusterStacks[0].BuildImage)
+			require.Equal(t, Source{Image: "source.io/run:1"}, d.ClusterStacks[0].RunImage)
+			require.Equal(t, []Source{{Image: "relocated.io/store:123"}}, d.ClusterStores[0].Sources)
+		})
+
+		it("keeps store sources added after the import", func() {
+			storeWithAddedSource := store.DeepCopy()
+			storeWithAddedSource.Spec.Sources = append(storeWithAddedSource.Spec.Sources, corev1alpha1.ImageSource{Image: "added.io/store:456"})
+			require.NoError(t, setImportSource(storeWithAddedSource, importedStore{
+				ClusterStore: ClusterStore{Name: "some-store", Sources: []Source{{Image: "source.io/store:1"}}},
+				Relocated:    map[string]string{"relocated.io/store:123": "source.io/store:1"},
+			}))
+
+			d := generate(true, storeWithAddedSource)
+
+			require.Equal(t, []Source{{Image: "source.io/store:1"}, {Image: "added.io/store:456"}}, d.ClusterStores[0].Sources)
+		})
+
+		it("ignores the recorded images without the option", func() {
+			importedBuildpack := buildpack.DeepCopy()
+			require.NoError(t, setImportSource(importedBuildpack, ClusterBuildpack{Name: "some-buildpack", Image: "source.io/buildpack:1"}))
+
+			d := generate(false, importedBuildpack)
+
+			require.Equal(t, []ClusterBuildpack{{Name: "some-buildpack", Image: "relocated.io/buildpack:123"}}, d.ClusterBuildpacks)
+		})
+
+		it("fails with an invalid recorded source", func() {
+			importedBuildpack := buildpack.DeepCopy()
+			importedBuildpack.Annotations = map[string]string{importSourceAnnotation: "not-json"}
+
+			_, err := NewGenerator(kpackfakes.NewSimpleClientset(importedBuildpack)).Generate(context.Background(), true)
+			require.EqualError(t, err, "invalid kpack.io/import-source annotation on 'some-buildpack': invalid character 'o' in literal null (expecting 'u')")
+		})
+	})
+}
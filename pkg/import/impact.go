@@ -0,0 +1,121 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package _import
+
+import (
+	"context"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/pivotal/kpack/pkg/client/clientset/versioned"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/config"
+	"github.com/buildpacks-community/kpack-cli/pkg/impact"
+	"github.com/buildpacks-community/kpack-cli/pkg/registry"
+)
+
+// ImpactChanges returns the changes the descriptor makes to the existing
+// ClusterStacks, ClusterStores, ClusterBuildpacks and Buildpacks that builders
+// depend on. Resources left out by the signature verification policy do not
+// change.
+func ImpactChanges(ctx context.Context, keychain authn.Keychain, desc DependencyDescriptor, kpConfig config.KpConfig, relocatedImageProvider RelocatedImageProvider, client versioned.Interface) ([]impact.Change, error) {
+	var changes []impact.Change
+
+	for _, stack := range GetClusterStacks(desc) {
+		existing, err := client.KpackV1alpha2().ClusterStacks().Get(ctx, stack.Name, metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		runImage, err := relocatedImageProvider.RelocatedImage(keychain, kpConfig, stack.RunImage.Image)
+		if registry.IsVerificationSkipped(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		updated := existing.DeepCopy()
+		updated.Spec.RunImage.Image = runImage
+		changes = append(changes, impact.StackChange(existing, updated)...)
+	}
+
+stores:
+	for _, store := range GetClusterStores(desc) {
+		existing, err := client.KpackV1alpha2().ClusterStores().Get(ctx, store.Name, metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		for _, source := range store.Sources {
+			image, err := relocatedImageProvider.RelocatedImage(keychain, kpConfig, source.Image)
+			if registry.IsVerificationSkipped(err) {
+				continue stores
+			} else if err != nil {
+				return nil, err
+			}
+
+			if !storeHasImage(existing, image) {
+				changes = append(changes, impact.Change{Kind: v1alpha2.ClusterStoreKind, Name: store.Name, Action: impact.Rebuild})
+				break
+			}
+		}
+	}
+
+	for _, buildpack := range GetClusterBuildpacks(desc) {
+		existing, err := client.KpackV1alpha2().ClusterBuildpacks().Get(ctx, buildpack.Name, metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		image, err := relocatedImageProvider.RelocatedImage(keychain, kpConfig, buildpack.Image)
+		if registry.IsVerificationSkipped(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		if image != existing.Spec.Image {
+			changes = append(changes, impact.BuildpackChange(v1alpha2.ClusterBuildpackKind, "", buildpack.Name, existing.Status.Buildpacks))
+		}
+	}
+
+	for _, buildpack := range GetBuildpacks(desc) {
+		existing, err := client.KpackV1alpha2().Buildpacks(buildpack.Namespace).Get(ctx, buildpack.Name, metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		image, err := relocatedImageProvider.RelocatedImage(keychain, kpConfig, buildpack.Image)
+		if registry.IsVerificationSkipped(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		if image != existing.Spec.Image {
+			changes = append(changes, impact.BuildpackChange(v1alpha2.BuildpackKind, buildpack.Namespace, buildpack.Name, existing.Status.Buildpacks))
+		}
+	}
+
+	return changes, nil
+}
+
+func storeHasImage(store *v1alpha2.ClusterStore, image string) bool {
+	for _, source := range store.Spec.Sources {
+		if source.Image == image {
+			return true
+		}
+	}
+	return false
+}
@@ -142,3 +142,46 @@ func (id *ImportDiffer) DiffClusterBuilder(oldCB *v1alpha2.ClusterBuilder, newCB
 
 	return id.Differ.Diff(oldDiffableCB, newCB)
 }
+
+func (id *ImportDiffer) DiffBuildpack(keychain authn.Keychain, kpConfig config.KpConfig, oldBP *v1alpha2.Buildpack, newBP Buildpack) (diff string, err error) {
+	newBP.Image, err = id.RelocatedImageProvider.RelocatedImage(keychain, kpConfig, newBP.Image)
+	if err != nil {
+		return "", err
+	}
+	newBP.ServiceAccount = serviceAccountOrDefault(newBP.ServiceAccount)
+
+	var oldDiffableBuildpack interface{}
+	if oldBP != nil {
+		oldDiffableBuildpack = Buildpack{
+			Name:           oldBP.Name,
+			Namespace:      oldBP.Namespace,
+			Image:          oldBP.Spec.ImageSource.Image,
+			ServiceAccount: oldBP.Spec.ServiceAccountName,
+		}
+	}
+
+	return id.Differ.Diff(oldDiffableBuildpack, newBP)
+}
+
+func (id *ImportDiffer) DiffBuilder(kpConfig config.KpConfig, oldB *v1alpha2.Builder, newB Builder) (diff string, err error) {
+	newB.Tag, err = builderTag(kpConfig, newB)
+	if err != nil {
+		return "", err
+	}
+	newB.ServiceAccount = serviceAccountOrDefault(newB.ServiceAccount)
+
+	var oldDiffableBuilder interface{}
+	if oldB != nil {
+		oldDiffableBuilder = Builder{
+			Name:           oldB.Name,
+			Namespace:      oldB.Namespace,
+			Tag:            oldB.Spec.Tag,
+			ClusterStack:   oldB.Spec.Stack.Name,
+			ClusterStore:   oldB.Spec.Store.Name,
+			ServiceAccount: oldB.Spec.ServiceAccountName,
+			Order:          oldB.Spec.Order,
+		}
+	}
+
+	return id.Differ.Diff(oldDiffableBuilder, newB)
+}
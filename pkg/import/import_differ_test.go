@@ -231,4 +231,61 @@ func testImportDiffer(t *testing.T, when spec.G, it spec.S) {
 			require.Equal(t, nil, diffArg0)
 		})
 	})
+
+	when("DiffBuildpack", func() {
+		it("defaults the service account", func() {
+			newBuildpack := importpkg.Buildpack{
+				Name:      "some-bp",
+				Namespace: "some-namespace",
+				Image:     "some-image",
+			}
+
+			diff, err := importDiffer.DiffBuildpack(fakeKeychain, kpConfig, nil, newBuildpack)
+			require.NoError(t, err)
+			require.Equal(t, "some-diff", diff)
+			diffArg0, diffArg1 := fakeDiffer.Args()
+			require.Equal(t, nil, diffArg0)
+			newBuildpack.ServiceAccount = "default"
+			require.Equal(t, newBuildpack, diffArg1)
+		})
+	})
+
+	when("DiffBuilder", func() {
+		oldBuilder := &v1alpha2.Builder{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "some-builder",
+				Namespace: "some-namespace",
+			},
+			Spec: v1alpha2.NamespacedBuilderSpec{
+				BuilderSpec: v1alpha2.BuilderSpec{
+					Tag:   "some-tag",
+					Stack: corev1.ObjectReference{Name: "some-stack"},
+				},
+				ServiceAccountName: "default",
+			},
+		}
+
+		it("defaults the builder tag to the default repository", func() {
+			newBuilder := importpkg.Builder{
+				Name:         "some-builder",
+				Namespace:    "some-namespace",
+				ClusterStack: "some-stack",
+			}
+
+			diff, err := importDiffer.DiffBuilder(kpConfig, oldBuilder, newBuilder)
+			require.NoError(t, err)
+			require.Equal(t, "some-diff", diff)
+			diffArg0, diffArg1 := fakeDiffer.Args()
+			require.Equal(t, importpkg.Builder{
+				Name:           "some-builder",
+				Namespace:      "some-namespace",
+				Tag:            "some-tag",
+				ClusterStack:   "some-stack",
+				ServiceAccount: "default",
+			}, diffArg0)
+			newBuilder.Tag = "my-cool-repo:builder-some-namespace-some-builder"
+			newBuilder.ServiceAccount = "default"
+			require.Equal(t, newBuilder, diffArg1)
+		})
+	})
 }
@@ -0,0 +1,82 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package _import
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/k8s"
+)
+
+// importSourceAnnotation records the descriptor entry a resource was imported
+// from, so that its source images are known after they have been relocated
+const importSourceAnnotation = "kpack.io/import-source"
+
+func setImportSource(obj k8s.Annotatable, entry interface{}) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	obj.SetAnnotations(k8s.MergeAnnotations(obj.GetAnnotations(), map[string]string{importSourceAnnotation: string(data)}))
+	return nil
+}
+
+func getImportSource(obj metav1.Object, entry interface{}) (bool, error) {
+	data, ok := obj.GetAnnotations()[importSourceAnnotation]
+	if !ok {
+		return false, nil
+	}
+
+	if err := json.Unmarshal([]byte(data), entry); err != nil {
+		return false, errors.Wrapf(err, "invalid %s annotation on '%s'", importSourceAnnotation, obj.GetName())
+	}
+	return true, nil
+}
+
+// importedStore is the recorded entry of an imported store. Relocated maps
+// each relocated store image to the source it was imported from, so that
+// sources added to the store after the import can be told apart.
+type importedStore struct {
+	ClusterStore
+	Relocated map[string]string `json:"relocated,omitempty"`
+}
+
+// mergeStoreSources returns the sources recorded on an existing store with the
+// sources of store added, as stores are only ever added to by an import.
+func mergeStoreSources(existing metav1.Object, store ClusterStore, relocated map[string]string) (importedStore, error) {
+	var recorded importedStore
+	if _, err := getImportSource(existing, &recorded); err != nil {
+		return importedStore{}, err
+	}
+
+	merged := importedStore{
+		ClusterStore: ClusterStore{Name: store.Name, Sources: recorded.Sources},
+		Relocated:    map[string]string{},
+	}
+	for _, source := range store.Sources {
+		if !containsSource(merged.Sources, source) {
+			merged.Sources = append(merged.Sources, source)
+		}
+	}
+	for image, source := range recorded.Relocated {
+		merged.Relocated[image] = source
+	}
+	for image, source := range relocated {
+		merged.Relocated[image] = source
+	}
+	return merged, nil
+}
+
+func containsSource(sources []Source, source Source) bool {
+	for _, s := range sources {
+		if s.Image == source.Image {
+			return true
+		}
+	}
+	return false
+}
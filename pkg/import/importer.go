@@ -3,6 +3,7 @@ package _import
 import (
 	"context"
 	"fmt"
+	"io"
 
 	"github.com/ghodss/yaml"
 	"github.com/google/go-containerregistry/pkg/authn"
@@ -28,6 +29,9 @@ import (
 	"github.com/buildpacks-community/kpack-cli/pkg/registry"
 )
 
+// importTimestampAnnotation marks resources created or updated by kp import
+const importTimestampAnnotation = "kpack.io/import-timestamp"
+
 type TimestampProvider interface {
 	GetTimestamp() string
 }
@@ -35,6 +39,7 @@ type TimestampProvider interface {
 type Printer interface {
 	Printlnf(format string, args ...interface{}) error
 	PrintStatus(format string, args ...interface{}) error
+	Writer() io.Writer
 }
 
 type Importer struct {
@@ -49,6 +54,7 @@ type Importer struct {
 	clusterStoreFactory     *clusterstore.Factory
 	clusterStackFactory     *clusterstack.Factory
 	timestampProvider       TimestampProvider
+	parallelism             int
 }
 
 type relocatedDescriptor struct {
@@ -57,9 +63,11 @@ type relocatedDescriptor struct {
 	clusterStores     []*v1alpha2.ClusterStore
 	clusterStacks     []*v1alpha2.ClusterStack
 	clusterBuilders   []*v1alpha2.ClusterBuilder
+	buildpacks        []*v1alpha2.Buildpack
+	builders          []*v1alpha2.Builder
 }
 
-func NewImporter(printer Printer, k8sClient kubernetes.Interface, client versioned.Interface, fetcher registry.Fetcher, relocator registry.Relocator, waiter commands.ResourceWaiter, timestampProvider TimestampProvider) *Importer {
+func NewImporter(printer Printer, k8sClient kubernetes.Interface, client versioned.Interface, fetcher registry.Fetcher, relocator registry.Relocator, waiter commands.ResourceWaiter, timestampProvider TimestampProvider, parallelism int) *Importer {
 	return &Importer{
 		imageRelocator:          relocator,
 		client:                  client,
@@ -68,6 +76,7 @@ func NewImporter(printer Printer, k8sClient kubernetes.Interface, client version
 		waiter:                  waiter,
 		imageFetcher:            fetcher,
 		timestampProvider:       timestampProvider,
+		parallelism:             parallelism,
 		clusterLifecycleFactory: clusterlifecycle.NewFactory(printer, relocator, fetcher),
 		clusterBuildpackFactory: clusterbuildpack.NewFactory(printer, relocator, fetcher),
 		clusterStackFactory:     clusterstack.NewFactory(printer, relocator, fetcher),
@@ -76,6 +85,10 @@ func NewImporter(printer Printer, k8sClient kubernetes.Interface, client version
 }
 
 func (i *Importer) ReadDescriptor(rawDescriptor string) (DependencyDescriptor, error) {
+	return ReadDescriptor(rawDescriptor)
+}
+
+func ReadDescriptor(rawDescriptor string) (DependencyDescriptor, error) {
 	var api API
 	if err := yaml.Unmarshal([]byte(rawDescriptor), &api); err != nil {
 		return DependencyDescriptor{}, err
@@ -95,12 +108,22 @@ func (i *Importer) ReadDescriptor(rawDescriptor string) (DependencyDescriptor, e
 			return DependencyDescriptor{}, err
 		}
 		desc = d3.ToV1()
+	case descriptor.APIVersionV1:
+		if err := yaml.Unmarshal([]byte(rawDescriptor), &desc); err != nil {
+			return DependencyDescriptor{}, err
+		}
+		if len(desc.Buildpacks) > 0 || len(desc.Builders) > 0 {
+			return DependencyDescriptor{}, errors.Errorf("buildpacks and builders require apiVersion %s", descriptor.APIVersionV2)
+		}
+		if desc.Verification != nil {
+			return DependencyDescriptor{}, errors.Errorf("verification requires apiVersion %s", descriptor.APIVersionV2)
+		}
 	case CurrentAPIVersion:
 		if err := yaml.Unmarshal([]byte(rawDescriptor), &desc); err != nil {
 			return DependencyDescriptor{}, err
 		}
 	default:
-		return DependencyDescriptor{}, errors.Errorf("did not find expected apiVersion, must be one of: %s", []string{descriptor.APIVersionV1Alpha1, descriptor.APIVersionV1Alpha3, CurrentAPIVersion})
+		return DependencyDescriptor{}, errors.Errorf("did not find expected apiVersion, must be one of: %s", []string{descriptor.APIVersionV1Alpha1, descriptor.APIVersionV1Alpha3, descriptor.APIVersionV1, CurrentAPIVersion})
 	}
 
 	if err := ValidateDescriptor(desc); err != nil {
@@ -135,6 +158,12 @@ func (i *Importer) ImportDescriptor(ctx context.Context, keychain authn.Keychain
 		}
 	}
 
+	for _, buildpack := range rDescriptor.buildpacks {
+		if err := i.saveBuildpack(ctx, buildpack); err != nil {
+			return nil, err
+		}
+	}
+
 	storeToGeneration := map[string]int64{}
 	for _, store := range rDescriptor.clusterStores {
 		gen, err := i.saveClusterStore(ctx, store)
@@ -161,6 +190,12 @@ func (i *Importer) ImportDescriptor(ctx context.Context, keychain authn.Keychain
 		}
 	}
 
+	for _, builder := range rDescriptor.builders {
+		if err := i.saveBuilder(ctx, storeToGeneration, stackToGeneration, builder); err != nil {
+			return nil, err
+		}
+	}
+
 	return objects, nil
 }
 
@@ -183,72 +218,119 @@ func (i *Importer) relocateDescriptor(ctx context.Context, keychain authn.Keycha
 		objs []runtime.Object
 	)
 
-	clusterLifecycles := make([]*v1alpha2.ClusterLifecycle, 0)
+	var jobs []relocationJob
 	for _, lifecycle := range GetClusterLifecycles(descriptor) {
-		rLifecycle, err := i.constructClusterLifecycle(keychain, kpConfig, lifecycle)
-		if err != nil {
-			return relocatedDescriptor{}, nil, err
-		}
-		rLifecycle.Annotations = k8s.MergeAnnotations(rLifecycle.Annotations, map[string]string{"kpack.io/import-timestamp": ts})
-
-		clusterLifecycles = append(clusterLifecycles, rLifecycle)
-		objs = append(objs, rLifecycle)
+		lifecycle := lifecycle
+		jobs = append(jobs, func(importer *Importer) (runtime.Object, error) {
+			return importer.constructClusterLifecycle(keychain, kpConfig, lifecycle)
+		})
 	}
 
-	clusterBuildpacks := make([]*v1alpha2.ClusterBuildpack, 0)
 	for _, buildpack := range GetClusterBuildpacks(descriptor) {
-		rBuildpack, err := i.constructClusterBuildpack(keychain, kpConfig, buildpack)
-		if err != nil {
-			return relocatedDescriptor{}, nil, err
-		}
-		rBuildpack.Annotations = k8s.MergeAnnotations(rBuildpack.Annotations, map[string]string{"kpack.io/import-timestamp": ts})
+		buildpack := buildpack
+		jobs = append(jobs, func(importer *Importer) (runtime.Object, error) {
+			return importer.constructClusterBuildpack(keychain, kpConfig, buildpack)
+		})
+	}
 
-		clusterBuildpacks = append(clusterBuildpacks, rBuildpack)
-		objs = append(objs, rBuildpack)
+	for _, buildpack := range GetBuildpacks(descriptor) {
+		buildpack := buildpack
+		jobs = append(jobs, func(importer *Importer) (runtime.Object, error) {
+			return importer.constructBuildpack(keychain, kpConfig, buildpack)
+		})
 	}
 
-	clusterstores := make([]*v1alpha2.ClusterStore, 0)
 	for _, clusterStore := range descriptor.ClusterStores {
-		rStore, err := i.constructClusterStore(ctx, keychain, kpConfig, clusterStore)
-		if err != nil {
-			return relocatedDescriptor{}, nil, err
-		}
-		rStore.Annotations = k8s.MergeAnnotations(rStore.Annotations, map[string]string{"kpack.io/import-timestamp": ts})
+		clusterStore := clusterStore
+		jobs = append(jobs, func(importer *Importer) (runtime.Object, error) {
+			return importer.constructClusterStore(ctx, keychain, kpConfig, clusterStore)
+		})
+	}
+
+	for _, clusterStack := range GetClusterStacks(descriptor) {
+		clusterStack := clusterStack
+		jobs = append(jobs, func(importer *Importer) (runtime.Object, error) {
+			return importer.constructClusterStack(keychain, kpConfig, clusterStack)
+		})
+	}
 
-		clusterstores = append(clusterstores, rStore)
-		objs = append(objs, rStore)
+	relocated, err := i.runRelocationJobs(ctx, jobs)
+	if err != nil {
+		return relocatedDescriptor{}, nil, err
 	}
 
+	clusterLifecycles := make([]*v1alpha2.ClusterLifecycle, 0)
+	clusterBuildpacks := make([]*v1alpha2.ClusterBuildpack, 0)
+	buildpacks := make([]*v1alpha2.Buildpack, 0)
+	clusterstores := make([]*v1alpha2.ClusterStore, 0)
 	clusterstacks := make([]*v1alpha2.ClusterStack, 0)
-	for _, clusterStack := range GetClusterStacks(descriptor) {
-		rStack, err := i.constructClusterStack(keychain, kpConfig, clusterStack)
-		if err != nil {
-			return relocatedDescriptor{}, nil, err
+	for _, obj := range relocated {
+		switch r := obj.(type) {
+		case *v1alpha2.ClusterLifecycle:
+			r.Annotations = k8s.MergeAnnotations(r.Annotations, map[string]string{importTimestampAnnotation: ts})
+			clusterLifecycles = append(clusterLifecycles, r)
+		case *v1alpha2.ClusterBuildpack:
+			r.Annotations = k8s.MergeAnnotations(r.Annotations, map[string]string{importTimestampAnnotation: ts})
+			clusterBuildpacks = append(clusterBuildpacks, r)
+		case *v1alpha2.Buildpack:
+			r.Annotations = k8s.MergeAnnotations(r.Annotations, map[string]string{importTimestampAnnotation: ts})
+			buildpacks = append(buildpacks, r)
+		case *v1alpha2.ClusterStore:
+			r.Annotations = k8s.MergeAnnotations(r.Annotations, map[string]string{importTimestampAnnotation: ts})
+			clusterstores = append(clusterstores, r)
+		case *v1alpha2.ClusterStack:
+			r.Annotations = k8s.MergeAnnotations(r.Annotations, map[string]string{importTimestampAnnotation: ts})
+			clusterstacks = append(clusterstacks, r)
 		}
-		rStack.Annotations = k8s.MergeAnnotations(rStack.Annotations, map[string]string{"kpack.io/import-timestamp": ts})
-
-		clusterstacks = append(clusterstacks, rStack)
-		objs = append(objs, rStack)
+		objs = append(objs, obj)
 	}
 
+	skipped := skippedResources(descriptor, relocated)
+
 	clusterBuilders := make([]*v1alpha2.ClusterBuilder, 0)
 	for _, clusterBuilder := range GetClusterBuilders(descriptor) {
 		rBuilder, err := i.constructClusterBuilder(kpConfig, clusterBuilder)
 		if err != nil {
 			return relocatedDescriptor{}, nil, err
 		}
-		rBuilder.Annotations = k8s.MergeAnnotations(rBuilder.Annotations, map[string]string{"kpack.io/import-timestamp": ts})
+		if dependency, ok := skippedDependency(rBuilder.Spec.BuilderSpec, "", skipped); ok {
+			if err := i.printer.Printlnf("Skipping ClusterBuilder '%s': depends on skipped %s '%s'", rBuilder.Name, dependency.Kind, dependency.FullName()); err != nil {
+				return relocatedDescriptor{}, nil, err
+			}
+			continue
+		}
+		rBuilder.Annotations = k8s.MergeAnnotations(rBuilder.Annotations, map[string]string{importTimestampAnnotation: ts})
 
 		clusterBuilders = append(clusterBuilders, rBuilder)
 		objs = append(objs, rBuilder)
 	}
 
+	builders := make([]*v1alpha2.Builder, 0)
+	for _, builder := range GetBuilders(descriptor) {
+		rBuilder, err := i.constructBuilder(kpConfig, builder)
+		if err != nil {
+			return relocatedDescriptor{}, nil, err
+		}
+		if dependency, ok := skippedDependency(rBuilder.Spec.BuilderSpec, rBuilder.Namespace, skipped); ok {
+			if err := i.printer.Printlnf("Skipping Builder '%s' in namespace '%s': depends on skipped %s '%s'", rBuilder.Name, rBuilder.Namespace, dependency.Kind, dependency.FullName()); err != nil {
+				return relocatedDescriptor{}, nil, err
+			}
+			continue
+		}
+		rBuilder.Annotations = k8s.MergeAnnotations(rBuilder.Annotations, map[string]string{importTimestampAnnotation: ts})
+
+		builders = append(builders, rBuilder)
+		objs = append(objs, rBuilder)
+	}
+
 	return relocatedDescriptor{
 		clusterLifecycles: clusterLifecycles,
 		clusterBuildpacks: clusterBuildpacks,
 		clusterStores:     clusterstores,
 		clusterStacks:     clusterstacks,
 		clusterBuilders:   clusterBuilders,
+		buildpacks:        buildpacks,
+		builders:          builders,
 	}, objs, nil
 }
 
@@ -265,15 +347,43 @@ func (i *Importer) constructClusterStore(ctx context.Context, keychain authn.Key
 		existingStore = nil
 	}
 
+	relocated := map[string]string{}
+	factory := *i.clusterStoreFactory
+	factory.Uploader = recordingUploader{BuildpackageUploader: factory.Uploader, uploaded: relocated}
+
 	if existingStore != nil {
-		return i.clusterStoreFactory.AddToStore(keychain, existingStore, kpConfig, buildpackagesForSource(store.Sources)...)
+		updatedStore, err := factory.AddToStore(keychain, existingStore, kpConfig, buildpackagesForSource(store.Sources)...)
+		if err != nil {
+			return nil, err
+		}
+
+		merged, err := mergeStoreSources(existingStore, store, relocated)
+		if err != nil {
+			return nil, err
+		}
+		return updatedStore, setImportSource(updatedStore, merged)
 	}
 
-	newStore, err := i.clusterStoreFactory.MakeStore(keychain, store.Name, kpConfig, buildpackagesForSource(store.Sources)...)
+	newStore, err := factory.MakeStore(keychain, store.Name, kpConfig, buildpackagesForSource(store.Sources)...)
 	if err != nil {
 		return nil, err
 	}
-	return newStore, nil
+	return newStore, setImportSource(newStore, importedStore{ClusterStore: store, Relocated: relocated})
+}
+
+// recordingUploader records the buildpackage each uploaded image was
+// uploaded from.
+type recordingUploader struct {
+	clusterstore.BuildpackageUploader
+	uploaded map[string]string
+}
+
+func (u recordingUploader) UploadBuildpackage(keychain authn.Keychain, buildPackage, repository string) (string, error) {
+	image, err := u.BuildpackageUploader.UploadBuildpackage(keychain, buildPackage, repository)
+	if err == nil {
+		u.uploaded[image] = buildPackage
+	}
+	return image, err
 }
 
 func (i *Importer) constructClusterStack(keychain authn.Keychain, kpConfig config.KpConfig, stack ClusterStack) (*v1alpha2.ClusterStack, error) {
@@ -286,7 +396,7 @@ func (i *Importer) constructClusterStack(keychain authn.Keychain, kpConfig confi
 		return nil, err
 	}
 
-	return newStack, nil
+	return newStack, setImportSource(newStack, stack)
 }
 
 func (i *Importer) constructClusterLifecycle(keychain authn.Keychain, kpConfig config.KpConfig, lifecycle ClusterLifecycle) (*v1alpha2.ClusterLifecycle, error) {
@@ -294,7 +404,12 @@ func (i *Importer) constructClusterLifecycle(keychain authn.Keychain, kpConfig c
 		return nil, err
 	}
 
-	return i.clusterLifecycleFactory.MakeLifecycle(keychain, lifecycle.Name, lifecycle.Image, kpConfig)
+	newLifecycle, err := i.clusterLifecycleFactory.MakeLifecycle(keychain, lifecycle.Name, lifecycle.Image, kpConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return newLifecycle, setImportSource(newLifecycle, lifecycle)
 }
 
 func (i *Importer) constructClusterBuildpack(keychain authn.Keychain, kpConfig config.KpConfig, buildpack ClusterBuildpack) (*v1alpha2.ClusterBuildpack, error) {
@@ -302,7 +417,84 @@ func (i *Importer) constructClusterBuildpack(keychain authn.Keychain, kpConfig c
 		return nil, err
 	}
 
-	return i.clusterBuildpackFactory.MakeBuildpack(keychain, buildpack.Name, buildpack.Image, kpConfig)
+	newBuildpack, err := i.clusterBuildpackFactory.MakeBuildpack(keychain, buildpack.Name, buildpack.Image, kpConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return newBuildpack, setImportSource(newBuildpack, buildpack)
+}
+
+func (i *Importer) constructBuildpack(keychain authn.Keychain, kpConfig config.KpConfig, buildpack Buildpack) (*v1alpha2.Buildpack, error) {
+	if err := i.printer.PrintStatus("Importing Buildpack '%s' in namespace '%s'...", buildpack.Name, buildpack.Namespace); err != nil {
+		return nil, err
+	}
+
+	clusterBuildpack, err := i.clusterBuildpackFactory.MakeBuildpack(keychain, buildpack.Name, buildpack.Image, kpConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	newBuildpack := &v1alpha2.Buildpack{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       v1alpha2.BuildpackKind,
+			APIVersion: "kpack.io/v1alpha2",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        buildpack.Name,
+			Namespace:   buildpack.Namespace,
+			Annotations: map[string]string{},
+		},
+		Spec: v1alpha2.BuildpackSpec{
+			ImageSource:        clusterBuildpack.Spec.ImageSource,
+			ServiceAccountName: serviceAccountOrDefault(buildpack.ServiceAccount),
+		},
+	}
+
+	return newBuildpack, k8s.SetLastAppliedCfg(newBuildpack)
+}
+
+func (i *Importer) constructBuilder(kpConfig config.KpConfig, builder Builder) (*v1alpha2.Builder, error) {
+	if err := i.printer.PrintStatus("Importing Builder '%s' in namespace '%s'...", builder.Name, builder.Namespace); err != nil {
+		return nil, err
+	}
+
+	tag, err := builderTag(kpConfig, builder)
+	if err != nil {
+		return nil, err
+	}
+
+	newBuilder := &v1alpha2.Builder{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       v1alpha2.BuilderKind,
+			APIVersion: "kpack.io/v1alpha2",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        builder.Name,
+			Namespace:   builder.Namespace,
+			Annotations: map[string]string{},
+		},
+		Spec: v1alpha2.NamespacedBuilderSpec{
+			BuilderSpec: v1alpha2.BuilderSpec{
+				Tag: tag,
+				Stack: corev1.ObjectReference{
+					Name: builder.ClusterStack,
+					Kind: v1alpha2.ClusterStackKind,
+				},
+				Order: builder.Order,
+			},
+			ServiceAccountName: serviceAccountOrDefault(builder.ServiceAccount),
+		},
+	}
+
+	if builder.ClusterStore != "" {
+		newBuilder.Spec.Store = corev1.ObjectReference{
+			Name: builder.ClusterStore,
+			Kind: v1alpha2.ClusterStoreKind,
+		}
+	}
+
+	return newBuilder, k8s.SetLastAppliedCfg(newBuilder)
 }
 
 func (i *Importer) constructClusterBuilder(kpConfig config.KpConfig, builder ClusterBuilder) (*v1alpha2.ClusterBuilder, error) {
@@ -512,6 +704,87 @@ func (i *Importer) saveClusterBuilder(ctx context.Context, storeToGeneration, st
 	return i.waiter.Wait(ctx, builder, builderHasResolved(storeToGeneration[relocatedBuilder.Spec.Store.Name], stackToGeneration[relocatedBuilder.Spec.Stack.Name]))
 }
 
+func (i *Importer) saveBuildpack(ctx context.Context, relocatedBuildpack *v1alpha2.Buildpack) error {
+	buildpacks := i.client.KpackV1alpha2().Buildpacks(relocatedBuildpack.Namespace)
+	existingBuildpack, err := buildpacks.Get(ctx, relocatedBuildpack.Name, metav1.GetOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+
+	var buildpack *v1alpha2.Buildpack
+	if k8serrors.IsNotFound(err) {
+		buildpack, err = buildpacks.Create(ctx, relocatedBuildpack, metav1.CreateOptions{})
+		if err != nil {
+			return err
+		}
+	} else {
+		updateBuildpack := existingBuildpack.DeepCopy()
+		updateBuildpack.Spec = relocatedBuildpack.Spec
+		updateBuildpack.Annotations = k8s.MergeAnnotations(updateBuildpack.Annotations, relocatedBuildpack.Annotations)
+		patch, err := k8s.CreatePatch(existingBuildpack, updateBuildpack)
+		if err != nil {
+			return err
+		}
+		buildpack, err = buildpacks.Patch(ctx, updateBuildpack.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			return err
+		}
+	}
+
+	return i.waiter.Wait(ctx, buildpack)
+}
+
+func (i *Importer) saveBuilder(ctx context.Context, storeToGeneration, stackToGeneration map[string]int64, relocatedBuilder *v1alpha2.Builder) error {
+	builders := i.client.KpackV1alpha2().Builders(relocatedBuilder.Namespace)
+	existingBuilder, err := builders.Get(ctx, relocatedBuilder.Name, metav1.GetOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+
+	var builder *v1alpha2.Builder
+	if k8serrors.IsNotFound(err) {
+		builder, err = builders.Create(ctx, relocatedBuilder, metav1.CreateOptions{})
+		if err != nil {
+			return err
+		}
+	} else {
+		updateBuilder := existingBuilder.DeepCopy()
+		updateBuilder.Spec = relocatedBuilder.Spec
+		updateBuilder.Annotations = k8s.MergeAnnotations(updateBuilder.Annotations, relocatedBuilder.Annotations)
+		patch, err := k8s.CreatePatch(existingBuilder, updateBuilder)
+		if err != nil {
+			return err
+		}
+		builder, err = builders.Patch(ctx, updateBuilder.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			return err
+		}
+	}
+
+	return i.waiter.Wait(ctx, builder, builderHasResolved(storeToGeneration[relocatedBuilder.Spec.Store.Name], stackToGeneration[relocatedBuilder.Spec.Stack.Name]))
+}
+
+// builderTag defaults the tag of a namespaced builder to the default
+// repository, like the tags of cluster builders.
+func builderTag(kpConfig config.KpConfig, builder Builder) (string, error) {
+	if builder.Tag != "" {
+		return builder.Tag, nil
+	}
+
+	defaultRepo, err := kpConfig.DefaultRepository()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get default repository")
+	}
+	return fmt.Sprintf("%s:builder-%s-%s", defaultRepo, builder.Namespace, builder.Name), nil
+}
+
+func serviceAccountOrDefault(serviceAccount string) string {
+	if serviceAccount == "" {
+		return "default"
+	}
+	return serviceAccount
+}
+
 func buildpackagesForSource(sources []Source) []string {
 	var buildpackages []string
 	for _, s := range sources {
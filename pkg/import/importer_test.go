@@ -28,6 +28,7 @@ import (
 
 	"github.com/buildpacks-community/kpack-cli/pkg/config"
 	"github.com/buildpacks-community/kpack-cli/pkg/k8s"
+	"github.com/buildpacks-community/kpack-cli/pkg/registry"
 	"github.com/buildpacks-community/kpack-cli/pkg/registry/fakes"
 	"github.com/buildpacks-community/kpack-cli/pkg/testhelpers"
 )
@@ -92,7 +93,7 @@ func testImporter(t *testing.T, when spec.G, it spec.S) {
 						Name:      "some-serviceaccount",
 					},
 				},
-			}, kubectlAnnotation, timestampAnnotation)
+			}, kubectlAnnotation, timestampAnnotation, sourceAnnotation(ClusterLifecycle{Name: v1alpha2.DefaultLifecycleName, Image: "new-image.com/lifecycle"}))
 			expectedDefaultClusterStore = annotate(t, &v1alpha2.ClusterStore{
 				TypeMeta: metav1.TypeMeta{
 					Kind:       "ClusterStore",
@@ -110,7 +111,10 @@ func testImporter(t *testing.T, when spec.G, it spec.S) {
 						Name:      "some-serviceaccount",
 					},
 				},
-			}, kubectlAnnotation, timestampAnnotation)
+			}, kubectlAnnotation, timestampAnnotation, sourceAnnotation(importedStore{
+				ClusterStore: ClusterStore{Name: "default", Sources: []Source{{Image: "new-image.com/buildpacks/dotnet-core"}}},
+				Relocated:    map[string]string{fmt.Sprintf("gcr.io/my-cool-repo@sha256:%s", dotnetCoreDigest): "new-image.com/buildpacks/dotnet-core"},
+			}))
 			expectedClusterStack = annotate(t, &v1alpha2.ClusterStack{
 				TypeMeta: metav1.TypeMeta{
 					Kind:       "ClusterStack",
@@ -132,7 +136,7 @@ func testImporter(t *testing.T, when spec.G, it spec.S) {
 						Name:      "some-serviceaccount",
 					},
 				},
-			}, timestampAnnotation)
+			}, timestampAnnotation, sourceAnnotation(ClusterStack{Name: "base", BuildImage: Source{Image: "new-image.com/stacks/base/build"}, RunImage: Source{Image: "new-image.com/stacks/base/run"}}))
 			expectedDefaultClusterStack = annotate(t, &v1alpha2.ClusterStack{
 				TypeMeta: metav1.TypeMeta{
 					Kind:       "ClusterStack",
@@ -154,7 +158,7 @@ func testImporter(t *testing.T, when spec.G, it spec.S) {
 						Name:      "some-serviceaccount",
 					},
 				},
-			}, timestampAnnotation)
+			}, timestampAnnotation, sourceAnnotation(ClusterStack{Name: "default", BuildImage: Source{Image: "new-image.com/stacks/base/build"}, RunImage: Source{Image: "new-image.com/stacks/base/run"}}))
 			expectedClusterBuilder = annotate(t, &v1alpha2.ClusterBuilder{
 				TypeMeta: metav1.TypeMeta{
 					Kind:       "ClusterBuilder",
@@ -567,11 +571,12 @@ clusterBuilders:
     - id: tanzu-buildpacks/nodejs
 `,
 					ExpectPatches: []string{
-						`{"spec":{"sources":[{"image":"gcr.io/my-cool-repo@sha256:dotnetcoredigest"},{"image":"gcr.io/my-cool-repo@sha256:newdotnetcoredigest"},{"image":"gcr.io/my-cool-repo@sha256:nodejsdigest"}]}}`,
-						`{"spec":{"buildImage":{"image":"gcr.io/my-cool-repo@sha256:newbuildimagedigest"},"runImage":{"image":"gcr.io/my-cool-repo@sha256:newrunimagedigest"}}}`,
 						`{"metadata":{"annotations":{"kubectl.kubernetes.io/last-applied-configuration":"{\"kind\":\"ClusterBuilder\",\"apiVersion\":\"kpack.io/v1alpha2\",\"metadata\":{\"name\":\"base\",\"creationTimestamp\":null},\"spec\":{\"tag\":\"gcr.io/my-cool-repo:clusterbuilder-base\",\"stack\":{\"kind\":\"ClusterStack\",\"name\":\"base\"},\"lifecycle\":{},\"store\":{\"kind\":\"ClusterStore\",\"name\":\"default\"},\"order\":[{\"group\":[{\"id\":\"tanzu-buildpacks/dotnet-core\"}]},{\"group\":[{\"id\":\"tanzu-buildpacks/nodejs\"}]}],\"serviceAccountRef\":{\"namespace\":\"some-namespace\",\"name\":\"some-serviceaccount\"}},\"status\":{\"stack\":{},\"lifecycle\":{\"image\":{},\"api\":{},\"apis\":{\"buildpack\":{\"deprecated\":null,\"supported\":null},\"platform\":{\"deprecated\":null,\"supported\":null}}}}}"}},"spec":{"order":[{"group":[{"id":"tanzu-buildpacks/dotnet-core"}]},{"group":[{"id":"tanzu-buildpacks/nodejs"}]}],"tag":"gcr.io/my-cool-repo:clusterbuilder-base"}}`,
 						`{"metadata":{"annotations":{"kubectl.kubernetes.io/last-applied-configuration":"{\"kind\":\"ClusterBuilder\",\"apiVersion\":\"kpack.io/v1alpha2\",\"metadata\":{\"name\":\"default\",\"creationTimestamp\":null},\"spec\":{\"tag\":\"gcr.io/my-cool-repo:clusterbuilder-default\",\"stack\":{\"kind\":\"ClusterStack\",\"name\":\"base\"},\"lifecycle\":{},\"store\":{\"kind\":\"ClusterStore\",\"name\":\"default\"},\"order\":[{\"group\":[{\"id\":\"tanzu-buildpacks/dotnet-core\"}]},{\"group\":[{\"id\":\"tanzu-buildpacks/nodejs\"}]}],\"serviceAccountRef\":{\"namespace\":\"some-namespace\",\"name\":\"some-serviceaccount\"}},\"status\":{\"stack\":{},\"lifecycle\":{\"image\":{},\"api\":{},\"apis\":{\"buildpack\":{\"deprecated\":null,\"supported\":null},\"platform\":{\"deprecated\":null,\"supported\":null}}}}}"}},"spec":{"order":[{"group":[{"id":"tanzu-buildpacks/dotnet-core"}]},{"group":[{"id":"tanzu-buildpacks/nodejs"}]}],"tag":"gcr.io/my-cool-repo:clusterbuilder-default"}}`,
-						`{"metadata":{"annotations":{"kpack.io/import-timestamp":"0001-01-01 00:00:00 +0000 UTC","kubectl.kubernetes.io/last-applied-configuration":"{\"kind\":\"ClusterLifecycle\",\"apiVersion\":\"kpack.io/v1alpha2\",\"metadata\":{\"name\":\"default-lifecycle\",\"creationTimestamp\":null},\"spec\":{\"image\":\"gcr.io/my-cool-repo@sha256:newlifecycledigest\",\"serviceAccountRef\":{\"namespace\":\"some-namespace\",\"name\":\"some-serviceaccount\"}},\"status\":{\"image\":{},\"api\":{},\"apis\":{\"buildpack\":{\"deprecated\":null,\"supported\":null},\"platform\":{\"deprecated\":null,\"supported\":null}}}}"}},"spec":{"image":"gcr.io/my-cool-repo@sha256:newlifecycledigest","serviceAccountRef":{"name":"some-serviceaccount","namespace":"some-namespace"}}}`,
+						`{"metadata":{"annotations":{"kpack.io/import-source":"{\"name\":\"default-lifecycle\",\"image\":\"new-image.com/lifecycle\"}","kpack.io/import-timestamp":"0001-01-01 00:00:00 +0000 UTC","kubectl.kubernetes.io/last-applied-configuration":"{\"kind\":\"ClusterLifecycle\",\"apiVersion\":\"kpack.io/v1alpha2\",\"metadata\":{\"name\":\"default-lifecycle\",\"creationTimestamp\":null},\"spec\":{\"image\":\"gcr.io/my-cool-repo@sha256:newlifecycledigest\",\"serviceAccountRef\":{\"namespace\":\"some-namespace\",\"name\":\"some-serviceaccount\"}},\"status\":{\"image\":{},\"api\":{},\"apis\":{\"buildpack\":{\"deprecated\":null,\"supported\":null},\"platform\":{\"deprecated\":null,\"supported\":null}}}}"}},"spec":{"image":"gcr.io/my-cool-repo@sha256:newlifecycledigest","serviceAccountRef":{"name":"some-serviceaccount","namespace":"some-namespace"}}}`,
+						`{"metadata":{"annotations":{"kpack.io/import-source":"{\"name\":\"default\",\"sources\":[{\"image\":\"new-image.com/buildpacks/dotnet-core\"},{\"image\":\"new-image.com/buildpacks/nodejs\"}],\"relocated\":{\"gcr.io/my-cool-repo@sha256:newdotnetcoredigest\":\"new-image.com/buildpacks/dotnet-core\",\"gcr.io/my-cool-repo@sha256:nodejsdigest\":\"new-image.com/buildpacks/nodejs\"}}"}},"spec":{"sources":[{"image":"gcr.io/my-cool-repo@sha256:dotnetcoredigest"},{"image":"gcr.io/my-cool-repo@sha256:newdotnetcoredigest"},{"image":"gcr.io/my-cool-repo@sha256:nodejsdigest"}]}}`,
+						`{"metadata":{"annotations":{"kpack.io/import-source":"{\"name\":\"base\",\"buildImage\":{\"image\":\"new-image.com/stacks/base/build\"},\"runImage\":{\"image\":\"new-image.com/stacks/base/run\"}}"}},"spec":{"buildImage":{"image":"gcr.io/my-cool-repo@sha256:newbuildimagedigest"},"runImage":{"image":"gcr.io/my-cool-repo@sha256:newrunimagedigest"}}}`,
+						`{"metadata":{"annotations":{"kpack.io/import-source":"{\"name\":\"default\",\"buildImage\":{\"image\":\"new-image.com/stacks/base/build\"},\"runImage\":{\"image\":\"new-image.com/stacks/base/run\"}}"}},"spec":{"buildImage":{"image":"gcr.io/my-cool-repo@sha256:newbuildimagedigest"},"runImage":{"image":"gcr.io/my-cool-repo@sha256:newrunimagedigest"}}}`,
 					},
 				}.TestImporter(t)
 			})
@@ -628,10 +633,11 @@ clusterBuilders:
     - id: tanzu-buildpacks/nodejs
 `,
 					ExpectPatches: []string{
-						`{"spec":{"buildImage":{"image":"gcr.io/my-cool-repo@sha256:newbuildimagedigest"},"runImage":{"image":"gcr.io/my-cool-repo@sha256:newrunimagedigest"}}}`,
 						`{"metadata":{"annotations":{"kubectl.kubernetes.io/last-applied-configuration":"{\"kind\":\"ClusterBuilder\",\"apiVersion\":\"kpack.io/v1alpha2\",\"metadata\":{\"name\":\"base\",\"creationTimestamp\":null},\"spec\":{\"tag\":\"gcr.io/my-cool-repo:clusterbuilder-base\",\"stack\":{\"kind\":\"ClusterStack\",\"name\":\"base\"},\"lifecycle\":{},\"store\":{\"kind\":\"ClusterStore\",\"name\":\"default\"},\"order\":[{\"group\":[{\"id\":\"tanzu-buildpacks/dotnet-core\"}]},{\"group\":[{\"id\":\"tanzu-buildpacks/nodejs\"}]}],\"serviceAccountRef\":{\"namespace\":\"some-namespace\",\"name\":\"some-serviceaccount\"}},\"status\":{\"stack\":{},\"lifecycle\":{\"image\":{},\"api\":{},\"apis\":{\"buildpack\":{\"deprecated\":null,\"supported\":null},\"platform\":{\"deprecated\":null,\"supported\":null}}}}}"}},"spec":{"order":[{"group":[{"id":"tanzu-buildpacks/dotnet-core"}]},{"group":[{"id":"tanzu-buildpacks/nodejs"}]}],"tag":"gcr.io/my-cool-repo:clusterbuilder-base"}}`,
 						`{"metadata":{"annotations":{"kubectl.kubernetes.io/last-applied-configuration":"{\"kind\":\"ClusterBuilder\",\"apiVersion\":\"kpack.io/v1alpha2\",\"metadata\":{\"name\":\"default\",\"creationTimestamp\":null},\"spec\":{\"tag\":\"gcr.io/my-cool-repo:clusterbuilder-default\",\"stack\":{\"kind\":\"ClusterStack\",\"name\":\"base\"},\"lifecycle\":{},\"store\":{\"kind\":\"ClusterStore\",\"name\":\"default\"},\"order\":[{\"group\":[{\"id\":\"tanzu-buildpacks/dotnet-core\"}]},{\"group\":[{\"id\":\"tanzu-buildpacks/nodejs\"}]}],\"serviceAccountRef\":{\"namespace\":\"some-namespace\",\"name\":\"some-serviceaccount\"}},\"status\":{\"stack\":{},\"lifecycle\":{\"image\":{},\"api\":{},\"apis\":{\"buildpack\":{\"deprecated\":null,\"supported\":null},\"platform\":{\"deprecated\":null,\"supported\":null}}}}}"}},"spec":{"order":[{"group":[{"id":"tanzu-buildpacks/dotnet-core"}]},{"group":[{"id":"tanzu-buildpacks/nodejs"}]}],"tag":"gcr.io/my-cool-repo:clusterbuilder-default"}}`,
-						`{"spec":{"sources":[{"image":"gcr.io/my-cool-repo@sha256:dotnetcoredigest"},{"image":"gcr.io/my-cool-repo@sha256:newdotnetcoredigest"},{"image":"gcr.io/my-cool-repo@sha256:nodejsdigest"}]}}`,
+						`{"metadata":{"annotations":{"kpack.io/import-source":"{\"name\":\"default\",\"sources\":[{\"image\":\"new-image.com/buildpacks/dotnet-core\"},{\"image\":\"new-image.com/buildpacks/nodejs\"}],\"relocated\":{\"gcr.io/my-cool-repo@sha256:newdotnetcoredigest\":\"new-image.com/buildpacks/dotnet-core\",\"gcr.io/my-cool-repo@sha256:nodejsdigest\":\"new-image.com/buildpacks/nodejs\"}}"}},"spec":{"sources":[{"image":"gcr.io/my-cool-repo@sha256:dotnetcoredigest"},{"image":"gcr.io/my-cool-repo@sha256:newdotnetcoredigest"},{"image":"gcr.io/my-cool-repo@sha256:nodejsdigest"}]}}`,
+						`{"metadata":{"annotations":{"kpack.io/import-source":"{\"name\":\"base\",\"buildImage\":{\"image\":\"new-image.com/stacks/base/build\"},\"runImage\":{\"image\":\"new-image.com/stacks/base/run\"}}"}},"spec":{"buildImage":{"image":"gcr.io/my-cool-repo@sha256:newbuildimagedigest"},"runImage":{"image":"gcr.io/my-cool-repo@sha256:newrunimagedigest"}}}`,
+						`{"metadata":{"annotations":{"kpack.io/import-source":"{\"name\":\"default\",\"buildImage\":{\"image\":\"new-image.com/stacks/base/build\"},\"runImage\":{\"image\":\"new-image.com/stacks/base/run\"}}"}},"spec":{"buildImage":{"image":"gcr.io/my-cool-repo@sha256:newbuildimagedigest"},"runImage":{"image":"gcr.io/my-cool-repo@sha256:newrunimagedigest"}}}`,
 					},
 				}.TestImporter(t)
 			})
@@ -742,7 +748,7 @@ clusterBuilders:
 						Name:      "some-serviceaccount",
 					},
 				},
-			}, kubectlAnnotation, timestampAnnotation)
+			}, kubectlAnnotation, timestampAnnotation, sourceAnnotation(ClusterLifecycle{Name: v1alpha2.DefaultLifecycleName, Image: "new-image.com/lifecycle"}))
 
 			expectedClusterStack := annotate(t, &v1alpha2.ClusterStack{
 				TypeMeta: metav1.TypeMeta{
@@ -765,7 +771,7 @@ clusterBuilders:
 						Name:      "some-serviceaccount",
 					},
 				},
-			}, timestampAnnotation)
+			}, timestampAnnotation, sourceAnnotation(ClusterStack{Name: "base", BuildImage: Source{Image: "new-image.com/stacks/base/build"}, RunImage: Source{Image: "new-image.com/stacks/base/run"}}))
 
 			expectedDefaultClusterStack := annotate(t, &v1alpha2.ClusterStack{
 				TypeMeta: metav1.TypeMeta{
@@ -788,7 +794,7 @@ clusterBuilders:
 						Name:      "some-serviceaccount",
 					},
 				},
-			}, timestampAnnotation)
+			}, timestampAnnotation, sourceAnnotation(ClusterStack{Name: "default", BuildImage: Source{Image: "new-image.com/stacks/base/build"}, RunImage: Source{Image: "new-image.com/stacks/base/run"}}))
 
 			// ClusterBuilder WITHOUT store reference (empty ClusterStore)
 			expectedClusterBuilderNoStore := annotate(t, &v1alpha2.ClusterBuilder{
@@ -907,6 +913,137 @@ clusterBuilders:
 			}.TestImporter(t)
 		})
 	})
+
+	when("importing a v2 descriptor", func() {
+		it("creates namespaced buildpacks and builders", func() {
+			expectedClusterStack := annotate(t, &v1alpha2.ClusterStack{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "ClusterStack",
+					APIVersion: "kpack.io/v1alpha2",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "base",
+				},
+				Spec: v1alpha2.ClusterStackSpec{
+					Id: stackId,
+					BuildImage: v1alpha2.ClusterStackSpecImage{
+						Image: fmt.Sprintf("gcr.io/my-cool-repo@sha256:%s", buildImageDigest),
+					},
+					RunImage: v1alpha2.ClusterStackSpecImage{
+						Image: fmt.Sprintf("gcr.io/my-cool-repo@sha256:%s", runImageDigest),
+					},
+					ServiceAccountRef: &corev1.ObjectReference{
+						Namespace: "some-namespace",
+						Name:      "some-serviceaccount",
+					},
+				},
+			}, timestampAnnotation, sourceAnnotation(ClusterStack{Name: "base", BuildImage: Source{Image: "new-image.com/stacks/base/build"}, RunImage: Source{Image: "new-image.com/stacks/base/run"}}))
+
+			expectedBuildpack := annotate(t, &v1alpha2.Buildpack{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Buildpack",
+					APIVersion: "kpack.io/v1alpha2",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "dotnet-core",
+					Namespace: "team-a",
+				},
+				Spec: v1alpha2.BuildpackSpec{
+					ImageSource: corev1alpha1.ImageSource{
+						Image: fmt.Sprintf("gcr.io/my-cool-repo@sha256:%s", dotnetCoreDigest),
+					},
+					ServiceAccountName: "default",
+				},
+			}, kubectlAnnotation, timestampAnnotation)
+
+			expectedBuilder := annotate(t, &v1alpha2.Builder{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Builder",
+					APIVersion: "kpack.io/v1alpha2",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "base",
+					Namespace: "team-a",
+				},
+				Spec: v1alpha2.NamespacedBuilderSpec{
+					BuilderSpec: v1alpha2.BuilderSpec{
+						Tag: "gcr.io/my-cool-repo:builder-team-a-base",
+						Stack: corev1.ObjectReference{
+							Name: "base",
+							Kind: "ClusterStack",
+						},
+						Order: []v1alpha2.BuilderOrderEntry{
+							{
+								Group: []v1alpha2.BuilderBuildpackRef{
+									{
+										ObjectReference: corev1.ObjectReference{
+											Name: "dotnet-core",
+											Kind: "Buildpack",
+										},
+									},
+								},
+							},
+						},
+					},
+					ServiceAccountName: "builder-sa",
+				},
+			}, kubectlAnnotation, timestampAnnotation)
+
+			TestImport{
+				Images: map[string]v1.Image{
+					"new-image.com/buildpacks/dotnet-core": fakes.NewFakeLabeledImage("io.buildpacks.buildpackage.metadata", fmt.Sprintf("{\"id\":%q}", dotnetCoreId), dotnetCoreDigest),
+					"new-image.com/stacks/base/run":        fakes.NewFakeLabeledImage("io.buildpacks.stack.id", stackId, runImageDigest),
+					"new-image.com/stacks/base/build":      fakes.NewFakeLabeledImage("io.buildpacks.stack.id", stackId, buildImageDigest),
+				},
+				Objects:  []runtime.Object{},
+				KpConfig: kpConfig,
+				DependencyDescriptor: `
+apiVersion: kp.kpack.io/v2
+kind: DependencyDescriptor
+clusterStacks:
+- name: base
+  buildImage:
+    image: new-image.com/stacks/base/build
+  runImage:
+    image: new-image.com/stacks/base/run
+buildpacks:
+- name: dotnet-core
+  namespace: team-a
+  image: new-image.com/buildpacks/dotnet-core
+builders:
+- name: base
+  namespace: team-a
+  clusterStack: base
+  serviceAccount: builder-sa
+  order:
+  - group:
+    - name: dotnet-core
+      kind: Buildpack
+`,
+				ExpectCreates: []runtime.Object{
+					expectedBuildpack,
+					expectedClusterStack,
+					expectedBuilder,
+				},
+			}.TestImporter(t)
+		})
+
+		it("rejects namespaced resources in a v1 descriptor", func() {
+			TestImport{
+				Objects:  []runtime.Object{},
+				KpConfig: kpConfig,
+				DependencyDescriptor: `
+apiVersion: kp.kpack.io/v1
+kind: DependencyDescriptor
+builders:
+- name: base
+  namespace: team-a
+  clusterStack: base
+`,
+				ExpectErr: errors.New("buildpacks and builders require apiVersion kp.kpack.io/v2"),
+			}.TestImporter(t)
+		})
+	})
 }
 
 func annotate(t *testing.T, object k8s.Annotatable, f ...func(t *testing.T, object k8s.Annotatable) k8s.Annotatable) runtime.Object {
@@ -932,6 +1069,13 @@ func timestampAnnotation(t *testing.T, object k8s.Annotatable) k8s.Annotatable {
 	return object
 }
 
+func sourceAnnotation(entry interface{}) func(t *testing.T, object k8s.Annotatable) k8s.Annotatable {
+	return func(t *testing.T, object k8s.Annotatable) k8s.Annotatable {
+		require.NoError(t, setImportSource(object, entry))
+		return object
+	}
+}
+
 type TestImport struct {
 	Objects              []runtime.Object
 	KpConfig             config.KpConfig
@@ -953,7 +1097,7 @@ func (i TestImport) TestImporter(t *testing.T) {
 
 	buffer := &bytes.Buffer{}
 	var err error
-	importer := NewImporter(testLogger{writer: buffer}, k8sClient, client, &fakeFetcher{Images: i.Images}, &fakeRelocator{}, &fakeWaiter{}, &fakeTimestampProvider{ts: time.Time{}.String()})
+	importer := NewImporter(testLogger{writer: buffer}, k8sClient, client, &fakeFetcher{Images: i.Images}, &fakeRelocator{}, &fakeWaiter{}, &fakeTimestampProvider{ts: time.Time{}.String()}, 1)
 	if i.DryRun {
 		_, err = importer.ImportDescriptorDryRun(context.Background(), authn.NewMultiKeychain(), i.KpConfig, i.DependencyDescriptor)
 	} else {
@@ -1001,7 +1145,7 @@ type fakeFetcher struct {
 func (f *fakeFetcher) Fetch(keychain authn.Keychain, image string) (v1.Image, error) {
 	img, ok := f.Images[image]
 	if !ok {
-		return nil, errors.New("buddy we don't have your image, check another registry")
+		return nil, &registry.ImageNotFoundError{Message: "buddy we don't have your image, check another registry"}
 	}
 
 	return img, nil
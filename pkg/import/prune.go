@@ -0,0 +1,357 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package _import
+
+import (
+	"context"
+
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PruneCandidate is a resource created by a previous import that is no
+// longer declared in the dependency descriptor.
+type PruneCandidate struct {
+	Kind      string `yaml:"kind" json:"kind"`
+	Name      string `yaml:"name" json:"name"`
+	Namespace string `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+}
+
+// FullName returns the name of the candidate, prefixed with the namespace of
+// namespaced resources.
+func (c PruneCandidate) FullName() string {
+	if c.Namespace == "" {
+		return c.Name
+	}
+	return c.Namespace + "/" + c.Name
+}
+
+// FindPruneCandidates returns the imported resources missing from the
+// descriptor, in the order they can be safely deleted.
+func (i *Importer) FindPruneCandidates(ctx context.Context, desc DependencyDescriptor) ([]PruneCandidate, error) {
+	var candidates []PruneCandidate
+
+	clusterBuilderNames := map[string]bool{}
+	for _, cb := range GetClusterBuilders(desc) {
+		clusterBuilderNames[cb.Name] = true
+	}
+	clusterBuilders, err := i.client.KpackV1alpha2().ClusterBuilders().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, cb := range clusterBuilders.Items {
+		if isImported(cb.ObjectMeta) && !clusterBuilderNames[cb.Name] {
+			candidates = append(candidates, PruneCandidate{Kind: v1alpha2.ClusterBuilderKind, Name: cb.Name})
+		}
+	}
+
+	builderNames := map[string]bool{}
+	for _, b := range GetBuilders(desc) {
+		builderNames[b.Namespace+"/"+b.Name] = true
+	}
+	builders, err := i.client.KpackV1alpha2().Builders(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range builders.Items {
+		if isImported(b.ObjectMeta) && !builderNames[b.Namespace+"/"+b.Name] {
+			candidates = append(candidates, PruneCandidate{Kind: v1alpha2.BuilderKind, Name: b.Name, Namespace: b.Namespace})
+		}
+	}
+
+	stackNames := map[string]bool{}
+	for _, stack := range GetClusterStacks(desc) {
+		stackNames[stack.Name] = true
+	}
+	stacks, err := i.client.KpackV1alpha2().ClusterStacks().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, stack := range stacks.Items {
+		if isImported(stack.ObjectMeta) && !stackNames[stack.Name] {
+			candidates = append(candidates, PruneCandidate{Kind: v1alpha2.ClusterStackKind, Name: stack.Name})
+		}
+	}
+
+	storeNames := map[string]bool{}
+	for _, store := range GetClusterStores(desc) {
+		storeNames[store.Name] = true
+	}
+	stores, err := i.client.KpackV1alpha2().ClusterStores().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, store := range stores.Items {
+		if isImported(store.ObjectMeta) && !storeNames[store.Name] {
+			candidates = append(candidates, PruneCandidate{Kind: v1alpha2.ClusterStoreKind, Name: store.Name})
+		}
+	}
+
+	buildpackNames := map[string]bool{}
+	for _, buildpack := range GetClusterBuildpacks(desc) {
+		buildpackNames[buildpack.Name] = true
+	}
+	buildpacks, err := i.client.KpackV1alpha2().ClusterBuildpacks().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, buildpack := range buildpacks.Items {
+		if isImported(buildpack.ObjectMeta) && !buildpackNames[buildpack.Name] {
+			candidates = append(candidates, PruneCandidate{Kind: v1alpha2.ClusterBuildpackKind, Name: buildpack.Name})
+		}
+	}
+
+	namespacedBuildpackNames := map[string]bool{}
+	for _, buildpack := range GetBuildpacks(desc) {
+		namespacedBuildpackNames[buildpack.Namespace+"/"+buildpack.Name] = true
+	}
+	namespacedBuildpacks, err := i.client.KpackV1alpha2().Buildpacks(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, buildpack := range namespacedBuildpacks.Items {
+		if isImported(buildpack.ObjectMeta) && !namespacedBuildpackNames[buildpack.Namespace+"/"+buildpack.Name] {
+			candidates = append(candidates, PruneCandidate{Kind: v1alpha2.BuildpackKind, Name: buildpack.Name, Namespace: buildpack.Namespace})
+		}
+	}
+
+	lifecycleNames := map[string]bool{}
+	for _, lifecycle := range GetClusterLifecycles(desc) {
+		lifecycleNames[lifecycle.Name] = true
+	}
+	lifecycles, err := i.client.KpackV1alpha2().ClusterLifecycles().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, lifecycle := range lifecycles.Items {
+		if isImported(lifecycle.ObjectMeta) && !lifecycleNames[lifecycle.Name] {
+			candidates = append(candidates, PruneCandidate{Kind: v1alpha2.ClusterLifecycleKind, Name: lifecycle.Name})
+		}
+	}
+
+	return candidates, nil
+}
+
+// Prune deletes the candidates. Use ValidatePrune before importing the
+// descriptor to ensure nothing will still reference them.
+func (i *Importer) Prune(ctx context.Context, candidates []PruneCandidate) error {
+	for _, candidate := range candidates {
+		if err := i.printer.PrintStatus("Pruning %s '%s'...", candidate.Kind, candidate.FullName()); err != nil {
+			return err
+		}
+
+		if err := i.deletePruneCandidate(ctx, candidate); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i *Importer) PruneDryRun(candidates []PruneCandidate) error {
+	for _, candidate := range candidates {
+		if err := i.printer.PrintStatus("Pruning %s '%s'...", candidate.Kind, candidate.FullName()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i *Importer) deletePruneCandidate(ctx context.Context, candidate PruneCandidate) error {
+	switch candidate.Kind {
+	case v1alpha2.ClusterBuilderKind:
+		return i.client.KpackV1alpha2().ClusterBuilders().Delete(ctx, candidate.Name, metav1.DeleteOptions{})
+	case v1alpha2.BuilderKind:
+		return i.client.KpackV1alpha2().Builders(candidate.Namespace).Delete(ctx, candidate.Name, metav1.DeleteOptions{})
+	case v1alpha2.ClusterStackKind:
+		return i.client.KpackV1alpha2().ClusterStacks().Delete(ctx, candidate.Name, metav1.DeleteOptions{})
+	case v1alpha2.ClusterStoreKind:
+		return i.client.KpackV1alpha2().ClusterStores().Delete(ctx, candidate.Name, metav1.DeleteOptions{})
+	case v1alpha2.ClusterBuildpackKind:
+		return i.client.KpackV1alpha2().ClusterBuildpacks().Delete(ctx, candidate.Name, metav1.DeleteOptions{})
+	case v1alpha2.BuildpackKind:
+		return i.client.KpackV1alpha2().Buildpacks(candidate.Namespace).Delete(ctx, candidate.Name, metav1.DeleteOptions{})
+	case v1alpha2.ClusterLifecycleKind:
+		return i.client.KpackV1alpha2().ClusterLifecycles().Delete(ctx, candidate.Name, metav1.DeleteOptions{})
+	default:
+		return errors.Errorf("cannot prune unknown kind '%s'", candidate.Kind)
+	}
+}
+
+// ValidatePrune fails if a candidate is referenced by a ClusterBuilder,
+// Builder or Image that is not being pruned. Builders declared in the
+// descriptor are checked against their declared spec, which replaces the one
+// in the cluster on import.
+func (i *Importer) ValidatePrune(ctx context.Context, desc DependencyDescriptor, candidates []PruneCandidate) error {
+	pruned := map[PruneCandidate]bool{}
+	for _, candidate := range candidates {
+		pruned[candidate] = true
+	}
+
+	ids, err := i.candidateBuildpackIds(ctx, pruned)
+	if err != nil {
+		return err
+	}
+
+	checkRefs := func(refs []PruneCandidate, referrer string) error {
+		for _, ref := range refs {
+			if pruned[ref] {
+				return errors.Errorf("cannot prune %s '%s': it is referenced by %s", ref.Kind, ref.FullName(), referrer)
+			}
+		}
+		return nil
+	}
+
+	declaredClusterBuilders := map[string]bool{}
+	for _, cb := range GetClusterBuilders(desc) {
+		declaredClusterBuilders[cb.Name] = true
+		refs := declaredBuilderReferences(cb.ClusterStack, cb.ClusterStore, cb.Order, "", ids)
+		if err := checkRefs(refs, "ClusterBuilder '"+cb.Name+"'"); err != nil {
+			return err
+		}
+	}
+
+	clusterBuilders, err := i.client.KpackV1alpha2().ClusterBuilders().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, cb := range clusterBuilders.Items {
+		if declaredClusterBuilders[cb.Name] || pruned[PruneCandidate{Kind: v1alpha2.ClusterBuilderKind, Name: cb.Name}] {
+			continue
+		}
+		if err := checkRefs(builderSpecReferences(cb.Spec.BuilderSpec, "", ids), "ClusterBuilder '"+cb.Name+"'"); err != nil {
+			return err
+		}
+	}
+
+	declaredBuilders := map[string]bool{}
+	for _, b := range GetBuilders(desc) {
+		declaredBuilders[b.Namespace+"/"+b.Name] = true
+		refs := declaredBuilderReferences(b.ClusterStack, b.ClusterStore, b.Order, b.Namespace, ids)
+		if err := checkRefs(refs, "Builder '"+b.Namespace+"/"+b.Name+"'"); err != nil {
+			return err
+		}
+	}
+
+	builders, err := i.client.KpackV1alpha2().Builders(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, b := range builders.Items {
+		if declaredBuilders[b.Namespace+"/"+b.Name] || pruned[PruneCandidate{Kind: v1alpha2.BuilderKind, Name: b.Name, Namespace: b.Namespace}] {
+			continue
+		}
+		if err := checkRefs(builderSpecReferences(b.Spec.BuilderSpec, b.Namespace, ids), "Builder '"+b.Namespace+"/"+b.Name+"'"); err != nil {
+			return err
+		}
+	}
+
+	images, err := i.client.KpackV1alpha2().Images(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, img := range images.Items {
+		var ref PruneCandidate
+		switch img.Spec.Builder.Kind {
+		case v1alpha2.ClusterBuilderKind:
+			ref = PruneCandidate{Kind: v1alpha2.ClusterBuilderKind, Name: img.Spec.Builder.Name}
+		case v1alpha2.BuilderKind:
+			ref = PruneCandidate{Kind: v1alpha2.BuilderKind, Name: img.Spec.Builder.Name, Namespace: img.Namespace}
+		default:
+			continue
+		}
+		if err := checkRefs([]PruneCandidate{ref}, "Image '"+img.Namespace+"/"+img.Name+"'"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// candidateBuildpackIds maps the buildpack ids provided by the ClusterBuildpack
+// and Buildpack candidates to the candidates, so that builder order entries
+// that only reference a buildpack id can be resolved.
+func (i *Importer) candidateBuildpackIds(ctx context.Context, pruned map[PruneCandidate]bool) (map[string][]PruneCandidate, error) {
+	ids := map[string][]PruneCandidate{}
+	add := func(candidate PruneCandidate, statuses []corev1alpha1.BuildpackStatus) {
+		if !pruned[candidate] {
+			return
+		}
+		for _, status := range statuses {
+			ids[status.Id] = append(ids[status.Id], candidate)
+		}
+	}
+
+	clusterBuildpacks, err := i.client.KpackV1alpha2().ClusterBuildpacks().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, bp := range clusterBuildpacks.Items {
+		add(PruneCandidate{Kind: v1alpha2.ClusterBuildpackKind, Name: bp.Name}, bp.Status.Buildpacks)
+	}
+
+	buildpacks, err := i.client.KpackV1alpha2().Buildpacks(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, bp := range buildpacks.Items {
+		add(PruneCandidate{Kind: v1alpha2.BuildpackKind, Name: bp.Name, Namespace: bp.Namespace}, bp.Status.Buildpacks)
+	}
+
+	return ids, nil
+}
+
+func declaredBuilderReferences(clusterStack, clusterStore string, order []v1alpha2.BuilderOrderEntry, namespace string, ids map[string][]PruneCandidate) []PruneCandidate {
+	return builderSpecReferences(v1alpha2.BuilderSpec{
+		Stack: corev1.ObjectReference{Name: clusterStack},
+		Store: corev1.ObjectReference{Name: clusterStore},
+		Order: order,
+	}, namespace, ids)
+}
+
+// builderSpecReferences returns the resources a builder in the namespace
+// references, or that of a ClusterBuilder when the namespace is empty. Order
+// entries with only a buildpack id reference every candidate that provides
+// that id and is visible to the builder.
+func builderSpecReferences(spec v1alpha2.BuilderSpec, namespace string, ids map[string][]PruneCandidate) []PruneCandidate {
+	refs := []PruneCandidate{
+		{Kind: v1alpha2.ClusterStackKind, Name: spec.Stack.Name},
+	}
+
+	if spec.Store.Name != "" {
+		refs = append(refs, PruneCandidate{Kind: v1alpha2.ClusterStoreKind, Name: spec.Store.Name})
+	}
+
+	// builders without a lifecycle reference use the default lifecycle
+	lifecycle := spec.Lifecycle.Name
+	if lifecycle == "" {
+		lifecycle = v1alpha2.DefaultLifecycleName
+	}
+	refs = append(refs, PruneCandidate{Kind: v1alpha2.ClusterLifecycleKind, Name: lifecycle})
+
+	for _, entry := range spec.Order {
+		for _, ref := range entry.Group {
+			switch {
+			case ref.Kind == v1alpha2.ClusterBuildpackKind:
+				refs = append(refs, PruneCandidate{Kind: v1alpha2.ClusterBuildpackKind, Name: ref.Name})
+			case ref.Kind == v1alpha2.BuildpackKind && namespace != "":
+				refs = append(refs, PruneCandidate{Kind: v1alpha2.BuildpackKind, Name: ref.Name, Namespace: namespace})
+			case ref.Name == "" && ref.Image == "" && ref.Id != "":
+				for _, candidate := range ids[ref.Id] {
+					if candidate.Namespace == "" || candidate.Namespace == namespace {
+						refs = append(refs, candidate)
+					}
+				}
+			}
+		}
+	}
+	return refs
+}
+
+func isImported(meta metav1.ObjectMeta) bool {
+	_, ok := meta.Annotations[importTimestampAnnotation]
+	return ok
+}
@@ -0,0 +1,245 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package _import
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	kpackfakes "github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfakes "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestPrune(t *testing.T) {
+	spec.Run(t, "TestPrune", testPrune)
+}
+
+func testPrune(t *testing.T, when spec.G, it spec.S) {
+	imported := map[string]string{importTimestampAnnotation: "2006-01-02T15:04:05Z"}
+
+	desc := DependencyDescriptor{
+		ClusterStacks: []ClusterStack{{Name: "new-stack"}},
+		ClusterBuilders: []ClusterBuilder{
+			{Name: "builder", ClusterStack: "new-stack"},
+		},
+	}
+
+	oldStack := &v1alpha2.ClusterStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "old-stack", Annotations: imported},
+	}
+	manualStack := &v1alpha2.ClusterStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "manual-stack"},
+	}
+	newStack := &v1alpha2.ClusterStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "new-stack", Annotations: imported},
+	}
+	builder := &v1alpha2.ClusterBuilder{
+		ObjectMeta: metav1.ObjectMeta{Name: "builder", Annotations: imported},
+		Spec: v1alpha2.ClusterBuilderSpec{
+			BuilderSpec: v1alpha2.BuilderSpec{
+				Stack: corev1.ObjectReference{Name: "old-stack", Kind: v1alpha2.ClusterStackKind},
+			},
+		},
+	}
+	oldBuilder := &v1alpha2.ClusterBuilder{
+		ObjectMeta: metav1.ObjectMeta{Name: "old-builder", Annotations: imported},
+		Spec: v1alpha2.ClusterBuilderSpec{
+			BuilderSpec: v1alpha2.BuilderSpec{
+				Stack: corev1.ObjectReference{Name: "old-stack", Kind: v1alpha2.ClusterStackKind},
+			},
+		},
+	}
+
+	newImporter := func(objs ...runtime.Object) (*Importer, *kpackfakes.Clientset) {
+		client := kpackfakes.NewSimpleClientset(objs...)
+		importer := NewImporter(testLogger{writer: &bytes.Buffer{}}, k8sfakes.NewSimpleClientset(), client, &fakeFetcher{}, &fakeRelocator{}, &fakeWaiter{}, &fakeTimestampProvider{ts: time.Time{}.String()}, 1)
+		return importer, client
+	}
+
+	when("#FindPruneCandidates", func() {
+		it("returns imported resources that are not in the descriptor", func() {
+			importer, _ := newImporter(oldStack, manualStack, newStack, builder, oldBuilder)
+
+			candidates, err := importer.FindPruneCandidates(context.Background(), desc)
+			require.NoError(t, err)
+			require.Equal(t, []PruneCandidate{
+				{Kind: v1alpha2.ClusterBuilderKind, Name: "old-builder"},
+				{Kind: v1alpha2.ClusterStackKind, Name: "old-stack"},
+			}, candidates)
+		})
+
+		it("returns imported buildpacks and builders that are not in the descriptor", func() {
+			oldBuildpack := &v1alpha2.Buildpack{
+				ObjectMeta: metav1.ObjectMeta{Name: "old-bp", Namespace: "some-namespace", Annotations: imported},
+			}
+			newBuildpack := &v1alpha2.Buildpack{
+				ObjectMeta: metav1.ObjectMeta{Name: "new-bp", Namespace: "some-namespace", Annotations: imported},
+			}
+			oldNamespacedBuilder := &v1alpha2.Builder{
+				ObjectMeta: metav1.ObjectMeta{Name: "old-builder", Namespace: "some-namespace", Annotations: imported},
+			}
+			manualNamespacedBuilder := &v1alpha2.Builder{
+				ObjectMeta: metav1.ObjectMeta{Name: "manual-builder", Namespace: "some-namespace"},
+			}
+			importer, _ := newImporter(newStack, builder, oldBuildpack, newBuildpack, oldNamespacedBuilder, manualNamespacedBuilder)
+
+			descWithBuildpack := desc
+			descWithBuildpack.Buildpacks = []Buildpack{{Name: "new-bp", Namespace: "some-namespace"}}
+
+			candidates, err := importer.FindPruneCandidates(context.Background(), descWithBuildpack)
+			require.NoError(t, err)
+			require.Equal(t, []PruneCandidate{
+				{Kind: v1alpha2.BuilderKind, Name: "old-builder", Namespace: "some-namespace"},
+				{Kind: v1alpha2.BuildpackKind, Name: "old-bp", Namespace: "some-namespace"},
+			}, candidates)
+		})
+	})
+
+	when("#ValidatePrune", func() {
+		candidates := []PruneCandidate{
+			{Kind: v1alpha2.ClusterBuilderKind, Name: "old-builder"},
+			{Kind: v1alpha2.ClusterStackKind, Name: "old-stack"},
+		}
+
+		it("succeeds when only pruned or re-imported builders reference the candidates", func() {
+			importer, _ := newImporter(oldStack, newStack, builder, oldBuilder)
+
+			require.NoError(t, importer.ValidatePrune(context.Background(), desc, candidates))
+		})
+
+		it("fails when a cluster builder that is not being pruned references a candidate", func() {
+			manualBuilder := oldBuilder.DeepCopy()
+			manualBuilder.Name = "manual-builder"
+			manualBuilder.Annotations = nil
+			importer, _ := newImporter(oldStack, newStack, builder, oldBuilder, manualBuilder)
+
+			err := importer.ValidatePrune(context.Background(), desc, candidates)
+			require.EqualError(t, err, "cannot prune ClusterStack 'old-stack': it is referenced by ClusterBuilder 'manual-builder'")
+		})
+
+		it("fails when a cluster buildpack is referenced in a builder order", func() {
+			descWithBuildpack := desc
+			descWithBuildpack.ClusterBuilders = []ClusterBuilder{
+				{
+					Name:         "builder",
+					ClusterStack: "new-stack",
+					Order: []v1alpha2.BuilderOrderEntry{
+						{
+							Group: []v1alpha2.BuilderBuildpackRef{
+								{ObjectReference: corev1.ObjectReference{Name: "old-bp", Kind: v1alpha2.ClusterBuildpackKind}},
+							},
+						},
+					},
+				},
+			}
+			importer, _ := newImporter()
+
+			err := importer.ValidatePrune(context.Background(), descWithBuildpack, []PruneCandidate{{Kind: v1alpha2.ClusterBuildpackKind, Name: "old-bp"}})
+			require.EqualError(t, err, "cannot prune ClusterBuildpack 'old-bp': it is referenced by ClusterBuilder 'builder'")
+		})
+
+		it("fails when a builder references a candidate cluster buildpack by buildpack id", func() {
+			oldBuildpack := &v1alpha2.ClusterBuildpack{
+				ObjectMeta: metav1.ObjectMeta{Name: "old-bp", Annotations: imported},
+				Status: v1alpha2.ClusterBuildpackStatus{
+					Buildpacks: []corev1alpha1.BuildpackStatus{
+						{BuildpackInfo: corev1alpha1.BuildpackInfo{Id: "some-buildpack-id", Version: "1.0.0"}},
+					},
+				},
+			}
+			idBuilder := &v1alpha2.Builder{
+				ObjectMeta: metav1.ObjectMeta{Name: "id-builder", Namespace: "some-namespace"},
+				Spec: v1alpha2.NamespacedBuilderSpec{
+					BuilderSpec: v1alpha2.BuilderSpec{
+						Stack: corev1.ObjectReference{Name: "new-stack", Kind: v1alpha2.ClusterStackKind},
+						Order: []v1alpha2.BuilderOrderEntry{
+							{
+								Group: []v1alpha2.BuilderBuildpackRef{
+									{BuildpackRef: corev1alpha1.BuildpackRef{BuildpackInfo: corev1alpha1.BuildpackInfo{Id: "some-buildpack-id"}}},
+								},
+							},
+						},
+					},
+				},
+			}
+			importer, _ := newImporter(newStack, builder, oldBuildpack, idBuilder)
+
+			err := importer.ValidatePrune(context.Background(), desc, []PruneCandidate{{Kind: v1alpha2.ClusterBuildpackKind, Name: "old-bp"}})
+			require.EqualError(t, err, "cannot prune ClusterBuildpack 'old-bp': it is referenced by Builder 'some-namespace/id-builder'")
+		})
+
+		it("fails when an image references a candidate builder", func() {
+			oldNamespacedBuilder := &v1alpha2.Builder{
+				ObjectMeta: metav1.ObjectMeta{Name: "old-builder", Namespace: "some-namespace", Annotations: imported},
+			}
+			image := &v1alpha2.Image{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-image", Namespace: "some-namespace"},
+				Spec: v1alpha2.ImageSpec{
+					Builder: corev1.ObjectReference{Name: "old-builder", Kind: v1alpha2.BuilderKind},
+				},
+			}
+			importer, _ := newImporter(newStack, builder, oldNamespacedBuilder, image)
+
+			err := importer.ValidatePrune(context.Background(), desc, []PruneCandidate{{Kind: v1alpha2.BuilderKind, Name: "old-builder", Namespace: "some-namespace"}})
+			require.EqualError(t, err, "cannot prune Builder 'some-namespace/old-builder': it is referenced by Image 'some-namespace/some-image'")
+		})
+
+		it("fails when an image references a candidate cluster builder", func() {
+			image := &v1alpha2.Image{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-image", Namespace: "some-namespace"},
+				Spec: v1alpha2.ImageSpec{
+					Builder: corev1.ObjectReference{Name: "old-builder", Kind: v1alpha2.ClusterBuilderKind},
+				},
+			}
+			importer, _ := newImporter(oldStack, newStack, builder, oldBuilder, image)
+
+			err := importer.ValidatePrune(context.Background(), desc, candidates)
+			require.EqualError(t, err, "cannot prune ClusterBuilder 'old-builder': it is referenced by Image 'some-namespace/some-image'")
+		})
+	})
+
+	when("#Prune", func() {
+		it("deletes the candidates", func() {
+			importer, client := newImporter(oldStack, newStack, builder, oldBuilder)
+
+			err := importer.Prune(context.Background(), []PruneCandidate{
+				{Kind: v1alpha2.ClusterBuilderKind, Name: "old-builder"},
+				{Kind: v1alpha2.ClusterStackKind, Name: "old-stack"},
+			})
+			require.NoError(t, err)
+
+			_, err = client.KpackV1alpha2().ClusterBuilders().Get(context.Background(), "old-builder", metav1.GetOptions{})
+			require.True(t, k8serrors.IsNotFound(err))
+			_, err = client.KpackV1alpha2().ClusterStacks().Get(context.Background(), "old-stack", metav1.GetOptions{})
+			require.True(t, k8serrors.IsNotFound(err))
+			_, err = client.KpackV1alpha2().ClusterStacks().Get(context.Background(), "new-stack", metav1.GetOptions{})
+			require.NoError(t, err)
+		})
+
+		it("deletes namespaced candidates in their namespace", func() {
+			oldBuildpack := &v1alpha2.Buildpack{
+				ObjectMeta: metav1.ObjectMeta{Name: "old-bp", Namespace: "some-namespace", Annotations: imported},
+			}
+			importer, client := newImporter(oldBuildpack)
+
+			err := importer.Prune(context.Background(), []PruneCandidate{
+				{Kind: v1alpha2.BuildpackKind, Name: "old-bp", Namespace: "some-namespace"},
+			})
+			require.NoError(t, err)
+
+			_, err = client.KpackV1alpha2().Buildpacks("some-namespace").Get(context.Background(), "old-bp", metav1.GetOptions{})
+			require.True(t, k8serrors.IsNotFound(err))
+		})
+	})
+}
@@ -0,0 +1,275 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package _import
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"golang.org/x/sync/errgroup"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/clusterbuildpack"
+	"github.com/buildpacks-community/kpack-cli/pkg/clusterlifecycle"
+	"github.com/buildpacks-community/kpack-cli/pkg/clusterstack"
+	"github.com/buildpacks-community/kpack-cli/pkg/clusterstore"
+	"github.com/buildpacks-community/kpack-cli/pkg/registry"
+)
+
+// relocationJob constructs a single resource, relocating its images with the
+// printer and factories of the given importer.
+type relocationJob func(importer *Importer) (runtime.Object, error)
+
+// run runs the job. Resources with an image that was skipped by the signature
+// verification policy are left out with a nil result.
+func (job relocationJob) run(importer *Importer) (runtime.Object, error) {
+	obj, err := job(importer)
+	if registry.IsVerificationSkipped(err) {
+		return nil, importer.printer.Printlnf("Skipping: %s", err)
+	}
+	return obj, err
+}
+
+// runRelocationJobs runs up to i.parallelism jobs at once. The output of each
+// job is recorded and replayed in job order, so that it reads the same as when
+// the jobs run one at a time. After a job fails no later jobs are started, and
+// the running jobs are waited for before the error is returned.
+func (i *Importer) runRelocationJobs(ctx context.Context, jobs []relocationJob) ([]runtime.Object, error) {
+	relocator, ok := i.imageRelocator.(registry.ConcurrentRelocator)
+	if i.parallelism <= 1 || !ok {
+		results := make([]runtime.Object, 0, len(jobs))
+		for _, job := range jobs {
+			obj, err := job.run(i)
+			if err != nil {
+				return nil, err
+			}
+			if obj != nil {
+				results = append(results, obj)
+			}
+		}
+		return results, nil
+	}
+
+	var (
+		results = make([]runtime.Object, len(jobs))
+		errs    = make([]error, len(jobs))
+		outputs = make([]*recordingPrinter, len(jobs))
+		done    = make([]chan struct{}, len(jobs))
+	)
+
+	for idx := range jobs {
+		outputs[idx] = &recordingPrinter{}
+		done[idx] = make(chan struct{})
+	}
+
+	replayed := make(chan error, 1)
+	go func() {
+		replayed <- i.replayRelocationJobs(outputs, errs, done)
+	}()
+
+	var (
+		group  errgroup.Group
+		mu     sync.Mutex
+		failed = len(jobs)
+	)
+	group.SetLimit(i.parallelism)
+
+	// a job is not started once the context is done or an earlier job failed,
+	// jobs before the failed one still run so their output can be replayed
+	started := func(idx int) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return idx < failed && ctx.Err() == nil
+	}
+
+	for idx, job := range jobs {
+		idx, job := idx, job
+		group.Go(func() error {
+			defer close(done[idx])
+
+			if !started(idx) {
+				errs[idx] = ctx.Err()
+				return nil
+			}
+
+			output := outputs[idx]
+			results[idx], errs[idx] = job.run(i.withOutput(output, relocator.WithWriter(output)))
+			if errs[idx] != nil {
+				mu.Lock()
+				if idx < failed {
+					failed = idx
+				}
+				mu.Unlock()
+			}
+			return errs[idx]
+		})
+	}
+
+	// errors are returned in job order by the replay below
+	_ = group.Wait()
+
+	if err := <-replayed; err != nil {
+		return nil, err
+	}
+
+	relocated := make([]runtime.Object, 0, len(results))
+	for _, obj := range results {
+		if obj != nil {
+			relocated = append(relocated, obj)
+		}
+	}
+	return relocated, nil
+}
+
+// replayRelocationJobs replays the output of each job in order as soon as it
+// is done, and returns the error of the first job that failed.
+func (i *Importer) replayRelocationJobs(outputs []*recordingPrinter, errs []error, done []chan struct{}) error {
+	for idx := range done {
+		<-done[idx]
+
+		if err := outputs[idx].replay(i.printer); err != nil {
+			return err
+		}
+
+		if errs[idx] != nil {
+			return errs[idx]
+		}
+	}
+	return nil
+}
+
+func (i *Importer) withOutput(printer Printer, relocator registry.Relocator) *Importer {
+	importer := *i
+	importer.printer = printer
+	importer.imageRelocator = relocator
+	importer.clusterLifecycleFactory = clusterlifecycle.NewFactory(printer, relocator, i.imageFetcher)
+	importer.clusterBuildpackFactory = clusterbuildpack.NewFactory(printer, relocator, i.imageFetcher)
+	importer.clusterStackFactory = clusterstack.NewFactory(printer, relocator, i.imageFetcher)
+	importer.clusterStoreFactory = clusterstore.NewFactory(printer, relocator, i.imageFetcher)
+	return &importer
+}
+
+// skippedResources returns the resources of the descriptor that were left out
+// of the relocated objects by the signature verification policy.
+func skippedResources(descriptor DependencyDescriptor, relocated []runtime.Object) []PruneCandidate {
+	relocatedSet := map[PruneCandidate]bool{}
+	for _, obj := range relocated {
+		accessor, err := meta.Accessor(obj)
+		if err != nil {
+			continue
+		}
+		kind := obj.GetObjectKind().GroupVersionKind().Kind
+		relocatedSet[PruneCandidate{Kind: kind, Name: accessor.GetName(), Namespace: accessor.GetNamespace()}] = true
+	}
+
+	var expected []PruneCandidate
+	for _, lifecycle := range GetClusterLifecycles(descriptor) {
+		expected = append(expected, PruneCandidate{Kind: v1alpha2.ClusterLifecycleKind, Name: lifecycle.Name})
+	}
+	for _, buildpack := range GetClusterBuildpacks(descriptor) {
+		expected = append(expected, PruneCandidate{Kind: v1alpha2.ClusterBuildpackKind, Name: buildpack.Name})
+	}
+	for _, buildpack := range GetBuildpacks(descriptor) {
+		expected = append(expected, PruneCandidate{Kind: v1alpha2.BuildpackKind, Name: buildpack.Name, Namespace: buildpack.Namespace})
+	}
+	for _, store := range GetClusterStores(descriptor) {
+		expected = append(expected, PruneCandidate{Kind: v1alpha2.ClusterStoreKind, Name: store.Name})
+	}
+	for _, stack := range GetClusterStacks(descriptor) {
+		expected = append(expected, PruneCandidate{Kind: v1alpha2.ClusterStackKind, Name: stack.Name})
+	}
+
+	var skipped []PruneCandidate
+	for _, resource := range expected {
+		if !relocatedSet[resource] {
+			skipped = append(skipped, resource)
+		}
+	}
+	return skipped
+}
+
+// skippedDependency returns the skipped resource a builder depends on. Id-only
+// buildpack references may resolve to any skipped buildpack the builder can
+// see, so these depend on all of them.
+func skippedDependency(spec v1alpha2.BuilderSpec, namespace string, skipped []PruneCandidate) (PruneCandidate, bool) {
+	if len(skipped) == 0 {
+		return PruneCandidate{}, false
+	}
+
+	ids := map[string][]PruneCandidate{}
+	for _, entry := range spec.Order {
+		for _, ref := range entry.Group {
+			if ref.Name == "" && ref.Image == "" && ref.Id != "" {
+				for _, resource := range skipped {
+					if resource.Kind == v1alpha2.ClusterBuildpackKind || resource.Kind == v1alpha2.BuildpackKind {
+						ids[ref.Id] = append(ids[ref.Id], resource)
+					}
+				}
+			}
+		}
+	}
+
+	for _, ref := range builderSpecReferences(spec, namespace, ids) {
+		for _, resource := range skipped {
+			if ref == resource {
+				return resource, true
+			}
+		}
+	}
+	return PruneCandidate{}, false
+}
+
+// recordingPrinter is a Printer and io.Writer that records everything written
+// to it so that it can be replayed on another Printer later.
+type recordingPrinter struct {
+	mu      sync.Mutex
+	entries []func(Printer) error
+}
+
+func (r *recordingPrinter) Printlnf(format string, args ...interface{}) error {
+	r.record(func(p Printer) error {
+		return p.Printlnf(format, args...)
+	})
+	return nil
+}
+
+func (r *recordingPrinter) PrintStatus(format string, args ...interface{}) error {
+	r.record(func(p Printer) error {
+		return p.PrintStatus(format, args...)
+	})
+	return nil
+}
+
+func (r *recordingPrinter) Writer() io.Writer {
+	return r
+}
+
+func (r *recordingPrinter) Write(b []byte) (int, error) {
+	buf := append([]byte(nil), b...)
+	r.record(func(p Printer) error {
+		_, err := p.Writer().Write(buf)
+		return err
+	})
+	return len(b), nil
+}
+
+func (r *recordingPrinter) record(entry func(Printer) error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = append(r.entries, entry)
+}
+
+func (r *recordingPrinter) replay(printer Printer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, entry := range r.entries {
+		if err := entry(printer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
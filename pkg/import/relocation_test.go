@@ -0,0 +1,163 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package _import
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/registry"
+)
+
+func TestRelocationJobs(t *testing.T) {
+	spec.Run(t, "TestRelocationJobs", testRelocationJobs)
+}
+
+func testRelocationJobs(t *testing.T, when spec.G, it spec.S) {
+	var (
+		out       *bytes.Buffer
+		relocator *concurrentRelocator
+	)
+
+	it.Before(func() {
+		out = &bytes.Buffer{}
+		relocator = &concurrentRelocator{}
+	})
+
+	newJob := func(name string, delay time.Duration) relocationJob {
+		return func(importer *Importer) (runtime.Object, error) {
+			if err := importer.printer.PrintStatus("Importing '%s'...", name); err != nil {
+				return nil, err
+			}
+
+			time.Sleep(delay)
+			if _, err := importer.imageRelocator.Relocate(authn.DefaultKeychain, nil, name); err != nil {
+				return nil, err
+			}
+
+			return &v1alpha2.ClusterStack{ObjectMeta: metav1.ObjectMeta{Name: name}}, nil
+		}
+	}
+
+	it("replays the output of concurrent jobs in order", func() {
+		importer := &Importer{printer: testLogger{writer: out}, imageRelocator: relocator, parallelism: 2}
+
+		objs, err := importer.runRelocationJobs(context.Background(), []relocationJob{
+			newJob("first", 30*time.Millisecond),
+			newJob("second", 0),
+			newJob("third", 10*time.Millisecond),
+		})
+		require.NoError(t, err)
+
+		require.Len(t, objs, 3)
+		require.Equal(t, "first", objs[0].(*v1alpha2.ClusterStack).Name)
+		require.Equal(t, "second", objs[1].(*v1alpha2.ClusterStack).Name)
+		require.Equal(t, "third", objs[2].(*v1alpha2.ClusterStack).Name)
+
+		require.Equal(t, "Importing 'first'...\tUploading 'first'\n"+
+			"Importing 'second'...\tUploading 'second'\n"+
+			"Importing 'third'...\tUploading 'third'\n", out.String())
+		require.Equal(t, 2, relocator.maxInFlight)
+	})
+
+	it("returns the first error in job order", func() {
+		importer := &Importer{printer: testLogger{writer: out}, imageRelocator: relocator, parallelism: 3}
+
+		_, err := importer.runRelocationJobs(context.Background(), []relocationJob{
+			newJob("first", 0),
+			func(*Importer) (runtime.Object, error) {
+				return nil, errors.New("some-error")
+			},
+			newJob("third", 0),
+		})
+		require.EqualError(t, err, "some-error")
+		require.Equal(t, "Importing 'first'...\tUploading 'first'\n", out.String())
+	})
+
+	it("waits for running jobs and does not start new jobs after an error", func() {
+		importer := &Importer{printer: testLogger{writer: out}, imageRelocator: relocator, parallelism: 2}
+
+		var finished, started int32
+		_, err := importer.runRelocationJobs(context.Background(), []relocationJob{
+			func(*Importer) (runtime.Object, error) {
+				time.Sleep(10 * time.Millisecond)
+				return nil, errors.New("some-error")
+			},
+			func(*Importer) (runtime.Object, error) {
+				time.Sleep(30 * time.Millisecond)
+				atomic.StoreInt32(&finished, 1)
+				return &v1alpha2.ClusterStack{}, nil
+			},
+			func(*Importer) (runtime.Object, error) {
+				atomic.StoreInt32(&started, 1)
+				return &v1alpha2.ClusterStack{}, nil
+			},
+		})
+		require.EqualError(t, err, "some-error")
+		require.Equal(t, int32(1), atomic.LoadInt32(&finished))
+		require.Equal(t, int32(0), atomic.LoadInt32(&started))
+	})
+
+	it("runs jobs one at a time when the relocator is not safe for concurrent use", func() {
+		importer := &Importer{printer: testLogger{writer: out}, imageRelocator: &fakeRelocator{}, parallelism: 3}
+
+		var running int
+		job := func(importer *Importer) (runtime.Object, error) {
+			running++
+			require.Equal(t, 1, running)
+			require.Equal(t, importer.printer, testLogger{writer: out})
+			running--
+			return &v1alpha2.ClusterStack{}, nil
+		}
+
+		objs, err := importer.runRelocationJobs(context.Background(), []relocationJob{job, job})
+		require.NoError(t, err)
+		require.Len(t, objs, 2)
+	})
+}
+
+type concurrentRelocator struct {
+	mu          sync.Mutex
+	inFlight    int
+	maxInFlight int
+	writer      io.Writer
+	parent      *concurrentRelocator
+}
+
+func (c *concurrentRelocator) Relocate(_ authn.Keychain, _ v1.Image, destination string) (string, error) {
+	c.parent.mu.Lock()
+	c.parent.inFlight++
+	if c.parent.inFlight > c.parent.maxInFlight {
+		c.parent.maxInFlight = c.parent.inFlight
+	}
+	c.parent.mu.Unlock()
+
+	time.Sleep(20 * time.Millisecond)
+
+	c.parent.mu.Lock()
+	c.parent.inFlight--
+	c.parent.mu.Unlock()
+
+	_, err := fmt.Fprintf(c.writer, "\tUploading '%s'\n", destination)
+	return destination, err
+}
+
+func (c *concurrentRelocator) WithWriter(writer io.Writer) registry.Relocator {
+	return &concurrentRelocator{writer: writer, parent: c}
+}
@@ -5,6 +5,7 @@ package k8s
 
 import (
 	"os"
+	"sort"
 
 	// load credential helpers
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
@@ -30,10 +31,41 @@ type ClientSetProvider interface {
 	GetClientSet(namespace string) (ClientSet, error)
 }
 
+// ContextClientSetProvider provides clientsets for each of the contexts of
+// the kubeconfig.
+type ContextClientSetProvider interface {
+	ClientSetProvider
+	Contexts() ([]string, error)
+	ForContext(context string) ClientSetProvider
+}
+
 type DefaultClientSetProvider struct {
+	// Context is the kubeconfig context to use, the current-context when empty.
+	Context string
+
 	clientSet ClientSet
 }
 
+// Contexts returns the sorted names of the contexts in the kubeconfig.
+func (d DefaultClientSetProvider) Contexts() ([]string, error) {
+	rawConfig, err := d.clientConfig().RawConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	contexts := make([]string, 0, len(rawConfig.Contexts))
+	for name := range rawConfig.Contexts {
+		contexts = append(contexts, name)
+	}
+	sort.Strings(contexts)
+	return contexts, nil
+}
+
+// ForContext returns a provider for clientsets of the named kubeconfig context.
+func (d DefaultClientSetProvider) ForContext(context string) ClientSetProvider {
+	return DefaultClientSetProvider{Context: context}
+}
+
 func (d DefaultClientSetProvider) GetClientSet(namespace string) (ClientSet, error) {
 	var err error
 
@@ -88,34 +120,38 @@ func (d DefaultClientSetProvider) getDynamicClient() (dynamic.Interface, error)
 	return dynamic.NewForConfig(restConfig)
 }
 
-func (d DefaultClientSetProvider) restConfig() (*rest.Config, error) {
-	clientConfig := clientcmd.NewInteractiveDeferredLoadingClientConfig(
+func (d DefaultClientSetProvider) clientConfig() clientcmd.ClientConfig {
+	return clientcmd.NewInteractiveDeferredLoadingClientConfig(
 		clientcmd.NewDefaultClientConfigLoadingRules(),
-		&clientcmd.ConfigOverrides{},
+		&clientcmd.ConfigOverrides{CurrentContext: d.Context},
 		os.Stdin,
 	)
+}
 
-	restConfig, err := clientConfig.ClientConfig()
+func (d DefaultClientSetProvider) restConfig() (*rest.Config, error) {
+	restConfig, err := d.clientConfig().ClientConfig()
 	return restConfig, err
 }
 
 func (d DefaultClientSetProvider) getDefaultNamespace() (string, error) {
-	clientConfig := clientcmd.NewInteractiveDeferredLoadingClientConfig(
-		clientcmd.NewDefaultClientConfigLoadingRules(),
-		&clientcmd.ConfigOverrides{},
-		os.Stdin,
-	)
-
-	rawConfig, err := clientConfig.RawConfig()
+	rawConfig, err := d.clientConfig().RawConfig()
 	if err != nil {
 		return "", err
 	}
 
-	if _, ok := rawConfig.Contexts[rawConfig.CurrentContext]; !ok {
+	currentContext := rawConfig.CurrentContext
+	if d.Context != "" {
+		currentContext = d.Context
+	}
+
+	if _, ok := rawConfig.Contexts[currentContext]; !ok {
+		if d.Context != "" {
+			return "", errors.Errorf("Kubernetes context %q not found", d.Context)
+		}
 		return "", errors.New("Kubernetes current context is not set")
 	}
 
-	defaultNamespace := rawConfig.Contexts[rawConfig.CurrentContext].Namespace
+	defaultNamespace := rawConfig.Contexts[currentContext].Namespace
 	if defaultNamespace == "" {
 		defaultNamespace = "default"
 	}
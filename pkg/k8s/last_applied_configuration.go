@@ -9,6 +9,7 @@ import (
 
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/jsonmergepatch"
 )
 
 type Annotatable interface {
@@ -41,3 +42,32 @@ func SetLastAppliedCfg(obj Annotatable) error {
 
 	return nil
 }
+
+// CreateThreeWayPatch returns a merge patch that updates current to modified.
+// Fields of the last applied configuration of current that are no longer set
+// in modified are removed, fields set on current by others are left as is.
+// The last applied configuration of modified must already be set.
+func CreateThreeWayPatch(current, modified Annotatable) ([]byte, error) {
+	original := []byte(current.GetAnnotations()[kubectlLastAppliedConfig])
+
+	currentBytes, err := json.Marshal(current)
+	if err != nil {
+		return nil, err
+	}
+
+	modifiedBytes, err := json.Marshal(modified)
+	if err != nil {
+		return nil, err
+	}
+
+	patch, err := jsonmergepatch.CreateThreeWayJSONMergePatch(original, modifiedBytes, currentBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	if string(patch) == "{}" {
+		return nil, nil
+	}
+
+	return patch, nil
+}
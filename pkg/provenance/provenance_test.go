@@ -0,0 +1,167 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package provenance_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	slsacommon "github.com/in-toto/in-toto-golang/in_toto/slsa_provenance/common"
+	slsav1 "github.com/in-toto/in-toto-golang/in_toto/slsa_provenance/v1"
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/sclevine/spec"
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/provenance"
+)
+
+func TestProvenance(t *testing.T) {
+	spec.Run(t, "TestProvenance", testProvenance)
+}
+
+func testProvenance(t *testing.T, when spec.G, it spec.S) {
+	const (
+		appDigest     = "sha256:1111111111111111111111111111111111111111111111111111111111111111"
+		builderDigest = "sha256:2222222222222222222222222222222222222222222222222222222222222222"
+		runDigest     = "sha256:3333333333333333333333333333333333333333333333333333333333333333"
+		revision      = "0123456789abcdef0123456789abcdef01234567"
+	)
+
+	started := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	finished := started.Add(5 * time.Minute)
+
+	bld := v1alpha2.Build{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "some-image-build-1",
+			Namespace:         "some-namespace",
+			CreationTimestamp: metav1.NewTime(started),
+			Labels:            map[string]string{v1alpha2.BuildNumberLabel: "1"},
+			Annotations:       map[string]string{v1alpha2.BuildReasonAnnotation: "COMMIT"},
+		},
+		Spec: v1alpha2.BuildSpec{
+			Builder: corev1alpha1.BuildBuilderSpec{Image: "some-registry.io/builder@" + builderDigest},
+			Source: corev1alpha1.SourceConfig{
+				Git: &corev1alpha1.Git{URL: "https://github.com/some-org/some-repo", Revision: revision},
+			},
+		},
+		Status: v1alpha2.BuildStatus{
+			Status: corev1alpha1.Status{
+				Conditions: corev1alpha1.Conditions{{
+					Type:               corev1alpha1.ConditionSucceeded,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: corev1alpha1.VolatileTime{Inner: metav1.NewTime(finished)},
+				}},
+			},
+			LatestImage:   "some-registry.io/app@" + appDigest,
+			PodName:       "some-pod",
+			Stack:         corev1alpha1.BuildStack{RunImage: "some-registry.io/run@" + runDigest},
+			BuildMetadata: corev1alpha1.BuildpackMetadataList{{Id: "some-buildpack", Version: "1.2.3"}},
+		},
+	}
+
+	when("creating a statement", func() {
+		it("records the build inputs and timestamps", func() {
+			stmt, err := provenance.NewStatement(bld, false)
+			require.NoError(t, err)
+
+			require.Equal(t, "https://slsa.dev/provenance/v1", stmt.PredicateType)
+			require.Equal(t, "some-registry.io/app", stmt.Subject[0].Name)
+			require.Equal(t, slsacommon.DigestSet{"sha256": appDigest[7:]}, stmt.Subject[0].Digest)
+
+			predicate := stmt.Predicate.(slsav1.ProvenancePredicate)
+			require.Equal(t, []slsav1.ResourceDescriptor{
+				{Name: "source", URI: "https://github.com/some-org/some-repo", Digest: slsacommon.DigestSet{"sha1": revision}},
+				{Name: "builder-image", URI: "some-registry.io/builder", Digest: slsacommon.DigestSet{"sha256": builderDigest[7:]}},
+				{Name: "run-image", URI: "some-registry.io/run", Digest: slsacommon.DigestSet{"sha256": runDigest[7:]}},
+				{Name: "buildpack:some-buildpack", Annotations: map[string]interface{}{"version": "1.2.3"}},
+			}, predicate.BuildDefinition.ResolvedDependencies)
+
+			require.Equal(t, "https://kpack.io/slsa/unsigned-build", predicate.RunDetails.Builder.ID)
+			require.Equal(t, "https://kpack.io/some-namespace/some-image-build-1/some-pod", predicate.RunDetails.BuildMetadata.InvocationID)
+			require.Equal(t, started, *predicate.RunDetails.BuildMetadata.StartedOn)
+			require.Equal(t, finished, *predicate.RunDetails.BuildMetadata.FinishedOn)
+
+			params, err := json.Marshal(predicate.BuildDefinition.ExternalParameters)
+			require.NoError(t, err)
+			require.Contains(t, string(params), `"reason":"COMMIT"`)
+		})
+
+		it("fails when the build has not produced an image", func() {
+			unfinished := bld.DeepCopy()
+			unfinished.Status.LatestImage = ""
+
+			_, err := provenance.NewStatement(*unfinished, false)
+			require.EqualError(t, err, "build \"1\" has not produced an image")
+		})
+	})
+
+	when("encoding a statement", func() {
+		it("signs the statement in a dsse envelope with a pkcs8 key", func() {
+			key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			require.NoError(t, err)
+			der, err := x509.MarshalPKCS8PrivateKey(key)
+			require.NoError(t, err)
+
+			keyPath := filepath.Join(t.TempDir(), "some.key")
+			require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600))
+
+			signer, err := provenance.LoadSigner(keyPath, nil)
+			require.NoError(t, err)
+
+			stmt, err := provenance.NewStatement(bld, true)
+			require.NoError(t, err)
+
+			data, mediaType, err := provenance.Encode(context.Background(), stmt, signer)
+			require.NoError(t, err)
+			require.Equal(t, "application/vnd.dsse.envelope.v1+json", mediaType)
+
+			var envelope dsse.Envelope
+			require.NoError(t, json.Unmarshal(data, &envelope))
+			require.Len(t, envelope.Signatures, 1)
+			require.Equal(t, "some.key", envelope.Signatures[0].KeyID)
+
+			payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+			require.NoError(t, err)
+			sig, err := base64.StdEncoding.DecodeString(envelope.Signatures[0].Sig)
+			require.NoError(t, err)
+
+			digest := sha256.Sum256(dsse.PAE(envelope.PayloadType, payload))
+			require.True(t, ecdsa.VerifyASN1(&key.PublicKey, digest[:], sig))
+			require.Contains(t, string(payload), "https://kpack.io/slsa/signed-build")
+		})
+
+		it("returns the plain statement without a signer", func() {
+			stmt, err := provenance.NewStatement(bld, false)
+			require.NoError(t, err)
+
+			data, mediaType, err := provenance.Encode(context.Background(), stmt, nil)
+			require.NoError(t, err)
+			require.Equal(t, "application/vnd.in-toto+json", mediaType)
+			require.Contains(t, string(data), `"_type": "https://in-toto.io/Statement/v0.1"`)
+		})
+
+		it("fails to load a key that is not pem encoded", func() {
+			keyPath := filepath.Join(t.TempDir(), "some.key")
+			require.NoError(t, os.WriteFile(keyPath, []byte("not-a-key"), 0600))
+
+			_, err := provenance.LoadSigner(keyPath, nil)
+			require.EqualError(t, err, "failed to decode pem key '"+keyPath+"'")
+		})
+	})
+}
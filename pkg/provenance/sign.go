@@ -0,0 +1,63 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package provenance
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+
+	intoto "github.com/in-toto/in-toto-golang/in_toto"
+	"github.com/pivotal/kpack/pkg/slsa"
+	"github.com/pkg/errors"
+)
+
+const (
+	// StatementMediaType is the media type of unsigned statements.
+	StatementMediaType = slsa.IntotoPayloadType
+	// EnvelopeMediaType is the media type of statements signed in a DSSE envelope.
+	EnvelopeMediaType = slsa.DssePayloadType
+)
+
+// LoadSigner reads a PEM encoded private key. Encrypted cosign keys are
+// decrypted with the password, other keys must be unencrypted PKCS8 RSA,
+// ECDSA or ED25519 keys. The key id is the name of the key file.
+func LoadSigner(keyPath string, password []byte) (slsa.Signer, error) {
+	key, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(key)
+	if block == nil {
+		return nil, errors.Errorf("failed to decode pem key '%s'", keyPath)
+	}
+
+	keyID := filepath.Base(keyPath)
+	if strings.HasPrefix(block.Type, "ENCRYPTED") {
+		signer, err := slsa.NewCosignSigner(key, password, keyID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to load cosign key '%s'", keyPath)
+		}
+		return signer, nil
+	}
+
+	signer, err := slsa.NewPKCS8Signer(key, keyID)
+	return signer, errors.Wrapf(err, "failed to load key '%s'", keyPath)
+}
+
+// Encode returns the statement as json, or as a DSSE envelope signed by the
+// signer when one is provided, along with the media type of the result.
+func Encode(ctx context.Context, stmt intoto.Statement, signer slsa.Signer) ([]byte, string, error) {
+	if signer == nil {
+		payload, err := json.MarshalIndent(stmt, "", "  ")
+		return payload, StatementMediaType, err
+	}
+
+	envelope, err := (&slsa.Attester{}).Sign(ctx, stmt, signer)
+	return envelope, EnvelopeMediaType, err
+}
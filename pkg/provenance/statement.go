@@ -0,0 +1,144 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package provenance
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	intoto "github.com/in-toto/in-toto-golang/in_toto"
+	slsacommon "github.com/in-toto/in-toto-golang/in_toto/slsa_provenance/common"
+	slsav1 "github.com/in-toto/in-toto-golang/in_toto/slsa_provenance/v1"
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/slsa"
+	"github.com/pkg/errors"
+)
+
+const BuildType = "https://github.com/buildpacks-community/kpack/blob/main/docs/slsa.md"
+
+type externalParameters struct {
+	Source  corev1alpha1.SourceConfig `json:"source"`
+	Builder string                    `json:"builder"`
+	Reason  string                    `json:"reason,omitempty"`
+}
+
+// NewStatement returns an in-toto statement with a SLSA v1 provenance
+// predicate for the image built by the build. Signed statements are reported
+// with the signed build builder id used by kpack.
+func NewStatement(bld v1alpha2.Build, signed bool) (intoto.Statement, error) {
+	appImage, err := name.NewDigest(bld.Status.LatestImage)
+	if err != nil {
+		return intoto.Statement{}, errors.Errorf("build \"%s\" has not produced an image", bld.Labels[v1alpha2.BuildNumberLabel])
+	}
+
+	builderID := slsa.UnsignedBuildID
+	if signed {
+		builderID = slsa.SignedBuildID
+	}
+
+	dependencies := []slsav1.ResourceDescriptor{
+		sourceDescriptor(bld.Spec.Source),
+		imageDescriptor("builder-image", bld.Spec.Builder.Image),
+	}
+	if bld.Status.Stack.RunImage != "" {
+		dependencies = append(dependencies, imageDescriptor("run-image", bld.Status.Stack.RunImage))
+	}
+	for _, bp := range bld.Status.BuildMetadata {
+		annotations := map[string]interface{}{"version": bp.Version}
+		if bp.Homepage != "" {
+			annotations["homepage"] = bp.Homepage
+		}
+		dependencies = append(dependencies, slsav1.ResourceDescriptor{
+			Name:        "buildpack:" + bp.Id,
+			Annotations: annotations,
+		})
+	}
+
+	return intoto.Statement{
+		StatementHeader: intoto.StatementHeader{
+			Type:          intoto.StatementInTotoV01,
+			PredicateType: slsav1.PredicateSLSAProvenance,
+			Subject: []intoto.Subject{
+				{
+					Name:   appImage.Context().Name(),
+					Digest: digestSet(appImage.DigestStr()),
+				},
+			},
+		},
+		Predicate: slsav1.ProvenancePredicate{
+			BuildDefinition: slsav1.ProvenanceBuildDefinition{
+				BuildType: BuildType,
+				ExternalParameters: externalParameters{
+					Source:  bld.Spec.Source,
+					Builder: bld.Spec.Builder.Image,
+					Reason:  bld.Annotations[v1alpha2.BuildReasonAnnotation],
+				},
+				ResolvedDependencies: dependencies,
+			},
+			RunDetails: slsav1.ProvenanceRunDetails{
+				Builder: slsav1.Builder{ID: string(builderID)},
+				BuildMetadata: slsav1.BuildMetadata{
+					InvocationID: fmt.Sprintf("https://kpack.io/%s/%s/%s", bld.Namespace, bld.Name, bld.Status.PodName),
+					StartedOn:    startedOn(bld),
+					FinishedOn:   finishedOn(bld),
+				},
+			},
+		},
+	}, nil
+}
+
+func sourceDescriptor(source corev1alpha1.SourceConfig) slsav1.ResourceDescriptor {
+	switch {
+	case source.Git != nil:
+		// kpack resolves git revisions to commit shas before creating builds
+		return slsav1.ResourceDescriptor{
+			Name:   "source",
+			URI:    source.Git.URL,
+			Digest: slsacommon.DigestSet{"sha1": source.Git.Revision},
+		}
+	case source.Blob != nil:
+		return slsav1.ResourceDescriptor{Name: "source", URI: source.Blob.URL}
+	case source.Registry != nil:
+		return imageDescriptor("source", source.Registry.Image)
+	default:
+		return slsav1.ResourceDescriptor{Name: "source"}
+	}
+}
+
+func imageDescriptor(descriptorName, image string) slsav1.ResourceDescriptor {
+	ref, err := name.NewDigest(image)
+	if err != nil {
+		return slsav1.ResourceDescriptor{Name: descriptorName, URI: image}
+	}
+	return slsav1.ResourceDescriptor{
+		Name:   descriptorName,
+		URI:    ref.Context().Name(),
+		Digest: digestSet(ref.DigestStr()),
+	}
+}
+
+func digestSet(digest string) slsacommon.DigestSet {
+	algorithm, hex, _ := strings.Cut(digest, ":")
+	return slsacommon.DigestSet{algorithm: hex}
+}
+
+func startedOn(bld v1alpha2.Build) *time.Time {
+	if bld.CreationTimestamp.IsZero() {
+		return nil
+	}
+	started := bld.CreationTimestamp.Time.UTC()
+	return &started
+}
+
+func finishedOn(bld v1alpha2.Build) *time.Time {
+	cond := bld.Status.GetCondition(corev1alpha1.ConditionSucceeded)
+	if bld.IsRunning() || cond == nil || cond.LastTransitionTime.Inner.IsZero() {
+		return nil
+	}
+	finished := cond.LastTransitionTime.Inner.Time.UTC()
+	return &finished
+}
@@ -0,0 +1,68 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/pkg/errors"
+)
+
+// CheckAuth authenticates to the registry with the credentials and requests
+// the registry API root, returning an error when the credentials are rejected.
+// The registry may be a key of a docker config, such as https://index.docker.io/v1/.
+func CheckAuth(ctx context.Context, tlsCfg TLSConfig, registry string, auth authn.AuthConfig) error {
+	reg, err := name.NewRegistry(registryHost(registry), name.WeakValidation)
+	if err != nil {
+		return err
+	}
+
+	base, err := tlsCfg.Transport()
+	if err != nil {
+		return err
+	}
+
+	rt, err := transport.NewWithContext(ctx, reg, authn.FromConfig(auth), base, []string{})
+	if err != nil {
+		return checkAuthError(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s://%s/v2/", reg.Scheme(), reg.RegistryStr()), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := (&http.Client{Transport: rt}).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return checkAuthError(transport.CheckError(resp, http.StatusOK))
+}
+
+func registryHost(registry string) string {
+	host := strings.TrimPrefix(strings.TrimPrefix(registry, "https://"), "http://")
+	if i := strings.Index(host, "/"); i >= 0 {
+		host = host[:i]
+	}
+	if host == "index.docker.io" || host == "docker.io" {
+		return name.DefaultRegistry
+	}
+	return host
+}
+
+func checkAuthError(err error) error {
+	var transportError *transport.Error
+	if errors.As(err, &transportError) && transportError.StatusCode == http.StatusUnauthorized {
+		return errors.New("invalid credentials")
+	}
+	return err
+}
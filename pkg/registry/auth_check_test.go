@@ -0,0 +1,43 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package registry_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/registry"
+	"github.com/buildpacks-community/kpack-cli/pkg/testhelpers"
+)
+
+func TestCheckAuth(t *testing.T) {
+	spec.Run(t, "TestCheckAuth", testCheckAuth)
+}
+
+func testCheckAuth(t *testing.T, when spec.G, it spec.S) {
+	var host string
+
+	it.Before(func() {
+		host = testhelpers.NewBasicAuthRegistry(t, "some-user", "some-password")
+	})
+
+	it("succeeds with valid credentials", func() {
+		err := registry.CheckAuth(context.Background(), registry.DefaultTLSConfig(), host, authn.AuthConfig{Username: "some-user", Password: "some-password"})
+		require.NoError(t, err)
+	})
+
+	it("accepts docker config keys with a scheme and path", func() {
+		err := registry.CheckAuth(context.Background(), registry.DefaultTLSConfig(), "http://"+host+"/v1/", authn.AuthConfig{Username: "some-user", Password: "some-password"})
+		require.NoError(t, err)
+	})
+
+	it("fails with invalid credentials", func() {
+		err := registry.CheckAuth(context.Background(), registry.DefaultTLSConfig(), host, authn.AuthConfig{Username: "some-user", Password: "wrong-password"})
+		require.EqualError(t, err, "invalid credentials")
+	})
+}
@@ -0,0 +1,93 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"sync"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+// blobCache makes sure each layer is only uploaded once per repository, even
+// when several images sharing that layer are relocated at the same time.
+type blobCache struct {
+	mu      sync.Mutex
+	uploads map[string]*blobUpload
+}
+
+type blobUpload struct {
+	once sync.Once
+	err  error
+}
+
+func newBlobCache() *blobCache {
+	return &blobCache{uploads: map[string]*blobUpload{}}
+}
+
+func (c *blobCache) writeLayers(repo name.Repository, img v1.Image, options ...remote.Option) error {
+	if indexImage, ok := img.(*IndexImage); ok {
+		return c.writeIndexLayers(repo, indexImage.Index, options...)
+	}
+
+	layers, err := img.Layers()
+	if err != nil {
+		return err
+	}
+
+	for _, layer := range layers {
+		digest, err := layer.Digest()
+		if err != nil {
+			return err
+		}
+
+		layer := layer
+		err = c.upload(repo.String()+"@"+digest.String(), func() error {
+			return remote.WriteLayer(repo, layer, options...)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *blobCache) writeIndexLayers(repo name.Repository, index v1.ImageIndex, options ...remote.Option) error {
+	manifest, err := index.IndexManifest()
+	if err != nil {
+		return err
+	}
+
+	for _, desc := range manifest.Manifests {
+		if !desc.MediaType.IsImage() {
+			continue
+		}
+
+		img, err := index.Image(desc.Digest)
+		if err != nil {
+			return err
+		}
+
+		if err := c.writeLayers(repo, img, options...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *blobCache) upload(key string, write func() error) error {
+	c.mu.Lock()
+	upload, ok := c.uploads[key]
+	if !ok {
+		upload = &blobUpload{}
+		c.uploads[key] = upload
+	}
+	c.mu.Unlock()
+
+	upload.once.Do(func() {
+		upload.err = write()
+	})
+	return upload.err
+}
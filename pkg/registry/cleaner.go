@@ -0,0 +1,78 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+// Cleaner lists the tags of a repository and removes tags and manifests from it.
+type Cleaner interface {
+	Tags(keychain authn.Keychain, repository string) (map[string]string, error)
+	Delete(keychain authn.Keychain, reference string) error
+}
+
+type DefaultCleaner struct {
+	tlsCfg TLSConfig
+}
+
+func NewDefaultCleaner(tlsCfg TLSConfig) DefaultCleaner {
+	return DefaultCleaner{tlsCfg: tlsCfg}
+}
+
+// Tags returns the digest each tag of the repository points to, by tag.
+func (d DefaultCleaner) Tags(keychain authn.Keychain, repository string) (map[string]string, error) {
+	repo, err := name.NewRepository(repository, name.WeakValidation)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err := d.options(keychain)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := remote.List(repo, opts...)
+	if err != nil {
+		return nil, newImageAccessError(repo.String(), err)
+	}
+
+	digests := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		desc, err := remote.Head(repo.Tag(tag), opts...)
+		if err != nil {
+			return nil, newImageAccessError(repo.Tag(tag).String(), err)
+		}
+		digests[tag] = desc.Digest.String()
+	}
+	return digests, nil
+}
+
+// Delete removes a tag, or a manifest with all its tags when reference is a digest.
+func (d DefaultCleaner) Delete(keychain authn.Keychain, reference string) error {
+	ref, err := name.ParseReference(reference, name.WeakValidation)
+	if err != nil {
+		return err
+	}
+
+	opts, err := d.options(keychain)
+	if err != nil {
+		return err
+	}
+
+	if err := remote.Delete(ref, opts...); err != nil {
+		return newImageAccessError(ref.String(), err)
+	}
+	return nil
+}
+
+func (d DefaultCleaner) options(keychain authn.Keychain) ([]remote.Option, error) {
+	transport, err := d.tlsCfg.Transport()
+	if err != nil {
+		return nil, err
+	}
+	return []remote.Option{remote.WithAuthFromKeychain(keychain), remote.WithTransport(transport)}, nil
+}
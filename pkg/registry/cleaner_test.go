@@ -0,0 +1,71 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package registry_test
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	ggcrregistry "github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/registry"
+)
+
+func TestCleaner(t *testing.T) {
+	spec.Run(t, "TestCleaner", testCleaner)
+}
+
+func testCleaner(t *testing.T, when spec.G, it spec.S) {
+	var (
+		repo    name.Repository
+		digest  string
+		cleaner = registry.NewDefaultCleaner(registry.DefaultTLSConfig())
+	)
+
+	it.Before(func() {
+		server := httptest.NewServer(ggcrregistry.New())
+		t.Cleanup(server.Close)
+
+		uri, err := url.Parse(server.URL)
+		require.NoError(t, err)
+
+		repo, err = name.NewRepository(uri.Host + "/some/repo")
+		require.NoError(t, err)
+
+		image, err := random.Image(10, 1)
+		require.NoError(t, err)
+		d, err := image.Digest()
+		require.NoError(t, err)
+		digest = d.String()
+
+		require.NoError(t, remote.Write(repo.Tag("20240101000000"), image))
+		require.NoError(t, remote.Write(repo.Tag("some-tag"), image))
+	})
+
+	it("lists the digest of each tag", func() {
+		tags, err := cleaner.Tags(authn.DefaultKeychain, repo.String())
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"20240101000000": digest, "some-tag": digest}, tags)
+	})
+
+	it("deletes manifests by digest", func() {
+		require.NoError(t, cleaner.Delete(authn.DefaultKeychain, repo.String()+"@"+digest))
+
+		_, err := remote.Head(repo.Digest(digest))
+		require.Error(t, err)
+	})
+
+	it("checks for relocation tags", func() {
+		require.True(t, registry.IsRelocationTag("20240101000000"))
+		require.False(t, registry.IsRelocationTag("some-tag"))
+		require.False(t, registry.IsRelocationTag("2024010100000"))
+	})
+}
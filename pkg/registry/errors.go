@@ -4,10 +4,46 @@
 package registry
 
 import (
+	"net/http"
+
 	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
 	"github.com/pkg/errors"
 )
 
+// ImageNotFoundError is returned by fetchers that do not read from a registry
+// when the image does not exist.
+type ImageNotFoundError struct {
+	Message string
+}
+
+func (e *ImageNotFoundError) Error() string {
+	return e.Message
+}
+
+// IsNotFound returns whether err is the error of fetching an image that does
+// not exist, as opposed to an authentication, network or TLS error.
+func IsNotFound(err error) bool {
+	var notFoundError *ImageNotFoundError
+	if errors.As(err, &notFoundError) {
+		return true
+	}
+
+	var transportError *transport.Error
+	if !errors.As(err, &transportError) {
+		return false
+	}
+
+	if transportError.StatusCode == http.StatusNotFound {
+		return true
+	}
+	for _, diagnostic := range transportError.Errors {
+		if diagnostic.Code == transport.ManifestUnknownErrorCode || diagnostic.Code == transport.NameUnknownErrorCode {
+			return true
+		}
+	}
+	return false
+}
+
 func newImageAccessError(ref string, err error) error {
 	if transportError, ok := err.(*transport.Error); ok {
 		if transportError.StatusCode == 401 {
@@ -0,0 +1,28 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package fakes
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+)
+
+type AttachedArtifact struct {
+	Subject      string
+	ArtifactType string
+	Payload      []byte
+}
+
+type Attacher struct {
+	Attached []AttachedArtifact
+}
+
+func (a *Attacher) Attach(_ authn.Keychain, subject string, artifactType string, payload []byte) (string, error) {
+	a.Attached = append(a.Attached, AttachedArtifact{Subject: subject, ArtifactType: artifactType, Payload: payload})
+	repo := strings.SplitN(subject, "@", 2)[0]
+	return fmt.Sprintf("%s@sha256:%x", repo, sha256.Sum256(payload)), nil
+}
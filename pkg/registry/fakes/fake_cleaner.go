@@ -0,0 +1,28 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package fakes
+
+import (
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/pkg/errors"
+)
+
+type Cleaner struct {
+	// digest of each tag, by repository and tag
+	Repositories map[string]map[string]string
+	Deleted      []string
+}
+
+func (c *Cleaner) Tags(_ authn.Keychain, repository string) (map[string]string, error) {
+	tags, ok := c.Repositories[repository]
+	if !ok {
+		return nil, errors.Errorf("repository '%s' not found", repository)
+	}
+	return tags, nil
+}
+
+func (c *Cleaner) Delete(_ authn.Keychain, reference string) error {
+	c.Deleted = append(c.Deleted, reference)
+	return nil
+}
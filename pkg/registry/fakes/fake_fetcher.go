@@ -5,10 +5,12 @@ package fakes
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/google/go-containerregistry/pkg/authn"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
-	"github.com/pkg/errors"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/registry"
 )
 
 const (
@@ -20,6 +22,7 @@ const (
 )
 
 type Fetcher struct {
+	mu        sync.Mutex
 	images    map[string]v1.Image
 	callCount int
 	err       error
@@ -67,13 +70,16 @@ func NewLifecycleImageFetcher(i ...LifecycleInfo) *Fetcher {
 }
 
 func (f *Fetcher) Fetch(_ authn.Keychain, src string) (v1.Image, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
 	f.callCount++
 	if f.err != nil {
 		return nil, f.err
 	}
 	image, ok := f.images[src]
 	if !ok {
-		return nil, errors.Errorf("image not found: %q", src)
+		return nil, &registry.ImageNotFoundError{Message: fmt.Sprintf("image not found: %q", src)}
 	}
 	return image, nil
 }
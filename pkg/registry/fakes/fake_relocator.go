@@ -11,6 +11,8 @@ import (
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/name"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/registry"
 )
 
 type Relocator struct {
@@ -56,6 +58,13 @@ func (r *Relocator) Relocate(keychain authn.Keychain, image v1.Image, dest strin
 	return refDigestStr, err
 }
 
+func (r *Relocator) WithWriter(writer io.Writer) registry.Relocator {
+	return &Relocator{
+		skip:   r.skip,
+		writer: writer,
+	}
+}
+
 func (r *Relocator) CallCount() int {
 	return len(r.calls)
 }
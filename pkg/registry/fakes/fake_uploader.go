@@ -22,7 +22,7 @@ func NewFakeSourceUploader(writer io.Writer, changeState bool) *SourceUploader {
 	}
 }
 
-func (f *SourceUploader) Upload(keychain authn.Keychain, dstImgRefStr, srcPath string) (string, error) {
+func (f *SourceUploader) Upload(keychain authn.Keychain, dstImgRefStr, srcPath string, excludes []string) (string, error) {
 	uploadPath := fmt.Sprintf("%s:source-id", dstImgRefStr)
 	var message string
 	if !f.changeState {
@@ -10,7 +10,9 @@ import (
 )
 
 type UtilProvider struct {
-	FakeFetcher registry.Fetcher
+	FakeFetcher  registry.Fetcher
+	FakeAttacher registry.Attacher
+	FakeCleaner  registry.Cleaner
 }
 
 func (u UtilProvider) Relocator(writer io.Writer, _ registry.TLSConfig, changeState bool) registry.Relocator {
@@ -27,3 +29,11 @@ func (u UtilProvider) Fetcher(_ registry.TLSConfig) registry.Fetcher {
 func (u UtilProvider) SourceUploader(writer io.Writer, tlsConfig registry.TLSConfig, changeState bool) registry.SourceUploader {
 	return NewFakeSourceUploader(writer, changeState)
 }
+
+func (u UtilProvider) Attacher(_ registry.TLSConfig) registry.Attacher {
+	return u.FakeAttacher
+}
+
+func (u UtilProvider) Cleaner(_ registry.TLSConfig) registry.Cleaner {
+	return u.FakeCleaner
+}
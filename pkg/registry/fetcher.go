@@ -46,7 +46,24 @@ func (d DefaultFetcher) Fetch(keychain authn.Keychain, src string) (v1.Image, er
 			return nil, err
 		}
 
-		img, err := remote.Image(imageRef, remote.WithAuthFromKeychain(keychain), remote.WithTransport(t))
+		desc, err := remote.Get(imageRef, remote.WithAuthFromKeychain(keychain), remote.WithTransport(t))
+		if err != nil {
+			return nil, newImageAccessError(imageRef.String(), err)
+		}
+
+		if desc.MediaType.IsIndex() {
+			index, err := desc.ImageIndex()
+			if err != nil {
+				return nil, newImageAccessError(imageRef.String(), err)
+			}
+			img, err := NewIndexImage(index)
+			if err != nil {
+				return nil, newImageAccessError(imageRef.String(), err)
+			}
+			return img, nil
+		}
+
+		img, err := desc.Image()
 		if err != nil {
 			return nil, newImageAccessError(imageRef.String(), err)
 		}
@@ -0,0 +1,161 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"github.com/google/go-containerregistry/pkg/authn"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/pkg/errors"
+)
+
+var errNoIndexImages = errors.New("image index does not contain any images")
+
+// IndexImage is a v1.Image backed by an OCI image index or docker manifest list.
+// Config and labels are read from one of the platform images in the index so
+// existing image validation keeps working, while the digest, media type and raw
+// manifest refer to the index so that relocation copies every platform.
+type IndexImage struct {
+	v1.Image
+	Index v1.ImageIndex
+}
+
+func NewIndexImage(index v1.ImageIndex) (*IndexImage, error) {
+	manifest, err := index.IndexManifest()
+	if err != nil {
+		return nil, err
+	}
+
+	var desc *v1.Descriptor
+	for i, m := range manifest.Manifests {
+		if !m.MediaType.IsImage() || isUnknownPlatform(m.Platform) {
+			continue
+		}
+		desc = &manifest.Manifests[i]
+		break
+	}
+
+	if desc == nil {
+		return nil, errNoIndexImages
+	}
+
+	img, err := index.Image(desc.Digest)
+	if err != nil {
+		return nil, err
+	}
+
+	return &IndexImage{Image: img, Index: index}, nil
+}
+
+func (i *IndexImage) Digest() (v1.Hash, error) {
+	return i.Index.Digest()
+}
+
+func (i *IndexImage) MediaType() (types.MediaType, error) {
+	return i.Index.MediaType()
+}
+
+func (i *IndexImage) RawManifest() ([]byte, error) {
+	return i.Index.RawManifest()
+}
+
+func (i *IndexImage) Size() (int64, error) {
+	return i.Index.Size()
+}
+
+type platformFilteringFetcher struct {
+	fetcher   Fetcher
+	platforms []v1.Platform
+}
+
+// NewPlatformFilteringFetcher wraps a Fetcher so that image indexes only contain
+// the given platforms (format: os/arch[/variant]). Single platform images are
+// returned unchanged. When no platforms are given the fetcher is returned as is.
+func NewPlatformFilteringFetcher(fetcher Fetcher, platforms []string) (Fetcher, error) {
+	if len(platforms) == 0 {
+		return fetcher, nil
+	}
+
+	f := platformFilteringFetcher{fetcher: fetcher}
+	for _, p := range platforms {
+		platform, err := v1.ParsePlatform(p)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid platform '%s'", p)
+		}
+		f.platforms = append(f.platforms, *platform)
+	}
+	return f, nil
+}
+
+func (f platformFilteringFetcher) Fetch(keychain authn.Keychain, src string) (v1.Image, error) {
+	img, err := f.fetcher.Fetch(keychain, src)
+	if err != nil {
+		return nil, err
+	}
+
+	indexImage, ok := img.(*IndexImage)
+	if !ok {
+		return img, nil
+	}
+
+	filtered := mutate.RemoveManifests(indexImage.Index, func(desc v1.Descriptor) bool {
+		return !f.matches(desc.Platform)
+	})
+
+	img, err = NewIndexImage(filtered)
+	if err == errNoIndexImages {
+		return nil, errors.Errorf("image '%s' does not contain any of the platforms %s", src, f.platforms)
+	} else if err != nil {
+		return nil, newImageAccessError(src, err)
+	}
+	return img, nil
+}
+
+func (f platformFilteringFetcher) matches(platform *v1.Platform) bool {
+	if platform == nil {
+		return false
+	}
+	for _, p := range f.platforms {
+		if platform.Satisfies(p) {
+			return true
+		}
+	}
+	return false
+}
+
+func isUnknownPlatform(platform *v1.Platform) bool {
+	return platform != nil && platform.OS == "unknown" && platform.Architecture == "unknown"
+}
+
+func indexSize(index v1.ImageIndex) (int64, error) {
+	size, err := index.Size()
+	if err != nil {
+		return 0, err
+	}
+
+	manifest, err := index.IndexManifest()
+	if err != nil {
+		return 0, err
+	}
+
+	for _, desc := range manifest.Manifests {
+		if !desc.MediaType.IsImage() {
+			size += desc.Size
+			continue
+		}
+
+		img, err := index.Image(desc.Digest)
+		if err != nil {
+			return 0, err
+		}
+
+		imgSize, err := imageSize(img)
+		if err != nil {
+			return 0, err
+		}
+		size += imgSize
+	}
+	return size, nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"fmt"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+)
+
+const refNameAnnotation = "org.opencontainers.image.ref.name"
+
+// LayoutFetcher fetches images from an OCI image layout on disk. Images are
+// looked up by the reference they were written with in WriteLayoutImage.
+type LayoutFetcher struct {
+	path layout.Path
+}
+
+func NewLayoutFetcher(dir string) (*LayoutFetcher, error) {
+	path, err := layout.FromPath(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &LayoutFetcher{path: path}, nil
+}
+
+func (l *LayoutFetcher) Fetch(_ authn.Keychain, src string) (v1.Image, error) {
+	index, err := l.path.ImageIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, err := index.IndexManifest()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, desc := range manifest.Manifests {
+		if desc.Annotations[refNameAnnotation] != src {
+			continue
+		}
+
+		if desc.MediaType.IsIndex() {
+			childIndex, err := index.ImageIndex(desc.Digest)
+			if err != nil {
+				return nil, err
+			}
+			return NewIndexImage(childIndex)
+		}
+		return index.Image(desc.Digest)
+	}
+
+	return nil, &ImageNotFoundError{Message: fmt.Sprintf("image '%s' not found in bundle", src)}
+}
+
+// NewLayout creates an empty OCI image layout in dir.
+func NewLayout(dir string) (layout.Path, error) {
+	return layout.Write(dir, empty.Index)
+}
+
+// WriteLayoutImage adds an image, or every platform of an IndexImage, to the
+// layout under the given reference.
+func WriteLayoutImage(path layout.Path, ref string, img v1.Image) error {
+	option := layout.WithAnnotations(map[string]string{refNameAnnotation: ref})
+
+	if indexImage, ok := img.(*IndexImage); ok {
+		return path.AppendIndex(indexImage.Index, option)
+	}
+	return path.AppendImage(img, option)
+}
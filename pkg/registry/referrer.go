@@ -0,0 +1,79 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"fmt"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+)
+
+// Attacher pushes artifacts that refer to an existing image, so they are
+// listed by the OCI referrers api of the registry.
+type Attacher interface {
+	Attach(keychain authn.Keychain, subject string, artifactType string, payload []byte) (string, error)
+}
+
+type DefaultAttacher struct {
+	tlsCfg TLSConfig
+}
+
+func NewDefaultAttacher(tlsCfg TLSConfig) DefaultAttacher {
+	return DefaultAttacher{tlsCfg: tlsCfg}
+}
+
+// Attach pushes the payload as a single layer artifact with the subject image
+// as its subject, to the repository of the subject. It returns the digest
+// reference of the artifact.
+func (d DefaultAttacher) Attach(keychain authn.Keychain, subject string, artifactType string, payload []byte) (string, error) {
+	subjectRef, err := name.ParseReference(subject, name.WeakValidation)
+	if err != nil {
+		return "", err
+	}
+
+	t, err := d.tlsCfg.Transport()
+	if err != nil {
+		return "", err
+	}
+
+	opts := []remote.Option{remote.WithAuthFromKeychain(keychain), remote.WithTransport(t)}
+
+	desc, err := remote.Head(subjectRef, opts...)
+	if err != nil {
+		return "", newImageAccessError(subjectRef.String(), err)
+	}
+
+	artifact := mutate.MediaType(empty.Image, types.OCIManifestSchema1)
+	artifact = mutate.ConfigMediaType(artifact, types.MediaType(artifactType))
+	artifact, err = mutate.Append(artifact, mutate.Addendum{
+		Layer: static.NewLayer(payload, types.MediaType(artifactType)),
+	})
+	if err != nil {
+		return "", err
+	}
+	artifact = mutate.Subject(artifact, v1.Descriptor{
+		MediaType: desc.MediaType,
+		Size:      desc.Size,
+		Digest:    desc.Digest,
+	}).(v1.Image)
+
+	digest, err := artifact.Digest()
+	if err != nil {
+		return "", err
+	}
+
+	artifactRef := subjectRef.Context().Digest(digest.String())
+	if err := remote.Write(artifactRef, artifact, opts...); err != nil {
+		return "", newImageAccessError(artifactRef.String(), err)
+	}
+
+	return fmt.Sprintf("%s@%s", subjectRef.Context().Name(), digest), nil
+}
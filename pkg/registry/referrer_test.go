@@ -0,0 +1,68 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package registry_test
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	ggcrregistry "github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/registry"
+)
+
+func TestAttacher(t *testing.T) {
+	spec.Run(t, "TestAttacher", testAttacher)
+}
+
+func testAttacher(t *testing.T, when spec.G, it spec.S) {
+	var subject name.Digest
+
+	it.Before(func() {
+		server := httptest.NewServer(ggcrregistry.New(ggcrregistry.WithReferrersSupport(true)))
+		t.Cleanup(server.Close)
+
+		uri, err := url.Parse(server.URL)
+		require.NoError(t, err)
+
+		image, err := random.Image(10, 1)
+		require.NoError(t, err)
+		digest, err := image.Digest()
+		require.NoError(t, err)
+
+		subject, err = name.NewDigest(uri.Host + "/some/app@" + digest.String())
+		require.NoError(t, err)
+		require.NoError(t, remote.Write(subject, image))
+	})
+
+	it("pushes the payload as an artifact referring to the subject", func() {
+		attacher := registry.NewDefaultAttacher(registry.DefaultTLSConfig())
+
+		ref, err := attacher.Attach(authn.DefaultKeychain, subject.String(), "application/vnd.in-toto+json", []byte(`{"some":"statement"}`))
+		require.NoError(t, err)
+
+		index, err := remote.Referrers(subject)
+		require.NoError(t, err)
+		manifest, err := index.IndexManifest()
+		require.NoError(t, err)
+		require.Len(t, manifest.Manifests, 1)
+		require.Equal(t, "application/vnd.in-toto+json", manifest.Manifests[0].ArtifactType)
+		require.Equal(t, subject.Context().Name()+"@"+manifest.Manifests[0].Digest.String(), ref)
+
+		artifactRef, err := name.NewDigest(ref)
+		require.NoError(t, err)
+		artifact, err := remote.Image(artifactRef)
+		require.NoError(t, err)
+		layers, err := artifact.Layers()
+		require.NoError(t, err)
+		require.Len(t, layers, 1)
+	})
+}
@@ -6,6 +6,7 @@ package registry
 import (
 	"fmt"
 	"io"
+	"regexp"
 	"time"
 
 	"github.com/google/go-containerregistry/pkg/authn"
@@ -18,6 +19,15 @@ type Relocator interface {
 	Relocate(keychain authn.Keychain, src v1.Image, destination string) (string, error)
 }
 
+// ConcurrentRelocator is a Relocator that is safe to use from several
+// goroutines. WithWriter returns a Relocator sharing the same state that
+// reports progress to a different writer, so that the output of concurrent
+// relocations can be kept apart.
+type ConcurrentRelocator interface {
+	Relocator
+	WithWriter(writer io.Writer) Relocator
+}
+
 type DiscardRelocator struct {
 	writer io.Writer
 }
@@ -36,13 +46,24 @@ func (d DiscardRelocator) Relocate(keychain authn.Keychain, src v1.Image, destin
 	return cfg.refDigestStr, err
 }
 
+func (d DiscardRelocator) WithWriter(writer io.Writer) Relocator {
+	d.writer = writer
+	return d
+}
+
 type DefaultRelocator struct {
 	tlsCfg TLSConfig
 	writer io.Writer
+	blobs  *blobCache
 }
 
 func NewDefaultRelocator(writer io.Writer, tlsCfg TLSConfig) DefaultRelocator {
-	return DefaultRelocator{writer: writer, tlsCfg: tlsCfg}
+	return DefaultRelocator{writer: writer, tlsCfg: tlsCfg, blobs: newBlobCache()}
+}
+
+func (d DefaultRelocator) WithWriter(writer io.Writer) Relocator {
+	d.writer = writer
+	return d
 }
 
 func (d DefaultRelocator) Relocate(keychain authn.Keychain, src v1.Image, destination string) (string, error) {
@@ -68,6 +89,22 @@ func (d DefaultRelocator) Relocate(keychain authn.Keychain, src v1.Image, destin
 		remote.WithTransport(transport),
 	}
 
+	if d.blobs != nil {
+		err = d.blobs.writeLayers(cfg.refRepo.Context(), src, imgWriteOptions...)
+		if err != nil {
+			return cfg.refDigestStr, newImageAccessError(cfg.refRepo.Context().RegistryStr(), err)
+		}
+	}
+
+	if indexImage, ok := src.(*IndexImage); ok {
+		err = remote.WriteIndex(cfg.refRepo, indexImage.Index, imgWriteOptions...)
+		if err != nil {
+			return cfg.refDigestStr, newImageAccessError(cfg.refRepo.Context().RegistryStr(), err)
+		}
+
+		return cfg.refDigestStr, remote.Tag(cfg.tag, indexImage.Index, imgWriteOptions...)
+	}
+
 	err = remote.Write(cfg.refRepo, src, imgWriteOptions...)
 	if err != nil {
 		return cfg.refDigestStr, newImageAccessError(cfg.refRepo.Context().RegistryStr(), err)
@@ -104,7 +141,12 @@ func getDstImageInfo(srcImage v1.Image, dstRepoStr string) (relocateImageInfo, e
 		return imgInfo, err
 	}
 
-	size, err := imageSize(srcImage)
+	var size int64
+	if indexImage, ok := srcImage.(*IndexImage); ok {
+		size, err = indexSize(indexImage.Index)
+	} else {
+		size, err = imageSize(srcImage)
+	}
 	if err != nil {
 		return imgInfo, err
 	}
@@ -122,3 +164,11 @@ func timestampTag() string {
 	now := time.Now()
 	return fmt.Sprintf("%s%02d%02d%02d", now.Format("20060102"), now.Hour(), now.Minute(), now.Second())
 }
+
+var timestampTagPattern = regexp.MustCompile(`^\d{14}$`)
+
+// IsRelocationTag reports whether tag is a timestamp tag like the ones
+// DefaultRelocator tags every upload with.
+func IsRelocationTag(tag string) bool {
+	return timestampTagPattern.MatchString(tag)
+}
@@ -12,9 +12,17 @@ import (
 	"net/url"
 	"regexp"
 	"strings"
+	"sync"
 	"testing"
 
+	"github.com/google/go-containerregistry/pkg/name"
+	ggcrregistry "github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
 	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/types"
 	"github.com/pivotal/kpack/pkg/registry/registryfakes"
 	"github.com/sclevine/spec"
 	"github.com/stretchr/testify/assert"
@@ -41,6 +49,68 @@ func testRelocateStackImages(t *testing.T, when spec.G, it spec.S) {
 				require.NoError(t, err)
 				assert.NotNil(t, image)
 			})
+
+			when("the image is an index", func() {
+				var (
+					registryHost string
+					index        v1.ImageIndex
+				)
+
+				it.Before(func() {
+					server := httptest.NewServer(ggcrregistry.New())
+					t.Cleanup(server.Close)
+
+					uri, err := url.Parse(server.URL)
+					require.NoError(t, err)
+					registryHost = uri.Host
+
+					index = multiPlatformIndex(t, "linux/amd64", "linux/arm64")
+					ref, err := name.ParseReference(registryHost + "/some/index")
+					require.NoError(t, err)
+					require.NoError(t, remote.WriteIndex(ref, index))
+				})
+
+				it("fetches the whole index", func() {
+					fetcher := registry.NewDefaultFetcher(registry.DefaultTLSConfig())
+
+					image, err := fetcher.Fetch(fakeKeychain, registryHost+"/some/index")
+					require.NoError(t, err)
+
+					indexImage, ok := image.(*registry.IndexImage)
+					require.True(t, ok)
+
+					expectedDigest, err := index.Digest()
+					require.NoError(t, err)
+					digest, err := image.Digest()
+					require.NoError(t, err)
+					require.Equal(t, expectedDigest, digest)
+
+					manifest, err := indexImage.Index.IndexManifest()
+					require.NoError(t, err)
+					require.Len(t, manifest.Manifests, 2)
+				})
+
+				it("filters the index to the requested platforms", func() {
+					fetcher, err := registry.NewPlatformFilteringFetcher(registry.NewDefaultFetcher(registry.DefaultTLSConfig()), []string{"linux/arm64"})
+					require.NoError(t, err)
+
+					image, err := fetcher.Fetch(fakeKeychain, registryHost+"/some/index")
+					require.NoError(t, err)
+
+					manifest, err := image.(*registry.IndexImage).Index.IndexManifest()
+					require.NoError(t, err)
+					require.Len(t, manifest.Manifests, 1)
+					require.Equal(t, "arm64", manifest.Manifests[0].Platform.Architecture)
+				})
+
+				it("errors when none of the requested platforms are in the index", func() {
+					fetcher, err := registry.NewPlatformFilteringFetcher(registry.NewDefaultFetcher(registry.DefaultTLSConfig()), []string{"windows/amd64"})
+					require.NoError(t, err)
+
+					_, err = fetcher.Fetch(fakeKeychain, registryHost+"/some/index")
+					require.EqualError(t, err, fmt.Sprintf("image '%s/some/index' does not contain any of the platforms [windows/amd64]", registryHost))
+				})
+			})
 		})
 	})
 
@@ -88,7 +158,87 @@ func testRelocateStackImages(t *testing.T, when spec.G, it spec.S) {
 			require.Equal(t, srcImageDigest.Hex, relocatedHex)
 			require.Equal(t, 1, additionalTags)
 
-			require.Equal(t, output.String(), fmt.Sprintf("\tUploading '%s'", relocatedRef))
+			require.Equal(t, output.String(), fmt.Sprintf("\tUploading '%s'\n", relocatedRef))
+		})
+
+		it("should relocate every platform of an index", func() {
+			server := httptest.NewServer(ggcrregistry.New())
+			defer server.Close()
+
+			uri, err := url.Parse(server.URL)
+			require.NoError(t, err)
+
+			index := multiPlatformIndex(t, "linux/amd64", "linux/arm64")
+			srcImage, err := registry.NewIndexImage(index)
+			require.NoError(t, err)
+
+			relocator := registry.NewDefaultRelocator(ioutil.Discard, registry.DefaultTLSConfig())
+			relocatedRef, err := relocator.Relocate(fakeKeychain, srcImage, uri.Host+"/dest-repo/an-index")
+			require.NoError(t, err)
+
+			indexDigest, err := index.Digest()
+			require.NoError(t, err)
+			require.Equal(t, fmt.Sprintf("%s/dest-repo/an-index@%s", uri.Host, indexDigest), relocatedRef)
+
+			ref, err := name.ParseReference(relocatedRef)
+			require.NoError(t, err)
+			relocatedIndex, err := remote.Index(ref)
+			require.NoError(t, err)
+
+			manifest, err := relocatedIndex.IndexManifest()
+			require.NoError(t, err)
+			require.Len(t, manifest.Manifests, 2)
+			for _, desc := range manifest.Manifests {
+				_, err := remote.Image(ref.Context().Digest(desc.Digest.String()))
+				require.NoError(t, err)
+			}
+		})
+
+		it("should only upload layers shared between images once", func() {
+			var (
+				mu      sync.Mutex
+				uploads = map[string]int{}
+			)
+			handler := ggcrregistry.New()
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPut && strings.Contains(r.URL.Path, "/blobs/uploads/") {
+					mu.Lock()
+					uploads[r.URL.Query().Get("digest")]++
+					mu.Unlock()
+				}
+				handler.ServeHTTP(w, r)
+			}))
+			defer server.Close()
+
+			uri, err := url.Parse(server.URL)
+			require.NoError(t, err)
+
+			base, err := random.Image(int64(100), int64(1))
+			require.NoError(t, err)
+			baseLayers, err := base.Layers()
+			require.NoError(t, err)
+			baseDigest, err := baseLayers[0].Digest()
+			require.NoError(t, err)
+
+			relocator := registry.NewDefaultRelocator(ioutil.Discard, registry.DefaultTLSConfig())
+
+			var wg sync.WaitGroup
+			for i := 0; i < 3; i++ {
+				layer, err := random.Layer(int64(100), types.DockerLayer)
+				require.NoError(t, err)
+				img, err := mutate.AppendLayers(base, layer)
+				require.NoError(t, err)
+
+				wg.Add(1)
+				go func(img v1.Image) {
+					defer wg.Done()
+					_, err := relocator.WithWriter(ioutil.Discard).Relocate(fakeKeychain, img, uri.Host+"/dest-repo/shared")
+					assert.NoError(t, err)
+				}(img)
+			}
+			wg.Wait()
+
+			require.Equal(t, 1, uploads[baseDigest.String()])
 		})
 
 		it("should error on invalid destination", func() {
@@ -101,3 +251,22 @@ func testRelocateStackImages(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 }
+
+func multiPlatformIndex(t *testing.T, platforms ...string) v1.ImageIndex {
+	var index v1.ImageIndex = empty.Index
+	for _, p := range platforms {
+		img, err := random.Image(int64(100), int64(2))
+		require.NoError(t, err)
+
+		platform, err := v1.ParsePlatform(p)
+		require.NoError(t, err)
+
+		index = mutate.AppendManifests(index, mutate.IndexAddendum{
+			Add: img,
+			Descriptor: v1.Descriptor{
+				Platform: platform,
+			},
+		})
+	}
+	return index
+}
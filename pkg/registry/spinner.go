@@ -26,17 +26,23 @@ type uploadSpinner struct {
 }
 
 func newUploadSpinner(writer io.Writer, size int64) *uploadSpinner {
-	isTerminal := terminal.IsTerminal(int(os.Stdout.Fd())) || terminal.IsTerminal(int(os.Stderr.Fd()))
 	sp := &uploadSpinner{
 		size:     readableSize(size),
 		stopChan: make(chan struct{}),
 		doneChan: make(chan struct{}),
 		Output:   writer,
-		NotTty:   !isTerminal,
+		NotTty:   !isTerminal(writer),
 	}
 	return sp
 }
 
+// isTerminal only reports true for terminals so that the spinner is never
+// written into buffered output, such as that of concurrent relocations.
+func isTerminal(writer io.Writer) bool {
+	file, ok := writer.(*os.File)
+	return ok && terminal.IsTerminal(int(file.Fd()))
+}
+
 func (s *uploadSpinner) Stop() {
 	close(s.stopChan)
 	<-s.doneChan
@@ -46,6 +52,7 @@ func (s *uploadSpinner) Write() {
 	defer close(s.doneChan)
 
 	if s.NotTty {
+		fmt.Fprint(s.Output, "\n")
 		return
 	}
 
@@ -4,6 +4,8 @@
 package registry
 
 import (
+	"fmt"
+	"io"
 	"os"
 
 	"github.com/google/go-containerregistry/pkg/authn"
@@ -16,50 +18,82 @@ import (
 )
 
 type SourceUploader interface {
-	Upload(keychain authn.Keychain, dstImgRefStr, srcPath string) (string, error)
+	Upload(keychain authn.Keychain, dstImgRefStr, srcPath string, excludes []string) (string, error)
 }
 
 type DefaultSourceUploader struct {
 	Relocator Relocator
+	// FileWriter lists the files added to the source image when set, so dry runs
+	// show what would be uploaded.
+	FileWriter io.Writer
 }
 
-func (d DefaultSourceUploader) Upload(keychain authn.Keychain, dstImgRefStr, srcPath string) (string, error) {
-	srcTarPath, err := readPathToTar(srcPath)
+// Upload pushes the source directory or zip at srcPath as an image. Files of a
+// directory matching its .kpignore or .gitignore files or the excludes patterns
+// are left out. Directories are split into reproducible layers, so the registry
+// already has the blobs of the layers whose files did not change.
+func (d DefaultSourceUploader) Upload(keychain authn.Keychain, dstImgRefStr, srcPath string, excludes []string) (string, error) {
+	srcTarPaths, err := d.readPathToTars(srcPath, excludes)
+	for _, srcTarPath := range srcTarPaths {
+		defer os.Remove(srcTarPath)
+	}
 	if err != nil {
 		return "", err
 	}
 
-	defer os.Remove(srcTarPath)
-
 	image, err := random.Image(0, 0)
 	if err != nil {
 		return "", err
 	}
 
-	layer, err := tarball.LayerFromFile(srcTarPath)
-	if err != nil {
-		return "", err
-	}
+	for _, srcTarPath := range srcTarPaths {
+		layer, err := tarball.LayerFromFile(srcTarPath)
+		if err != nil {
+			return "", err
+		}
 
-	image, err = mutate.AppendLayers(image, layer)
-	if err != nil {
-		return "", err
+		image, err = mutate.AppendLayers(image, layer)
+		if err != nil {
+			return "", err
+		}
 	}
 
 	return d.Relocator.Relocate(keychain, image, dstImgRefStr)
 }
 
-func readPathToTar(path string) (string, error) {
+func (d DefaultSourceUploader) readPathToTars(path string, excludes []string) ([]string, error) {
 	fi, err := os.Stat(path)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	if !fi.IsDir() && archive.IsZip(path) {
-		return archive.ZipToTar(path)
+		tarPath, err := archive.ZipToTar(path)
+		if err != nil {
+			return nil, err
+		}
+		return []string{tarPath}, nil
 	} else if !fi.IsDir() {
-		return "", errors.New("local path must be a directory or zip")
+		return nil, errors.New("local path must be a directory or zip")
+	}
+
+	ignorer, err := archive.NewIgnorer(path, excludes)
+	if err != nil {
+		return nil, err
+	}
+
+	if d.FileWriter != nil {
+		files, err := archive.ListFiles(path, ignorer)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, file := range files {
+			if _, err := fmt.Fprintf(d.FileWriter, "\tIncluding '%s'\n", file); err != nil {
+				return nil, err
+			}
+		}
 	}
 
-	return archive.CreateTar(path)
+	return archive.CreateLayerTars(path, ignorer)
 }
@@ -4,6 +4,9 @@
 package registry_test
 
 import (
+	"bytes"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/pivotal/kpack/pkg/registry/registryfakes"
@@ -33,7 +36,7 @@ func testUploader(t *testing.T, when spec.G, it spec.S) {
 		)
 
 		it("relocates local contents to registry", func() {
-			_, err := uploader.Upload(&registryfakes.FakeKeychain{}, "myregistry.com/blah", "testdata/sample")
+			_, err := uploader.Upload(&registryfakes.FakeKeychain{}, "myregistry.com/blah", "testdata/sample", nil)
 			require.NoError(t, err)
 
 			require.Equal(t, 1, fakeRelocator.CallCount())
@@ -45,7 +48,7 @@ func testUploader(t *testing.T, when spec.G, it spec.S) {
 		})
 
 		it("relocates local zip to registry", func() {
-			_, err := uploader.Upload(&registryfakes.FakeKeychain{}, "myregistry.com/blah", "testdata/sample.zip")
+			_, err := uploader.Upload(&registryfakes.FakeKeychain{}, "myregistry.com/blah", "testdata/sample.zip", nil)
 			require.NoError(t, err)
 
 			require.Equal(t, 1, fakeRelocator.CallCount())
@@ -56,8 +59,40 @@ func testUploader(t *testing.T, when spec.G, it spec.S) {
 			require.Equal(t, testZipDigest, digest.String())
 		})
 
+		it("lists the uploaded files that are not excluded", func() {
+			dir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "app"), []byte("app"), 0644))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "secret.env"), []byte("secret"), 0644))
+
+			out := &bytes.Buffer{}
+			uploader.FileWriter = out
+
+			_, err := uploader.Upload(&registryfakes.FakeKeychain{}, "myregistry.com/blah", dir, []string{"*.env"})
+			require.NoError(t, err)
+			require.Equal(t, "\tIncluding 'app'\n", out.String())
+
+			_, image, _ := fakeRelocator.RelocateCall(0)
+			layers, err := image.Layers()
+			require.NoError(t, err)
+			require.Len(t, layers, 1)
+		})
+
+		it("uploads dependency files in a separate layer", func() {
+			dir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "go.sum"), []byte("sums"), 0644))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main"), 0644))
+
+			_, err := uploader.Upload(&registryfakes.FakeKeychain{}, "myregistry.com/blah", dir, nil)
+			require.NoError(t, err)
+
+			_, image, _ := fakeRelocator.RelocateCall(0)
+			layers, err := image.Layers()
+			require.NoError(t, err)
+			require.Len(t, layers, 2)
+		})
+
 		it("returns err on path to invalid zip", func() {
-			_, err := uploader.Upload(&registryfakes.FakeKeychain{}, "myregistry.com/blah", "testdata/sample/app")
+			_, err := uploader.Upload(&registryfakes.FakeKeychain{}, "myregistry.com/blah", "testdata/sample/app", nil)
 			require.EqualError(t, err, "local path must be a directory or zip")
 
 			require.Equal(t, 0, fakeRelocator.CallCount())
@@ -9,6 +9,8 @@ type UtilProvider interface {
 	Relocator(writer io.Writer, tlsCfg TLSConfig, changeState bool) Relocator
 	SourceUploader(writer io.Writer, tlsCfg TLSConfig, changeState bool) SourceUploader
 	Fetcher(config TLSConfig) Fetcher
+	Attacher(config TLSConfig) Attacher
+	Cleaner(config TLSConfig) Cleaner
 }
 
 type DefaultUtilProvider struct{}
@@ -22,9 +24,21 @@ func (d DefaultUtilProvider) Relocator(writer io.Writer, tlsCfg TLSConfig, chang
 }
 
 func (d DefaultUtilProvider) SourceUploader(writer io.Writer, tlsCfg TLSConfig, changeState bool) SourceUploader {
-	return &DefaultSourceUploader{Relocator: d.Relocator(writer, tlsCfg, changeState)}
+	uploader := &DefaultSourceUploader{Relocator: d.Relocator(writer, tlsCfg, changeState)}
+	if !changeState {
+		uploader.FileWriter = writer
+	}
+	return uploader
 }
 
 func (d DefaultUtilProvider) Fetcher(config TLSConfig) Fetcher {
 	return NewDefaultFetcher(config)
 }
+
+func (d DefaultUtilProvider) Attacher(config TLSConfig) Attacher {
+	return NewDefaultAttacher(config)
+}
+
+func (d DefaultUtilProvider) Cleaner(config TLSConfig) Cleaner {
+	return NewDefaultCleaner(config)
+}
@@ -0,0 +1,225 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"bytes"
+	"crypto"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/pkg/errors"
+	"github.com/sigstore/sigstore/pkg/signature"
+)
+
+const (
+	// VerificationPolicyEnforce fails when an image does not have a valid signature.
+	VerificationPolicyEnforce = "enforce"
+	// VerificationPolicySkip leaves out images that do not have a valid signature.
+	VerificationPolicySkip = "skip"
+
+	cosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+	cosignSignatureTagSuffix  = ".sig"
+)
+
+// VerificationConfig configures the signature verification of fetched images.
+// Verification is disabled when no public key is set.
+type VerificationConfig struct {
+	PublicKeyPath string `yaml:"publicKey,omitempty" json:"publicKey,omitempty"`
+	Policy        string `yaml:"policy,omitempty" json:"policy,omitempty"`
+}
+
+func (c VerificationConfig) Enabled() bool {
+	return c.PublicKeyPath != ""
+}
+
+func (c VerificationConfig) Validate() error {
+	switch c.Policy {
+	case "", VerificationPolicyEnforce, VerificationPolicySkip:
+	default:
+		return errors.Errorf("invalid verification policy '%s', must be one of %s, %s", c.Policy, VerificationPolicyEnforce, VerificationPolicySkip)
+	}
+
+	if c.Policy != "" && !c.Enabled() {
+		return errors.New("verification policy requires a public key")
+	}
+	return nil
+}
+
+// VerificationError is returned for images that do not have a valid signature.
+// Skipped is set when the policy allows the image to be left out instead of
+// failing the whole operation.
+type VerificationError struct {
+	Image   string
+	Skipped bool
+	Err     error
+}
+
+func (e *VerificationError) Error() string {
+	return fmt.Sprintf("image '%s' failed signature verification: %s", e.Image, e.Err)
+}
+
+// IsVerificationSkipped reports whether err is caused by an image that the
+// verification policy allows to be skipped.
+func IsVerificationSkipped(err error) bool {
+	var verificationErr *VerificationError
+	return errors.As(err, &verificationErr) && verificationErr.Skipped
+}
+
+type verifyingFetcher struct {
+	fetcher  Fetcher
+	verifier signature.Verifier
+	skip     bool
+}
+
+// NewVerifyingFetcher wraps a Fetcher so that every fetched image is checked
+// for a cosign signature made with the private key matching the configured
+// public key. Signatures are looked up with the same fetcher, from the
+// sha256-<digest>.sig tag next to the image. When verification is not enabled
+// the fetcher is returned as is.
+func NewVerifyingFetcher(fetcher Fetcher, cfg VerificationConfig) (Fetcher, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	if !cfg.Enabled() {
+		return fetcher, nil
+	}
+
+	verifier, err := signature.LoadVerifierFromPEMFile(cfg.PublicKeyPath, crypto.SHA256)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load public key '%s'", cfg.PublicKeyPath)
+	}
+
+	return verifyingFetcher{
+		fetcher:  fetcher,
+		verifier: verifier,
+		skip:     cfg.Policy == VerificationPolicySkip,
+	}, nil
+}
+
+// NewSourceFetcher wraps a Fetcher with the signature verification and the
+// platform filtering of the images copied by a command. Filtering changes the
+// digest of an image index, so the copied index would no longer be the one
+// that was signed, and the two cannot be combined.
+func NewSourceFetcher(fetcher Fetcher, platforms []string, cfg VerificationConfig) (Fetcher, error) {
+	if cfg.Enabled() && len(platforms) > 0 {
+		return nil, errors.New("platforms cannot be filtered when verifying signatures, the filtered images would not match the signed digests")
+	}
+
+	fetcher, err := NewVerifyingFetcher(fetcher, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPlatformFilteringFetcher(fetcher, platforms)
+}
+
+func (f verifyingFetcher) Fetch(keychain authn.Keychain, src string) (v1.Image, error) {
+	img, err := f.fetcher.Fetch(keychain, src)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := f.verify(keychain, src, img); err != nil {
+		return nil, &VerificationError{Image: src, Skipped: f.skip, Err: err}
+	}
+	return img, nil
+}
+
+func (f verifyingFetcher) verify(keychain authn.Keychain, src string, img v1.Image) error {
+	sigTag, err := SignatureTag(src, img)
+	if err != nil {
+		return err
+	}
+
+	digest, err := img.Digest()
+	if err != nil {
+		return err
+	}
+
+	sigImg, err := f.fetcher.Fetch(keychain, sigTag)
+	if IsNotFound(err) {
+		return errors.Errorf("no signature found at '%s'", sigTag)
+	} else if err != nil {
+		return errors.Wrapf(err, "fetching signature '%s'", sigTag)
+	}
+
+	manifest, err := sigImg.Manifest()
+	if err != nil {
+		return err
+	}
+
+	for _, desc := range manifest.Layers {
+		if f.verifySignature(sigImg, desc, digest) == nil {
+			return nil
+		}
+	}
+	return errors.New("no signature matches the public key")
+}
+
+// SignatureTag returns the tag of the cosign signature of img, which is
+// stored next to the image at src.
+func SignatureTag(src string, img v1.Image) (string, error) {
+	ref, err := name.ParseReference(src, name.WeakValidation)
+	if err != nil {
+		return "", errors.New("only registry images can be verified")
+	}
+
+	digest, err := img.Digest()
+	if err != nil {
+		return "", err
+	}
+
+	return ref.Context().Tag(strings.Replace(digest.String(), ":", "-", 1) + cosignSignatureTagSuffix).String(), nil
+}
+
+func (f verifyingFetcher) verifySignature(sigImg v1.Image, desc v1.Descriptor, digest v1.Hash) error {
+	sig, err := base64.StdEncoding.DecodeString(desc.Annotations[cosignSignatureAnnotation])
+	if err != nil {
+		return err
+	}
+
+	layer, err := sigImg.LayerByDigest(desc.Digest)
+	if err != nil {
+		return err
+	}
+
+	rc, err := layer.Compressed()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	payload, err := io.ReadAll(rc)
+	if err != nil {
+		return err
+	}
+
+	if err := f.verifier.VerifySignature(bytes.NewReader(sig), bytes.NewReader(payload)); err != nil {
+		return err
+	}
+
+	var simpleSigning struct {
+		Critical struct {
+			Image struct {
+				DockerManifestDigest string `json:"docker-manifest-digest"`
+			} `json:"image"`
+		} `json:"critical"`
+	}
+	if err := json.Unmarshal(payload, &simpleSigning); err != nil {
+		return err
+	}
+
+	if simpleSigning.Critical.Image.DockerManifestDigest != digest.String() {
+		return errors.Errorf("signature is for '%s'", simpleSigning.Critical.Image.DockerManifestDigest)
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package registry_test
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+
+	"github.com/buildpacks-community/kpack-cli/pkg/registry"
+	registryfakes "github.com/buildpacks-community/kpack-cli/pkg/registry/fakes"
+	"github.com/buildpacks-community/kpack-cli/pkg/testhelpers"
+)
+
+func TestVerifyingFetcher(t *testing.T) {
+	spec.Run(t, "TestVerifyingFetcher", testVerifyingFetcher)
+}
+
+func testVerifyingFetcher(t *testing.T, when spec.G, it spec.S) {
+	const src = "some-registry.io/some-repo:some-tag"
+
+	var (
+		fetcher   *registryfakes.Fetcher
+		key       *ecdsa.PrivateKey
+		publicKey string
+		image     v1.Image
+		digest    v1.Hash
+		sigTag    string
+	)
+
+	it.Before(func() {
+		fetcher = &registryfakes.Fetcher{}
+		key, publicKey = testhelpers.MakeCosignKey(t)
+
+		var err error
+		image, err = random.Image(10, 1)
+		require.NoError(t, err)
+		digest, err = image.Digest()
+		require.NoError(t, err)
+
+		fetcher.AddImage(src, image)
+		sigTag = "some-registry.io/some-repo:sha256-" + digest.Hex + ".sig"
+	})
+
+	it("returns the fetcher as is without a public key", func() {
+		f, err := registry.NewVerifyingFetcher(fetcher, registry.VerificationConfig{})
+		require.NoError(t, err)
+		require.Equal(t, fetcher, f)
+	})
+
+	it("fetches images signed with the private key", func() {
+		fetcher.AddImage(sigTag, testhelpers.MakeCosignSignature(t, key, digest))
+
+		f, err := registry.NewVerifyingFetcher(fetcher, registry.VerificationConfig{PublicKeyPath: publicKey})
+		require.NoError(t, err)
+
+		fetched, err := f.Fetch(authn.DefaultKeychain, src)
+		require.NoError(t, err)
+		require.Equal(t, image, fetched)
+	})
+
+	it("fails for images without a signature", func() {
+		f, err := registry.NewVerifyingFetcher(fetcher, registry.VerificationConfig{PublicKeyPath: publicKey, Policy: registry.VerificationPolicyEnforce})
+		require.NoError(t, err)
+
+		_, err = f.Fetch(authn.DefaultKeychain, src)
+		require.EqualError(t, err, "image '"+src+"' failed signature verification: no signature found at '"+sigTag+"'")
+		require.False(t, registry.IsVerificationSkipped(err))
+	})
+
+	it("fails for images whose signature is unknown to the registry", func() {
+		f, err := registry.NewVerifyingFetcher(
+			signatureErrorFetcher{Fetcher: fetcher, err: &transport.Error{StatusCode: http.StatusNotFound}},
+			registry.VerificationConfig{PublicKeyPath: publicKey},
+		)
+		require.NoError(t, err)
+
+		_, err = f.Fetch(authn.DefaultKeychain, src)
+		require.EqualError(t, err, "image '"+src+"' failed signature verification: no signature found at '"+sigTag+"'")
+	})
+
+	it("fails with the error of fetching the signature", func() {
+		f, err := registry.NewVerifyingFetcher(
+			signatureErrorFetcher{Fetcher: fetcher, err: errors.New("some-network-error")},
+			registry.VerificationConfig{PublicKeyPath: publicKey},
+		)
+		require.NoError(t, err)
+
+		_, err = f.Fetch(authn.DefaultKeychain, src)
+		require.EqualError(t, err, "image '"+src+"' failed signature verification: fetching signature '"+sigTag+"': some-network-error")
+	})
+
+	it("fails for images signed with another key", func() {
+		otherKey, _ := testhelpers.MakeCosignKey(t)
+		fetcher.AddImage(sigTag, testhelpers.MakeCosignSignature(t, otherKey, digest))
+
+		f, err := registry.NewVerifyingFetcher(fetcher, registry.VerificationConfig{PublicKeyPath: publicKey})
+		require.NoError(t, err)
+
+		_, err = f.Fetch(authn.DefaultKeychain, src)
+		require.EqualError(t, err, "image '"+src+"' failed signature verification: no signature matches the public key")
+	})
+
+	it("fails for signatures of another image", func() {
+		other, err := random.Image(10, 1)
+		require.NoError(t, err)
+		otherDigest, err := other.Digest()
+		require.NoError(t, err)
+		fetcher.AddImage(sigTag, testhelpers.MakeCosignSignature(t, key, otherDigest))
+
+		f, err := registry.NewVerifyingFetcher(fetcher, registry.VerificationConfig{PublicKeyPath: publicKey})
+		require.NoError(t, err)
+
+		_, err = f.Fetch(authn.DefaultKeychain, src)
+		require.EqualError(t, err, "image '"+src+"' failed signature verification: no signature matches the public key")
+	})
+
+	it("marks the error as skipped with the skip policy", func() {
+		f, err := registry.NewVerifyingFetcher(fetcher, registry.VerificationConfig{PublicKeyPath: publicKey, Policy: registry.VerificationPolicySkip})
+		require.NoError(t, err)
+
+		_, err = f.Fetch(authn.DefaultKeychain, src)
+		require.True(t, registry.IsVerificationSkipped(err))
+	})
+
+	it("fails with an invalid policy", func() {
+		_, err := registry.NewVerifyingFetcher(fetcher, registry.VerificationConfig{PublicKeyPath: publicKey, Policy: "some-policy"})
+		require.EqualError(t, err, "invalid verification policy 'some-policy', must be one of enforce, skip")
+	})
+
+	it("fails with a policy but no public key", func() {
+		_, err := registry.NewVerifyingFetcher(fetcher, registry.VerificationConfig{Policy: registry.VerificationPolicySkip})
+		require.EqualError(t, err, "verification policy requires a public key")
+	})
+
+	it("cannot filter platforms when verifying signatures", func() {
+		_, err := registry.NewSourceFetcher(fetcher, []string{"linux/amd64"}, registry.VerificationConfig{PublicKeyPath: publicKey})
+		require.EqualError(t, err, "platforms cannot be filtered when verifying signatures, the filtered images would not match the signed digests")
+	})
+}
+
+// signatureErrorFetcher fails to fetch cosign signatures with err.
+type signatureErrorFetcher struct {
+	registry.Fetcher
+	err error
+}
+
+func (f signatureErrorFetcher) Fetch(keychain authn.Keychain, src string) (v1.Image, error) {
+	if strings.HasSuffix(src, ".sig") {
+		return nil, f.err
+	}
+	return f.Fetcher.Fetch(keychain, src)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/buildpacks-community/kpack-cli/pkg/commands"
+	applycmds "github.com/buildpacks-community/kpack-cli/pkg/commands/apply"
 	buildcmds "github.com/buildpacks-community/kpack-cli/pkg/commands/build"
 	buildercmds "github.com/buildpacks-community/kpack-cli/pkg/commands/builder"
 	buildpackcmds "github.com/buildpacks-community/kpack-cli/pkg/commands/buildpack"
@@ -20,6 +21,7 @@ import (
 	imgcmds "github.com/buildpacks-community/kpack-cli/pkg/commands/image"
 	importcmds "github.com/buildpacks-community/kpack-cli/pkg/commands/import"
 	"github.com/buildpacks-community/kpack-cli/pkg/commands/lifecycle"
+	registrycmds "github.com/buildpacks-community/kpack-cli/pkg/commands/registry"
 	secretcmds "github.com/buildpacks-community/kpack-cli/pkg/commands/secret"
 	importpkg "github.com/buildpacks-community/kpack-cli/pkg/import"
 	"github.com/buildpacks-community/kpack-cli/pkg/k8s"
@@ -34,7 +36,7 @@ var (
 )
 
 func GetRootCommand() *cobra.Command {
-	var clientSetProvider k8s.DefaultClientSetProvider
+	clientSetProvider := &k8s.DefaultClientSetProvider{}
 
 	rootCmd := &cobra.Command{
 		Use: "kp",
@@ -44,6 +46,7 @@ kpack extends Kubernetes and utilizes unprivileged kubernetes primitives to prov
 builds of OCI images as a platform implementation of Cloud Native Buildpacks (CNB).
 Learn more about kpack @ https://github.com/pivotal/kpack`,
 	}
+	rootCmd.PersistentFlags().StringVar(&clientSetProvider.Context, commands.ContextFlag, "", "name of the kubeconfig context to use")
 	rootCmd.AddCommand(
 		getVersionCommand(),
 		getImageCommand(clientSetProvider),
@@ -58,7 +61,9 @@ Learn more about kpack @ https://github.com/pivotal/kpack`,
 		getClusterLifecycleCommand(clientSetProvider),
 		getLifecycleCommand(clientSetProvider),
 		getImportCommand(clientSetProvider),
+		getApplyCommand(clientSetProvider),
 		getConfigCommand(clientSetProvider),
+		getRegistryCommand(clientSetProvider),
 		getCompletionCommand(),
 	)
 
@@ -76,7 +81,7 @@ func getVersionCommand() *cobra.Command {
 	return versionCmd
 }
 
-func getImageCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
+func getImageCommand(clientSetProvider k8s.ContextClientSetProvider) *cobra.Command {
 	newImageWaiter := func(clientSet k8s.ClientSet) imgcmds.ImageWaiter {
 		return kpackcompat.NewImageWaiterForV1alpha2(logs.NewImageWaiter(clientSet.KpackClient, logs.NewBuildLogsClient(clientSet.K8sClient)))
 	}
@@ -90,29 +95,36 @@ func getImageCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 		imgcmds.NewCreateCommand(clientSetProvider, registry.DefaultUtilProvider{}, newImageWaiter),
 		imgcmds.NewPatchCommand(clientSetProvider, registry.DefaultUtilProvider{}, newImageWaiter),
 		imgcmds.NewSaveCommand(clientSetProvider, registry.DefaultUtilProvider{}, newImageWaiter),
-		imgcmds.NewListCommand(clientSetProvider),
+		commands.NewMultiContextCommand(clientSetProvider, imgcmds.NewListCommand),
 		imgcmds.NewDeleteCommand(clientSetProvider),
 		imgcmds.NewTriggerCommand(clientSetProvider),
-		imgcmds.NewStatusCommand(clientSetProvider),
+		commands.NewMultiContextCommand(clientSetProvider, imgcmds.NewStatusCommand),
+		imgcmds.NewWatchCommand(clientSetProvider),
+		imgcmds.NewLogsCommand(clientSetProvider, func(clientSet k8s.ClientSet) imgcmds.BuildLogTailer {
+			return logs.NewBuildLogsClient(clientSet.K8sClient)
+		}),
 	)
 	return imageRootCmd
 }
 
-func getBuildCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
+func getBuildCommand(clientSetProvider k8s.ContextClientSetProvider) *cobra.Command {
 	buildRootCmd := &cobra.Command{
 		Use:     "build",
 		Short:   "Build Commands",
 		Aliases: []string{"builds", "blds", "bld"},
 	}
 	buildRootCmd.AddCommand(
-		buildcmds.NewListCommand(clientSetProvider),
-		buildcmds.NewStatusCommand(clientSetProvider),
+		commands.NewMultiContextCommand(clientSetProvider, buildcmds.NewListCommand),
+		commands.NewMultiContextCommand(clientSetProvider, buildcmds.NewStatusCommand),
 		buildcmds.NewLogsCommand(clientSetProvider),
+		buildcmds.NewDiffCommand(clientSetProvider, commands.Differ{}),
+		buildcmds.NewSBOMCommand(clientSetProvider, registry.DefaultUtilProvider{}),
+		buildcmds.NewAttestCommand(clientSetProvider, registry.DefaultUtilProvider{}),
 	)
 	return buildRootCmd
 }
 
-func getSecretCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
+func getSecretCommand(clientSetProvider k8s.ContextClientSetProvider) *cobra.Command {
 	credentialFetcher := &commands.CredentialFetcher{}
 	secretFactory := &secret.Factory{
 		CredentialFetcher: credentialFetcher,
@@ -125,13 +137,15 @@ func getSecretCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 	}
 	secretRootCmd.AddCommand(
 		secretcmds.NewCreateCommand(clientSetProvider, secretFactory),
+		secretcmds.NewUpdateCommand(clientSetProvider, secretFactory),
 		secretcmds.NewDeleteCommand(clientSetProvider),
-		secretcmds.NewListCommand(clientSetProvider),
+		commands.NewMultiContextCommand(clientSetProvider, secretcmds.NewListCommand),
+		secretcmds.NewTestCommand(clientSetProvider),
 	)
 	return secretRootCmd
 }
 
-func getClusterBuilderCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
+func getClusterBuilderCommand(clientSetProvider k8s.ContextClientSetProvider) *cobra.Command {
 	clusterBuilderRootCmd := &cobra.Command{
 		Use:     "clusterbuilder",
 		Short:   "ClusterBuilder Commands",
@@ -141,31 +155,31 @@ func getClusterBuilderCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Co
 		clusterbuildercmds.NewCreateCommand(clientSetProvider, commands.NewResourceWaiter),
 		clusterbuildercmds.NewPatchCommand(clientSetProvider, commands.NewResourceWaiter),
 		clusterbuildercmds.NewSaveCommand(clientSetProvider, commands.NewResourceWaiter),
-		clusterbuildercmds.NewListCommand(clientSetProvider),
-		clusterbuildercmds.NewStatusCommand(clientSetProvider),
+		commands.NewMultiContextCommand(clientSetProvider, clusterbuildercmds.NewListCommand),
+		commands.NewMultiContextCommand(clientSetProvider, clusterbuildercmds.NewStatusCommand),
 		clusterbuildercmds.NewDeleteCommand(clientSetProvider),
 	)
 	return clusterBuilderRootCmd
 }
 
-func getClusterBuildpackCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
+func getClusterBuildpackCommand(clientSetProvider k8s.ContextClientSetProvider) *cobra.Command {
 	clusterBuilderRootCmd := &cobra.Command{
 		Use:     "clusterbuildpack",
 		Short:   "ClusterBuildpack Commands",
 		Aliases: []string{"clusterbuildpacks", "clstrbps", "clstrbp", "cbps", "cbp"},
 	}
 	clusterBuilderRootCmd.AddCommand(
-		clusterbuildpackcmds.NewCreateCommand(clientSetProvider, commands.NewResourceWaiter),
-		clusterbuildpackcmds.NewPatchCommand(clientSetProvider, commands.NewResourceWaiter),
-		clusterbuildpackcmds.NewSaveCommand(clientSetProvider, commands.NewResourceWaiter),
-		clusterbuildpackcmds.NewListCommand(clientSetProvider),
-		clusterbuildpackcmds.NewStatusCommand(clientSetProvider),
+		clusterbuildpackcmds.NewCreateCommand(clientSetProvider, registry.DefaultUtilProvider{}, commands.NewResourceWaiter),
+		clusterbuildpackcmds.NewPatchCommand(clientSetProvider, registry.DefaultUtilProvider{}, commands.NewResourceWaiter),
+		clusterbuildpackcmds.NewSaveCommand(clientSetProvider, registry.DefaultUtilProvider{}, commands.NewResourceWaiter),
+		commands.NewMultiContextCommand(clientSetProvider, clusterbuildpackcmds.NewListCommand),
+		commands.NewMultiContextCommand(clientSetProvider, clusterbuildpackcmds.NewStatusCommand),
 		clusterbuildpackcmds.NewDeleteCommand(clientSetProvider),
 	)
 	return clusterBuilderRootCmd
 }
 
-func getBuilderCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
+func getBuilderCommand(clientSetProvider k8s.ContextClientSetProvider) *cobra.Command {
 	builderRootCmd := &cobra.Command{
 		Use:     "builder",
 		Short:   "Builder Commands",
@@ -175,31 +189,31 @@ func getBuilderCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 		buildercmds.NewCreateCommand(clientSetProvider, commands.NewResourceWaiter),
 		buildercmds.NewPatchCommand(clientSetProvider, commands.NewResourceWaiter),
 		buildercmds.NewSaveCommand(clientSetProvider, commands.NewResourceWaiter),
-		buildercmds.NewListCommand(clientSetProvider),
+		commands.NewMultiContextCommand(clientSetProvider, buildercmds.NewListCommand),
 		buildercmds.NewDeleteCommand(clientSetProvider),
-		buildercmds.NewStatusCommand(clientSetProvider),
+		commands.NewMultiContextCommand(clientSetProvider, buildercmds.NewStatusCommand),
 	)
 	return builderRootCmd
 }
 
-func getBuildpackCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
+func getBuildpackCommand(clientSetProvider k8s.ContextClientSetProvider) *cobra.Command {
 	builderRootCmd := &cobra.Command{
 		Use:     "buildpack",
 		Short:   "Buildpack Commands",
 		Aliases: []string{"buildpacks", "bp", "bps"},
 	}
 	builderRootCmd.AddCommand(
-		buildpackcmds.NewCreateCommand(clientSetProvider, commands.NewResourceWaiter),
-		buildpackcmds.NewPatchCommand(clientSetProvider, commands.NewResourceWaiter),
-		buildpackcmds.NewSaveCommand(clientSetProvider, commands.NewResourceWaiter),
-		buildpackcmds.NewListCommand(clientSetProvider),
+		buildpackcmds.NewCreateCommand(clientSetProvider, registry.DefaultUtilProvider{}, commands.NewResourceWaiter),
+		buildpackcmds.NewPatchCommand(clientSetProvider, registry.DefaultUtilProvider{}, commands.NewResourceWaiter),
+		buildpackcmds.NewSaveCommand(clientSetProvider, registry.DefaultUtilProvider{}, commands.NewResourceWaiter),
+		commands.NewMultiContextCommand(clientSetProvider, buildpackcmds.NewListCommand),
 		buildpackcmds.NewDeleteCommand(clientSetProvider),
-		buildpackcmds.NewStatusCommand(clientSetProvider),
+		commands.NewMultiContextCommand(clientSetProvider, buildpackcmds.NewStatusCommand),
 	)
 	return builderRootCmd
 }
 
-func getStackCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
+func getStackCommand(clientSetProvider k8s.ContextClientSetProvider) *cobra.Command {
 	stackRootCmd := &cobra.Command{
 		Use:     "clusterstack",
 		Aliases: []string{"clusterstacks", "clstrcsks", "clstrcsk", "cstacks", "cstack", "cstks", "cstk", "csks", "csk"},
@@ -209,14 +223,14 @@ func getStackCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 		clusterstackcmds.NewCreateCommand(clientSetProvider, registry.DefaultUtilProvider{}, commands.NewResourceWaiter),
 		clusterstackcmds.NewPatchCommand(clientSetProvider, registry.DefaultUtilProvider{}, commands.NewResourceWaiter),
 		clusterstackcmds.NewSaveCommand(clientSetProvider, registry.DefaultUtilProvider{}, commands.NewResourceWaiter),
-		clusterstackcmds.NewListCommand(clientSetProvider),
-		clusterstackcmds.NewStatusCommand(clientSetProvider),
+		commands.NewMultiContextCommand(clientSetProvider, clusterstackcmds.NewListCommand),
+		commands.NewMultiContextCommand(clientSetProvider, clusterstackcmds.NewStatusCommand),
 		clusterstackcmds.NewDeleteCommand(clientSetProvider),
 	)
 	return stackRootCmd
 }
 
-func getStoreCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
+func getStoreCommand(clientSetProvider k8s.ContextClientSetProvider) *cobra.Command {
 	storeRootCommand := &cobra.Command{
 		Use:     "clusterstore",
 		Aliases: []string{"clusterstores", "clstrcsrs", "clstrcsr", "cstores", "cstore", "cstrs", "cstr", "csrs", "csr"},
@@ -227,15 +241,15 @@ func getStoreCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 		clusterstorecmds.NewAddCommand(clientSetProvider, registry.DefaultUtilProvider{}, commands.NewResourceWaiter),
 		clusterstorecmds.NewSaveCommand(clientSetProvider, registry.DefaultUtilProvider{}, commands.NewResourceWaiter),
 		clusterstorecmds.NewDeleteCommand(clientSetProvider, commands.NewConfirmationProvider()),
-		clusterstorecmds.NewStatusCommand(clientSetProvider),
+		commands.NewMultiContextCommand(clientSetProvider, clusterstorecmds.NewStatusCommand),
 		clusterstorecmds.NewRemoveCommand(clientSetProvider, commands.NewResourceWaiter),
-		clusterstorecmds.NewListCommand(clientSetProvider),
+		commands.NewMultiContextCommand(clientSetProvider, clusterstorecmds.NewListCommand),
 	)
 
 	return storeRootCommand
 }
 
-func getClusterLifecycleCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
+func getClusterLifecycleCommand(clientSetProvider k8s.ContextClientSetProvider) *cobra.Command {
 	clusterLifecycleRootCommand := &cobra.Command{
 		Use:     "clusterlifecycle",
 		Aliases: []string{"clusterlifecycles", "clstrlcs", "clstrlc", "clcs", "clc"},
@@ -245,8 +259,8 @@ func getClusterLifecycleCommand(clientSetProvider k8s.ClientSetProvider) *cobra.
 		clusterlifecyclecmds.NewCreateCommand(clientSetProvider, registry.DefaultUtilProvider{}, commands.NewResourceWaiter),
 		clusterlifecyclecmds.NewPatchCommand(clientSetProvider, registry.DefaultUtilProvider{}, commands.NewResourceWaiter),
 		clusterlifecyclecmds.NewSaveCommand(clientSetProvider, registry.DefaultUtilProvider{}, commands.NewResourceWaiter),
-		clusterlifecyclecmds.NewListCommand(clientSetProvider),
-		clusterlifecyclecmds.NewStatusCommand(clientSetProvider),
+		commands.NewMultiContextCommand(clientSetProvider, clusterlifecyclecmds.NewListCommand),
+		commands.NewMultiContextCommand(clientSetProvider, clusterlifecyclecmds.NewStatusCommand),
 		clusterlifecyclecmds.NewDeleteCommand(clientSetProvider),
 	)
 	return clusterLifecycleRootCommand
@@ -264,7 +278,7 @@ func getLifecycleCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command
 }
 
 func getImportCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
-	return importcmds.NewImportCommand(
+	importCmd := importcmds.NewImportCommand(
 		commands.Differ{},
 		clientSetProvider,
 		registry.DefaultUtilProvider{},
@@ -272,6 +286,15 @@ func getImportCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 		commands.NewConfirmationProvider(),
 		commands.NewResourceWaiter,
 	)
+	importCmd.AddCommand(
+		importcmds.NewExportCommand(registry.DefaultUtilProvider{}),
+		importcmds.NewGenerateCommand(clientSetProvider),
+	)
+	return importCmd
+}
+
+func getApplyCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
+	return applycmds.NewApplyCommand(clientSetProvider, registry.DefaultUtilProvider{}, commands.Differ{})
 }
 
 func getConfigCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
@@ -288,6 +311,18 @@ func getConfigCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
 	return configRootCmd
 }
 
+func getRegistryCommand(clientSetProvider k8s.ClientSetProvider) *cobra.Command {
+	registryRootCmd := &cobra.Command{
+		Use:   "registry",
+		Short: "Registry commands",
+	}
+	registryRootCmd.AddCommand(
+		registrycmds.NewGCCommand(clientSetProvider, registry.DefaultUtilProvider{}, commands.NewConfirmationProvider()),
+	)
+
+	return registryRootCmd
+}
+
 func getCompletionCommand() *cobra.Command {
 	return &cobra.Command{
 		Use:   "completion [bash|zsh|fish|powershell]",
@@ -0,0 +1,160 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// keyFunc identifies an item of a merged list so that items found in more
+// than one document are only kept once.
+type keyFunc func(item map[string]interface{}) string
+
+func field(name string) keyFunc {
+	return func(item map[string]interface{}) string {
+		return fmt.Sprint(item[name])
+	}
+}
+
+func fields(names ...string) keyFunc {
+	return func(item map[string]interface{}) string {
+		key := ""
+		for _, name := range names {
+			key += fmt.Sprint(item[name]) + "\x00"
+		}
+		return key
+	}
+}
+
+// mergedLists are the top level lists of each format that are concatenated
+// when documents are merged. Other top level fields are taken from the first
+// document.
+var mergedLists = map[string]map[string]keyFunc{
+	FormatCycloneDX: {
+		"components":   fields("bom-ref", "purl", "name", "version"),
+		"dependencies": field("ref"),
+	},
+	FormatSPDX: {
+		"packages":      field("SPDXID"),
+		"files":         field("SPDXID"),
+		"relationships": fields("spdxElementId", "relationshipType", "relatedSpdxElement"),
+	},
+	FormatSyft: {
+		"artifacts":             field("id"),
+		"artifactRelationships": fields("parent", "child", "type"),
+		"files":                 field("id"),
+	},
+}
+
+// Merge combines the documents of the format into a single document named
+// after the merged image.
+func Merge(format, name string, docs []Document) ([]byte, error) {
+	lists, ok := mergedLists[format]
+	if !ok {
+		return nil, errors.Errorf("invalid sbom format '%s'", format)
+	}
+
+	var merged map[string]interface{}
+	seen := map[string]map[string]bool{}
+	index := 0
+	for _, doc := range docs {
+		if doc.Format != format {
+			continue
+		}
+		index++
+
+		var content map[string]interface{}
+		if err := json.Unmarshal(doc.Content, &content); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse sbom '%s'", doc.Path)
+		}
+
+		if format == FormatSPDX {
+			// spdx ids are only unique within a document
+			namespaceSPDXIds(content, fmt.Sprintf("%s%d-", spdxRefPrefix, index))
+		}
+
+		if merged == nil {
+			merged = map[string]interface{}{}
+			for key, value := range content {
+				if _, ok := lists[key]; !ok {
+					merged[key] = value
+				}
+			}
+		}
+
+		for list, key := range lists {
+			items, _ := content[list].([]interface{})
+			for _, item := range items {
+				obj, ok := item.(map[string]interface{})
+				if !ok {
+					continue
+				}
+
+				if seen[list] == nil {
+					seen[list] = map[string]bool{}
+				}
+				if k := key(obj); !seen[list][k] {
+					seen[list][k] = true
+					existing, _ := merged[list].([]interface{})
+					merged[list] = append(existing, obj)
+				}
+			}
+		}
+	}
+
+	if merged == nil {
+		return nil, errors.Errorf("no %s sbom documents found", format)
+	}
+
+	describe(format, name, merged)
+
+	return json.MarshalIndent(merged, "", "  ")
+}
+
+const (
+	spdxRefPrefix   = "SPDXRef-"
+	spdxDocumentRef = "SPDXRef-DOCUMENT"
+)
+
+// namespaceSPDXIds replaces the prefix of each element id in the document
+// so that elements of different documents keep distinct ids. References to
+// the document itself are kept since the merged document replaces them all.
+func namespaceSPDXIds(value interface{}, prefix string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range v {
+			v[key] = namespaceSPDXIds(nested, prefix)
+		}
+	case []interface{}:
+		for i, nested := range v {
+			v[i] = namespaceSPDXIds(nested, prefix)
+		}
+	case string:
+		if v != spdxDocumentRef && strings.HasPrefix(v, spdxRefPrefix) {
+			return prefix + strings.TrimPrefix(v, spdxRefPrefix)
+		}
+	}
+	return value
+}
+
+// describe replaces the fields that identify the subject of the document with
+// the merged image.
+func describe(format, name string, doc map[string]interface{}) {
+	switch format {
+	case FormatCycloneDX:
+		delete(doc, "serialNumber")
+		doc["metadata"] = map[string]interface{}{
+			"component": map[string]interface{}{"type": "container", "name": name},
+		}
+	case FormatSPDX:
+		doc["name"] = name
+		doc["documentNamespace"] = "https://kpack.io/sbom/" + name
+	case FormatSyft:
+		doc["source"] = map[string]interface{}{"type": "image", "target": name}
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package sbom
+
+import (
+	"archive/tar"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/pivotal/kpack/pkg/registry/imagehelpers"
+	"github.com/pkg/errors"
+)
+
+const (
+	FormatCycloneDX = "cyclonedx"
+	FormatSPDX      = "spdx"
+	FormatSyft      = "syft"
+
+	lifecycleMetadataLabel = "io.buildpacks.lifecycle.metadata"
+	launchDir              = "layers/sbom/launch/"
+)
+
+// Formats lists the supported formats in the order documents are written.
+var Formats = []string{FormatCycloneDX, FormatSPDX, FormatSyft}
+
+var extensions = map[string]string{
+	FormatCycloneDX: "sbom.cdx.json",
+	FormatSPDX:      "sbom.spdx.json",
+	FormatSyft:      "sbom.syft.json",
+}
+
+// Extension returns the file name used by the lifecycle for documents of the format.
+func Extension(format string) string {
+	return extensions[format]
+}
+
+func ValidateFormats(formats []string) error {
+	for _, format := range formats {
+		if _, ok := extensions[format]; !ok {
+			return errors.Errorf("invalid sbom format '%s', must be one of %s", format, strings.Join(Formats, ", "))
+		}
+	}
+	return nil
+}
+
+type Fetcher interface {
+	Fetch(keychain authn.Keychain, image string) (v1.Image, error)
+}
+
+// Document is one SBOM file written by a buildpack or the lifecycle. Path is
+// relative to the launch SBOM directory of the image, for example
+// "paketo-buildpacks_go-build/targets/sbom.cdx.json".
+type Document struct {
+	Path    string
+	Format  string
+	Content []byte
+}
+
+type Reader struct {
+	Fetcher Fetcher
+}
+
+// Read returns the launch SBOM documents of the app image with the given
+// formats, sorted by path.
+func (r *Reader) Read(keychain authn.Keychain, imageRef string, formats []string) ([]Document, error) {
+	image, err := r.Fetcher.Fetch(keychain, imageRef)
+	if err != nil {
+		return nil, err
+	}
+
+	layer, err := sbomLayer(image)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read sbom of image '%s'", imageRef)
+	}
+
+	rc, err := layer.Uncompressed()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	wanted := map[string]string{}
+	for _, format := range formats {
+		wanted[extensions[format]] = format
+	}
+
+	var docs []Document
+	tr := tar.NewReader(rc)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		name := strings.TrimPrefix(path.Clean("/"+header.Name), "/")
+		if header.Typeflag != tar.TypeReg || !strings.HasPrefix(name, launchDir) {
+			continue
+		}
+
+		format, ok := wanted[path.Base(name)]
+		if !ok {
+			continue
+		}
+
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, Document{Path: strings.TrimPrefix(name, launchDir), Format: format, Content: content})
+	}
+
+	sort.Slice(docs, func(i, j int) bool { return docs[i].Path < docs[j].Path })
+	return docs, nil
+}
+
+// sbomLayer finds the SBOM layer exported by the lifecycle using the layer
+// diff id recorded in the lifecycle metadata label.
+func sbomLayer(image v1.Image) (v1.Layer, error) {
+	hasLabel, err := imagehelpers.HasLabel(image, lifecycleMetadataLabel)
+	if err != nil {
+		return nil, err
+	} else if !hasLabel {
+		return nil, errors.Errorf("missing label %s", lifecycleMetadataLabel)
+	}
+
+	var metadata struct {
+		SBOM *struct {
+			SHA string `json:"sha"`
+		} `json:"sbom"`
+	}
+	if err := imagehelpers.GetLabel(image, lifecycleMetadataLabel, &metadata); err != nil {
+		return nil, err
+	}
+
+	if metadata.SBOM == nil || metadata.SBOM.SHA == "" {
+		return nil, errors.New("image has no sbom layer, it must be built with platform api 0.8 or newer")
+	}
+
+	diffID, err := v1.NewHash(metadata.SBOM.SHA)
+	if err != nil {
+		return nil, err
+	}
+
+	return image.LayerByDiffID(diffID)
+}
@@ -0,0 +1,127 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package sbom_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+
+	registryfakes "github.com/buildpacks-community/kpack-cli/pkg/registry/fakes"
+	"github.com/buildpacks-community/kpack-cli/pkg/sbom"
+	"github.com/buildpacks-community/kpack-cli/pkg/testhelpers"
+)
+
+func TestSBOM(t *testing.T) {
+	spec.Run(t, "TestSBOM", testSBOM)
+}
+
+func testSBOM(t *testing.T, when spec.G, it spec.S) {
+	const imageRef = "some-registry.io/some-app@sha256:123"
+
+	var (
+		fetcher *registryfakes.Fetcher
+		reader  *sbom.Reader
+	)
+
+	it.Before(func() {
+		fetcher = &registryfakes.Fetcher{}
+		fetcher.AddImage(imageRef, testhelpers.MakeSBOMImage(t, map[string]string{
+			"buildpacksio_lifecycle/launcher/sbom.cdx.json": `{"bomFormat":"CycloneDX","specVersion":"1.3","components":[{"name":"launcher","version":"0.20.12"}]}`,
+			"some-buildpack/some-layer/sbom.cdx.json":       `{"bomFormat":"CycloneDX","specVersion":"1.3","serialNumber":"urn:uuid:1","components":[{"name":"go","version":"1.24"},{"name":"launcher","version":"0.20.12"}]}`,
+			"some-buildpack/some-layer/sbom.syft.json":      `{"artifacts":[{"id":"go"}],"source":{"type":"directory"}}`,
+			"some-buildpack/sbom.legacy.json":               `{}`,
+		}))
+		reader = &sbom.Reader{Fetcher: fetcher}
+	})
+
+	when("reading the sbom of an image", func() {
+		it("returns the documents of the requested formats", func() {
+			docs, err := reader.Read(authn.DefaultKeychain, imageRef, []string{sbom.FormatCycloneDX})
+			require.NoError(t, err)
+
+			require.Len(t, docs, 2)
+			require.Equal(t, "buildpacksio_lifecycle/launcher/sbom.cdx.json", docs[0].Path)
+			require.Equal(t, "some-buildpack/some-layer/sbom.cdx.json", docs[1].Path)
+			require.Equal(t, sbom.FormatCycloneDX, docs[1].Format)
+		})
+
+		it("fails when the image has no sbom layer", func() {
+			image, err := random.Image(10, 1)
+			require.NoError(t, err)
+			fetcher.AddImage("some-registry.io/no-sbom", image)
+
+			_, err = reader.Read(authn.DefaultKeychain, "some-registry.io/no-sbom", sbom.Formats)
+			require.EqualError(t, err, "failed to read sbom of image 'some-registry.io/no-sbom': missing label io.buildpacks.lifecycle.metadata")
+		})
+	})
+
+	when("merging documents", func() {
+		it("combines the components of each document once", func() {
+			docs, err := reader.Read(authn.DefaultKeychain, imageRef, sbom.Formats)
+			require.NoError(t, err)
+
+			merged, err := sbom.Merge(sbom.FormatCycloneDX, imageRef, docs)
+			require.NoError(t, err)
+
+			var doc map[string]interface{}
+			require.NoError(t, json.Unmarshal(merged, &doc))
+			require.Equal(t, map[string]interface{}{
+				"bomFormat":   "CycloneDX",
+				"specVersion": "1.3",
+				"metadata": map[string]interface{}{
+					"component": map[string]interface{}{"type": "container", "name": imageRef},
+				},
+				"components": []interface{}{
+					map[string]interface{}{"name": "launcher", "version": "0.20.12"},
+					map[string]interface{}{"name": "go", "version": "1.24"},
+				},
+			}, doc)
+		})
+
+		it("keeps the spdx elements of each document distinct", func() {
+			docs := []sbom.Document{
+				{
+					Path:    "some-buildpack/some-layer/sbom.spdx.json",
+					Format:  sbom.FormatSPDX,
+					Content: []byte(`{"SPDXID":"SPDXRef-DOCUMENT","packages":[{"SPDXID":"SPDXRef-Package-1","name":"go"}],"relationships":[{"spdxElementId":"SPDXRef-DOCUMENT","relationshipType":"DESCRIBES","relatedSpdxElement":"SPDXRef-Package-1"}]}`),
+				},
+				{
+					Path:    "other-buildpack/other-layer/sbom.spdx.json",
+					Format:  sbom.FormatSPDX,
+					Content: []byte(`{"SPDXID":"SPDXRef-DOCUMENT","packages":[{"SPDXID":"SPDXRef-Package-1","name":"node"}],"relationships":[{"spdxElementId":"SPDXRef-DOCUMENT","relationshipType":"DESCRIBES","relatedSpdxElement":"SPDXRef-Package-1"}]}`),
+				},
+			}
+
+			merged, err := sbom.Merge(sbom.FormatSPDX, imageRef, docs)
+			require.NoError(t, err)
+
+			var doc map[string]interface{}
+			require.NoError(t, json.Unmarshal(merged, &doc))
+			require.Equal(t, []interface{}{
+				map[string]interface{}{"SPDXID": "SPDXRef-1-Package-1", "name": "go"},
+				map[string]interface{}{"SPDXID": "SPDXRef-2-Package-1", "name": "node"},
+			}, doc["packages"])
+			require.Equal(t, []interface{}{
+				map[string]interface{}{"spdxElementId": "SPDXRef-DOCUMENT", "relationshipType": "DESCRIBES", "relatedSpdxElement": "SPDXRef-1-Package-1"},
+				map[string]interface{}{"spdxElementId": "SPDXRef-DOCUMENT", "relationshipType": "DESCRIBES", "relatedSpdxElement": "SPDXRef-2-Package-1"},
+			}, doc["relationships"])
+			require.Equal(t, "SPDXRef-DOCUMENT", doc["SPDXID"])
+		})
+
+		it("fails when there are no documents of the format", func() {
+			_, err := sbom.Merge(sbom.FormatSPDX, imageRef, nil)
+			require.EqualError(t, err, "no spdx sbom documents found")
+		})
+	})
+
+	it("validates formats", func() {
+		require.NoError(t, sbom.ValidateFormats(sbom.Formats))
+		require.EqualError(t, sbom.ValidateFormats([]string{"xml"}), "invalid sbom format 'xml', must be one of cyclonedx, spdx, syft")
+	})
+}
@@ -23,6 +23,8 @@ const (
 	GcrUrl        = "gcr.io"
 	GcrUser       = "_json_key"
 	GitAnnotation = "kpack.io/git"
+
+	SSHAuthKnownHostsKey = "known_hosts"
 )
 
 var gitHttpUrlRegex = regexp.MustCompile(`^(?:https?://)?(?:[a-zA-Z0-9\-\.])+\.(?:\w+)(:(?:\d){3,5})?$`)
@@ -33,14 +35,17 @@ type CredentialFetcher interface {
 }
 
 type Factory struct {
-	CredentialFetcher     CredentialFetcher
-	DockerhubId           string
-	Registry              string
-	RegistryUser          string
-	GcrServiceAccountFile string
-	GitUrl                string
-	GitSshKeyFile         string
-	GitUser               string
+	CredentialFetcher      CredentialFetcher
+	Keychain               authn.Keychain
+	DockerhubId            string
+	Registry               string
+	RegistryUser           string
+	DockerConfigRegistries []string
+	GcrServiceAccountFile  string
+	GitUrl                 string
+	GitSshKeyFile          string
+	GitKnownHostsFile      string
+	GitUser                string
 }
 
 func (f *Factory) MakeSecret(name, namespace string) (*corev1.Secret, string, error) {
@@ -60,6 +65,8 @@ func (f *Factory) MakeSecret(name, namespace string) (*corev1.Secret, string, er
 		return f.makeGcrSecret(name, namespace)
 	case registryKind:
 		return f.makeRegistrySecret(name, namespace)
+	case dockerConfigKind:
+		return f.makeDockerConfigSecret(name, namespace)
 	case gitSshKind:
 		return f.makeGitSshSecret(name, namespace)
 	case gitBasicAuthKind:
@@ -73,16 +80,18 @@ func (f *Factory) validate() error {
 	set := paramSet{}
 	set.add("dockerhub", f.DockerhubId)
 	set.add("registry", f.Registry)
+	set.add("docker-config", strings.Join(f.DockerConfigRegistries, ","))
 	set.add("gcr", f.GcrServiceAccountFile)
 	set.add("git", f.GitUrl)
 
 	if len(set) != 1 {
-		return errors.Errorf("secret must be one of dockerhub, gcr, registry, or git")
+		return errors.Errorf("secret must be one of dockerhub, gcr, registry, docker-config, or git")
 	}
 
 	set.add("registry-user", f.RegistryUser)
 	set.add("git-user", f.GitUser)
 	set.add("git-ssh-key", f.GitSshKeyFile)
+	set.add("git-known-hosts", f.GitKnownHostsFile)
 
 	if set.contains("dockerhub") && len(set) != 1 {
 		return set.getExtraParamsError("dockerhub")
@@ -92,6 +101,10 @@ func (f *Factory) validate() error {
 		return set.getExtraParamsError("gcr")
 	}
 
+	if set.contains("docker-config") && len(set) != 1 {
+		return set.getExtraParamsError("docker-config")
+	}
+
 	if set.contains("registry") {
 		if !set.contains("registry-user") {
 			return errors.Errorf("missing parameter registry-user")
@@ -105,8 +118,10 @@ func (f *Factory) validate() error {
 			return errors.Errorf("missing parameter git-user or git-ssh-key")
 		} else if set.contains("git-user") && set.contains("git-ssh-key") {
 			return errors.Errorf("must provide one of git-user or git-ssh-key")
-		} else if len(set) != 2 {
-			return set.getExtraParamsError("git", "git-user", "git-ssh-key")
+		} else if set.contains("git-known-hosts") && !set.contains("git-ssh-key") {
+			return errors.Errorf("git-known-hosts requires git-ssh-key")
+		} else if len(set) != 2 && !(len(set) == 3 && set.contains("git-known-hosts")) {
+			return set.getExtraParamsError("git", "git-user", "git-ssh-key", "git-known-hosts")
 		}
 	}
 
@@ -126,6 +141,8 @@ func (f *Factory) getSecretKind() (secretKind, error) {
 		return dockerHubKind, nil
 	} else if f.Registry != "" && f.RegistryUser != "" {
 		return registryKind, nil
+	} else if len(f.DockerConfigRegistries) > 0 {
+		return dockerConfigKind, nil
 	} else if f.GcrServiceAccountFile != "" {
 		return gcrKind, nil
 	} else if f.GitUrl != "" && f.GitSshKeyFile != "" {
@@ -237,12 +254,89 @@ func (f *Factory) makeRegistrySecret(secretName string, namespace string) (*core
 	}, f.Registry, nil
 }
 
+// makeDockerConfigSecret reads the credentials of each registry from the local
+// docker config, including its credential helpers, into a single secret.
+func (f *Factory) makeDockerConfigSecret(secretName string, namespace string) (*corev1.Secret, string, error) {
+	keychain := f.Keychain
+	if keychain == nil {
+		keychain = authn.DefaultKeychain
+	}
+
+	credentials := DockerCredentials{}
+	for _, r := range f.DockerConfigRegistries {
+		reg, err := parseRegistry(r)
+		if err != nil {
+			return nil, "", err
+		}
+
+		authenticator, err := keychain.Resolve(reg)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if authenticator == authn.Anonymous {
+			return nil, "", errors.Errorf("no credentials found for %s in the docker config", r)
+		}
+
+		auth, err := authenticator.Authorization()
+		if err != nil {
+			return nil, "", errors.Wrapf(err, "reading credentials for %s", r)
+		}
+
+		host := reg.RegistryStr()
+		if host == name.DefaultRegistry {
+			host = DockerhubUrl
+		}
+		credentials[host] = *auth
+	}
+
+	dockerCfgJson, err := json.Marshal(DockerConfigJson{Auths: credentials})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretName,
+			Namespace: namespace,
+		},
+		Data: map[string][]byte{
+			corev1.DockerConfigJsonKey: dockerCfgJson,
+		},
+		Type: corev1.SecretTypeDockerConfigJson,
+	}, strings.Join(f.DockerConfigRegistries, ","), nil
+}
+
+func parseRegistry(registry string) (name.Registry, error) {
+	// Handle path in registry
+	if strings.ContainsRune(registry, '/') {
+		r, err := name.NewRepository(registry, name.WeakValidation)
+		if err != nil {
+			return name.Registry{}, err
+		}
+		return r.Registry, nil
+	}
+	return name.NewRegistry(registry, name.WeakValidation)
+}
+
 func (f *Factory) makeGitSshSecret(name string, namespace string) (*corev1.Secret, string, error) {
 	password, err := os.ReadFile(f.GitSshKeyFile)
 	if err != nil {
 		return nil, "", err
 	}
 
+	data := map[string][]byte{
+		corev1.SSHAuthPrivateKey: []byte(password),
+	}
+
+	if f.GitKnownHostsFile != "" {
+		knownHosts, err := os.ReadFile(f.GitKnownHostsFile)
+		if err != nil {
+			return nil, "", err
+		}
+		data[SSHAuthKnownHostsKey] = knownHosts
+	}
+
 	return &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
@@ -251,9 +345,7 @@ func (f *Factory) makeGitSshSecret(name string, namespace string) (*corev1.Secre
 				GitAnnotation: f.GitUrl,
 			},
 		},
-		Data: map[string][]byte{
-			corev1.SSHAuthPrivateKey: []byte(password),
-		},
+		Data: data,
 		Type: corev1.SecretTypeSSHAuth,
 	}, f.GitUrl, nil
 }
@@ -286,6 +378,7 @@ const (
 	dockerHubKind    secretKind = "dockerhub"
 	gcrKind                     = "gcr"
 	registryKind                = "registry"
+	dockerConfigKind            = "docker config"
 	gitSshKind                  = "git ssh"
 	gitBasicAuthKind            = "git basic auth"
 )
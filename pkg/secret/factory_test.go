@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/sclevine/spec"
 	"github.com/stretchr/testify/require"
 
@@ -37,7 +38,7 @@ func testSecretFactory(t *testing.T, when spec.G, it spec.S) {
 	when("no params are set", func() {
 		it("returns an error message", func() {
 			_, _, err := factory.MakeSecret("test-name", "test-namespace")
-			require.EqualError(t, err, "secret must be one of dockerhub, gcr, registry, or git")
+			require.EqualError(t, err, "secret must be one of dockerhub, gcr, registry, docker-config, or git")
 		})
 	})
 
@@ -46,7 +47,7 @@ func testSecretFactory(t *testing.T, when spec.G, it spec.S) {
 			factory.DockerhubId = "some-dockerhub-id"
 			factory.GcrServiceAccountFile = "some-gcr-service-account"
 			_, _, err := factory.MakeSecret("test-name", "test-namespace")
-			require.EqualError(t, err, "secret must be one of dockerhub, gcr, registry, or git")
+			require.EqualError(t, err, "secret must be one of dockerhub, gcr, registry, docker-config, or git")
 		})
 	})
 
@@ -88,6 +89,36 @@ func testSecretFactory(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 
+	when("using the docker config", func() {
+		it.Before(func() {
+			factory.Keychain = fakeKeychain{
+				"registry.io":     {Username: "some-reg-user", Password: "foo"},
+				"index.docker.io": {Username: "some-dockerhub-user", Password: "bar"},
+			}
+		})
+
+		it("stores the credentials of multiple registries in one secret", func() {
+			factory.DockerConfigRegistries = []string{"registry.io/my-repo", "docker.io"}
+			s, target, err := factory.MakeSecret("test-name", "test-namespace")
+			require.NoError(t, err)
+			require.Equal(t, "registry.io/my-repo,docker.io", target)
+			require.Equal(t, `{"auths":{"https://index.docker.io/v1/":{"username":"some-dockerhub-user","password":"bar","auth":"c29tZS1kb2NrZXJodWItdXNlcjpiYXI="},"registry.io":{"username":"some-reg-user","password":"foo","auth":"c29tZS1yZWctdXNlcjpmb28="}}}`, string(s.Data[".dockerconfigjson"]))
+		})
+
+		it("returns an error when a registry has no credentials", func() {
+			factory.DockerConfigRegistries = []string{"registry.io", "other-registry.io"}
+			_, _, err := factory.MakeSecret("test-name", "test-namespace")
+			require.EqualError(t, err, "no credentials found for other-registry.io in the docker config")
+		})
+
+		it("returns an error when mixed with sub params", func() {
+			factory.DockerConfigRegistries = []string{"registry.io"}
+			factory.RegistryUser = "some-reg-user"
+			_, _, err := factory.MakeSecret("test-name", "test-namespace")
+			require.EqualError(t, err, "extraneous parameters: registry-user")
+		})
+	})
+
 	when("sub params are mixed with registry", func() {
 		it("returns an error message", func() {
 			factory.Registry = "some-registry"
@@ -184,6 +215,23 @@ func testSecretFactory(t *testing.T, when spec.G, it spec.S) {
 			}
 		})
 
+		it("adds known hosts to the secret", func() {
+			factory.GitUrl = "git@github.com"
+			factory.GitSshKeyFile = "./testdata/some-ssh-key.pem"
+			factory.GitKnownHostsFile = "./testdata/known_hosts"
+			s, _, err := factory.MakeSecret("test-name", "test-namespace")
+			require.NoError(t, err)
+			require.Contains(t, string(s.Data["known_hosts"]), "github.com ssh-ed25519")
+		})
+
+		it("returns an error when known hosts are used without an ssh key", func() {
+			factory.GitUrl = "https://github.com"
+			factory.GitUser = "some-git-user"
+			factory.GitKnownHostsFile = "./testdata/known_hosts"
+			_, _, err := factory.MakeSecret("test-name", "test-namespace")
+			require.EqualError(t, err, "git-known-hosts requires git-ssh-key")
+		})
+
 		it("prints an error when the git url is not valid", func() {
 			invalidGitSshUrls := []string{
 				"some-git",
@@ -220,3 +268,12 @@ type fakeCredentialFetcher struct {
 func (f fakeCredentialFetcher) FetchPassword(envVar, prompt string) (string, error) {
 	return f.pw, nil
 }
+
+type fakeKeychain map[string]authn.AuthConfig
+
+func (k fakeKeychain) Resolve(r authn.Resource) (authn.Authenticator, error) {
+	if cfg, ok := k[r.RegistryStr()]; ok {
+		return authn.FromConfig(cfg), nil
+	}
+	return authn.Anonymous, nil
+}
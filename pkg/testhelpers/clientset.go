@@ -4,7 +4,10 @@
 package testhelpers
 
 import (
+	"sort"
+
 	kpackfakes "github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
+	"github.com/pkg/errors"
 	k8sfakes "k8s.io/client-go/kubernetes/fake"
 
 	"github.com/buildpacks-community/kpack-cli/pkg/k8s"
@@ -55,3 +58,46 @@ func GetFakeClusterProvider(k8sClient *k8sfakes.Clientset, kpackClient *kpackfak
 		},
 	}
 }
+
+func GetFakeProvider(k8sClient *k8sfakes.Clientset, kpackClient *kpackfakes.Clientset, namespace string) FakeClientSetProvider {
+	return FakeClientSetProvider{
+		clientSet: k8s.ClientSet{
+			K8sClient:   k8sClient,
+			KpackClient: kpackClient,
+			Namespace:   namespace,
+		},
+	}
+}
+
+// FakeContextClientSetProvider provides a clientset for each of its contexts
+// and uses DefaultProvider when no context is selected.
+type FakeContextClientSetProvider struct {
+	DefaultProvider k8s.ClientSetProvider
+	Providers       map[string]k8s.ClientSetProvider
+}
+
+func (f FakeContextClientSetProvider) GetClientSet(namespace string) (k8s.ClientSet, error) {
+	return f.DefaultProvider.GetClientSet(namespace)
+}
+
+func (f FakeContextClientSetProvider) Contexts() ([]string, error) {
+	contexts := make([]string, 0, len(f.Providers))
+	for name := range f.Providers {
+		contexts = append(contexts, name)
+	}
+	sort.Strings(contexts)
+	return contexts, nil
+}
+
+func (f FakeContextClientSetProvider) ForContext(context string) k8s.ClientSetProvider {
+	if p, ok := f.Providers[context]; ok {
+		return p
+	}
+	return missingContextProvider(context)
+}
+
+type missingContextProvider string
+
+func (m missingContextProvider) GetClientSet(string) (k8s.ClientSet, error) {
+	return k8s.ClientSet{}, errors.Errorf("Kubernetes context %q not found", string(m))
+}
@@ -0,0 +1,100 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package testhelpers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-billy/v5/osfs"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/format/pktline"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/server"
+	ggcrregistry "github.com/google/go-containerregistry/pkg/registry"
+	"github.com/stretchr/testify/require"
+)
+
+// NewBasicAuthRegistry starts an in-memory registry that requires the basic
+// auth credentials and returns its host.
+func NewBasicAuthRegistry(t *testing.T, username, password string) string {
+	t.Helper()
+
+	srv := httptest.NewServer(basicAuth(username, password, ggcrregistry.New()))
+	t.Cleanup(srv.Close)
+
+	uri, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+	return uri.Host
+}
+
+// NewGitServer starts a git server over HTTP that requires the basic auth
+// credentials and serves a repository with one commit on every path. It only
+// supports listing references, as done by "git ls-remote", and returns the
+// server url.
+func NewGitServer(t *testing.T, username, password string) string {
+	t.Helper()
+
+	repoPath := t.TempDir()
+	repo, err := gogit.PlainInit(repoPath, false)
+	require.NoError(t, err)
+
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+	_, err = worktree.Commit("first", &gogit.CommitOptions{
+		AllowEmptyCommits: true,
+		Author:            &object.Signature{Name: "some-author", Email: "some@example.com", When: time.Now()},
+	})
+	require.NoError(t, err)
+
+	loader := server.NewFilesystemLoader(osfs.New(filepath.Join(repoPath, ".git")))
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("service") != transport.UploadPackServiceName {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		ep, err := transport.NewEndpoint("/")
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		session, err := server.NewServer(loader).NewUploadPackSession(ep, nil)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		refs, err := session.AdvertisedReferencesContext(r.Context())
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		refs.Prefix = [][]byte{[]byte("# service=" + transport.UploadPackServiceName), pktline.Flush}
+
+		w.Header().Set("Content-Type", "application/x-git-upload-pack-advertisement")
+		_ = refs.Encode(w)
+	})
+
+	srv := httptest.NewServer(basicAuth(username, password, handler))
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func basicAuth(username, password string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if u, p, ok := r.BasicAuth(); !ok || u != username || p != password {
+			w.Header().Set("WWW-Authenticate", `Basic realm="test"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
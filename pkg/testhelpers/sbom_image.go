@@ -0,0 +1,66 @@
+// Copyright 2020-Present VMware, Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package testhelpers
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"testing"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/pivotal/kpack/pkg/registry/imagehelpers"
+	"github.com/stretchr/testify/require"
+)
+
+// MakeSBOMImage returns an app image with an SBOM layer holding the files,
+// keyed by their path relative to the launch SBOM directory, as exported by
+// the lifecycle.
+func MakeSBOMImage(t *testing.T, files map[string]string) v1.Image {
+	t.Helper()
+
+	var paths []string
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+	for _, path := range paths {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name:     "/layers/sbom/launch/" + path,
+			Typeflag: tar.TypeReg,
+			Mode:     0644,
+			Size:     int64(len(files[path])),
+		}))
+		_, err := tw.Write([]byte(files[path]))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+
+	layer, err := tarball.LayerFromOpener(func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(buf.Bytes())), nil
+	})
+	require.NoError(t, err)
+
+	image, err := random.Image(10, 1)
+	require.NoError(t, err)
+
+	image, err = mutate.AppendLayers(image, layer)
+	require.NoError(t, err)
+
+	diffID, err := layer.DiffID()
+	require.NoError(t, err)
+
+	image, err = imagehelpers.SetStringLabel(image, "io.buildpacks.lifecycle.metadata", fmt.Sprintf(`{"sbom":{"sha":%q}}`, diffID.String()))
+	require.NoError(t, err)
+
+	return image
+}